@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+	"golang.org/x/term"
+)
+
+// handleChatBroadcast sends one message to several chats:
+// chat broadcast <id,id,...|@label> <text> [--yes]. --label <label> works in
+// place of @label.
+func handleChatBroadcast(args []string) error {
+	const usage = "usage: chat broadcast <id,id,...|@label|--label <label>> <text> [--yes]"
+
+	yes := false
+	label := ""
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--yes", "-y":
+			yes = true
+		case "--label":
+			if i+1 >= len(args) || label != "" {
+				return usagef("%s", usage)
+			}
+			label = args[i+1]
+			i++
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	var ids []string
+	if label == "" {
+		if len(rest) == 0 {
+			return usagef("%s", usage)
+		}
+		ids, label = chat.ParseBroadcastTargets(rest[0])
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		return usagef("%s", usage)
+	}
+
+	targets, err := dmInstance.BroadcastTargets(ids, label)
+	if err != nil {
+		return err
+	}
+	text := strings.Join(rest, " ")
+
+	if len(targets) > chat.BroadcastConfirmAbove() && !yes {
+		ok, err := confirmBroadcast(targets)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Broadcast cancelled")
+			return nil
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	sent := 0
+	dmInstance.Broadcast(ctx, targets, text, func(result chat.BroadcastResult) {
+		if result.Err != nil {
+			fmt.Printf("  ✗ %s [%s]: %v\n", result.Chat.Title, result.Chat.InternalID, result.Err)
+			return
+		}
+		sent++
+		fmt.Printf("  ✓ %s [%s]\n", result.Chat.Title, result.Chat.InternalID)
+	})
+
+	fmt.Printf("Sent to %d of %d chats\n", sent, len(targets))
+	if sent < len(targets) {
+		return fmt.Errorf("broadcast failed for %d chats", len(targets)-sent)
+	}
+	return nil
+}
+
+// confirmBroadcast asks before sending to a lot of chats. Without a terminal
+// to ask on, --yes is required.
+func confirmBroadcast(targets []*chat.Chat) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("refusing to send to %d chats without --yes", len(targets))
+	}
+
+	titles := make([]string, len(targets))
+	for i, target := range targets {
+		titles[i] = target.Title
+	}
+	fmt.Printf("Send to %d chats (%s)? (y/N): ", len(targets), strings.Join(titles, ", "))
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read answer: %v", err)
+	}
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// Exit codes for one-shot mode
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotLogin = 3
+)
+
+// errNotLoggedIn is returned by commands that need a session when there isn't one
+var errNotLoggedIn = errors.New("not logged in. Use 'login' first")
+
+// usageError marks bad invocations so one-shot mode can exit with exitUsage
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Command is a node in the ig-cli command tree. The shell and one-shot mode
+// both dispatch through the same tree.
+type Command struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	Description string
+	NeedsLogin  bool
+	Hidden      bool
+	Private     bool // its arguments include message text, kept out of the history file
+	Run         func(args []string) error
+	Subcommands []*Command
+}
+
+// matches reports whether name refers to this command
+func (c *Command) matches(name string) bool {
+	if c.Name == name {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findCommand looks up a command by name among siblings
+func findCommand(commands []*Command, name string) *Command {
+	name = strings.ToLower(name)
+	for _, cmd := range commands {
+		if cmd.matches(name) {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// isPrivate reports whether line runs a Private command. A line that can't
+// be parsed counts as private, since it may still hold message text.
+func isPrivate(commands []*Command, line string) bool {
+	args, err := splitArgs(line)
+	if err != nil {
+		return true
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	cmd := findCommand(commands, args[0])
+	for _, arg := range args[1:] {
+		if cmd == nil {
+			break
+		}
+		sub := findCommand(cmd.Subcommands, arg)
+		if sub == nil {
+			break
+		}
+		cmd = sub
+	}
+	return cmd != nil && cmd.Private
+}
+
+// dispatch walks the tree with args and runs the deepest matching command
+func dispatch(commands []*Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	cmd := findCommand(commands, args[0])
+	if cmd == nil {
+		return usagef("unknown command: %s. Type 'help' for available commands", args[0])
+	}
+
+	return runCommand(cmd, args[0], args[1:])
+}
+
+// runCommand runs cmd, descending into subcommands when the next arg names one
+func runCommand(cmd *Command, path string, args []string) error {
+	if cmd.NeedsLogin {
+		if err := ensureSession(); err != nil {
+			return err
+		}
+	}
+
+	if len(args) > 0 && len(cmd.Subcommands) > 0 {
+		if sub := findCommand(cmd.Subcommands, args[0]); sub != nil {
+			return runCommand(sub, path+" "+sub.Name, args[1:])
+		}
+	}
+
+	if cmd.Run != nil {
+		return cmd.Run(args)
+	}
+
+	if len(args) > 0 {
+		printCommandUsage(cmd, path)
+		return usagef("unknown %s command: %s", path, args[0])
+	}
+
+	printCommandUsage(cmd, path)
+	return nil
+}
+
+// printCommandUsage lists a command's subcommands
+func printCommandUsage(cmd *Command, path string) {
+	fmt.Printf("Usage: %s <command>\n", path)
+	for _, sub := range cmd.Subcommands {
+		if sub.Hidden {
+			continue
+		}
+		fmt.Printf("  %-28s - %s\n", sub.Name+usageSuffix(sub), sub.Description)
+	}
+}
+
+// printHelp prints the whole tree, one line per runnable command
+func printHelp(commands []*Command, prefix string) {
+	for _, cmd := range commands {
+		if cmd.Hidden {
+			continue
+		}
+		name := strings.TrimSpace(prefix + " " + cmd.Name)
+		if cmd.Run != nil || len(cmd.Subcommands) == 0 {
+			fmt.Printf("  %-36s - %s\n", name+usageSuffix(cmd), cmd.Description)
+		}
+		printHelp(cmd.Subcommands, name)
+	}
+}
+
+func usageSuffix(cmd *Command) string {
+	if cmd.Usage == "" {
+		return ""
+	}
+	return " " + cmd.Usage
+}
+
+// splitArgs splits a shell line into arguments, honoring single and double quotes
+// and backslash escapes so `chat send 100003 "deploy done"` works in the shell too
+func splitArgs(input string) ([]string, error) {
+	return splitWords(input, nil)
+}
+
+// splitWords is splitArgs that also expands $NAME, ${NAME}, $? and $# outside
+// single quotes with lookup, when it isn't nil. A variable's value always
+// stays inside the argument it appears in, whatever quotes or spaces it holds.
+func splitWords(input string, lookup func(name string) string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	escaped := false
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		i += size
+
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case r == '$' && quote != '\'' && lookup != nil:
+			name, width := variableName(input[i:])
+			if width == 0 {
+				current.WriteRune(r)
+			} else {
+				current.WriteString(lookup(name))
+				i += width
+			}
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, usagef("unterminated quote in command")
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// variableName parses the name after a '$', returning it and the bytes consumed
+func variableName(rest string) (string, int) {
+	if rest == "" {
+		return "", 0
+	}
+
+	if rest[0] == '{' {
+		end := strings.IndexByte(rest, '}')
+		if end <= 1 {
+			return "", 0
+		}
+		return rest[1:end], end + 1
+	}
+
+	if rest[0] == '?' || rest[0] == '#' {
+		return rest[:1], 1
+	}
+
+	n := 0
+	for n < len(rest) && (rest[n] == '_' || isAlnum(rest[n])) {
+		n++
+	}
+	return rest[:n], n
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// exitCodeFor maps a command error onto a process exit code
+func exitCodeFor(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var usage *usageError
+	if errors.As(err, &usage) {
+		return exitUsage
+	}
+	if errors.Is(err, errNotLoggedIn) {
+		return exitNotLogin
+	}
+	return exitError
+}
+
+// parseOutputFlag pulls --output/-o out of args, defaulting to a table
+func parseOutputFlag(args []string) (output.Format, []string, error) {
+	format := output.FormatTable
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		var value string
+
+		switch {
+		case arg == "--output" || arg == "-o":
+			if i+1 >= len(args) {
+				return "", nil, usagef("%s needs a value: json, yaml, table or tsv", arg)
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(arg, "--output="):
+			value = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "-o="):
+			value = strings.TrimPrefix(arg, "-o=")
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		parsed, err := output.ParseFormat(value)
+		if err != nil {
+			return "", nil, &usageError{msg: err.Error()}
+		}
+		format = parsed
+	}
+
+	return format, rest, nil
+}
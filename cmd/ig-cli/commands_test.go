@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected []string
+		usage    bool // expect a usage error
+	}{
+		{``, nil, false},
+		{`  chat   list  `, []string{"chat", "list"}, false},
+		{"chat\tsend\t100003", []string{"chat", "send", "100003"}, false},
+		{`chat send 100003 "deploy done"`, []string{"chat", "send", "100003", "deploy done"}, false},
+		{`echo 'single "quoted"' "double 'quoted'"`, []string{"echo", `single "quoted"`, `double 'quoted'`}, false},
+		{`echo pre"mid dle"post`, []string{"echo", "premid dlepost"}, false},
+		{`echo "" ''`, []string{"echo", "", ""}, false},
+		{`echo a\ b \"c\"`, []string{"echo", "a b", `"c"`}, false},
+		{`echo "say \"hi\""`, []string{"echo", `say "hi"`}, false},
+		{`echo 'back\slash'`, []string{"echo", `back\slash`}, false},
+		{`echo trailing\`, []string{"echo", `trailing\`}, false},
+		{`echo $HOME`, []string{"echo", "$HOME"}, false},
+		{`echo "héllo wörld"`, []string{"echo", "héllo wörld"}, false},
+		{`echo "unterminated`, nil, true},
+		{`echo 'unterminated`, nil, true},
+	}
+
+	for _, tc := range testCases {
+		args, err := splitArgs(tc.line)
+		if tc.usage {
+			if exitCodeFor(err) != exitUsage {
+				t.Errorf("%s: expected a usage error, got %q, %v", tc.line, args, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, tc.expected) {
+			t.Errorf("%s: expected %q, got %q", tc.line, tc.expected, args)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	var ran string
+	var ranArgs []string
+	run := func(name string) func([]string) error {
+		return func(args []string) error {
+			ran, ranArgs = name, args
+			return nil
+		}
+	}
+
+	commands := []*Command{
+		{Name: "status", Run: run("status")},
+		{Name: "chat", Aliases: []string{"c"}, Run: run("chat"), Subcommands: []*Command{
+			{Name: "send", Run: func(args []string) error {
+				if len(args) < 2 {
+					return usagef("usage: chat send <id> <text>")
+				}
+				ran, ranArgs = "chat send", args
+				return nil
+			}},
+		}},
+		{Name: "config", Subcommands: []*Command{
+			{Name: "get", Run: run("config get")},
+		}},
+		{Name: "fail", Run: func([]string) error { return errors.New("boom") }},
+	}
+
+	testCases := []struct {
+		args     []string
+		code     int
+		ran      string
+		expected []string
+	}{
+		{nil, exitOK, "", nil},
+		{[]string{"status"}, exitOK, "status", []string{}},
+		{[]string{"STATUS"}, exitOK, "status", []string{}},
+		{[]string{"chat", "100003"}, exitOK, "chat", []string{"100003"}},
+		{[]string{"c", "send", "100003", "hi"}, exitOK, "chat send", []string{"100003", "hi"}},
+		{[]string{"chat", "send", "100003"}, exitUsage, "", nil},
+		{[]string{"config", "get", "language"}, exitOK, "config get", []string{"language"}},
+		{[]string{"config"}, exitOK, "", nil},
+		{[]string{"config", "nope"}, exitUsage, "", nil},
+		{[]string{"nope"}, exitUsage, "", nil},
+		{[]string{"fail"}, exitError, "", nil},
+	}
+
+	for _, tc := range testCases {
+		ran, ranArgs = "", nil
+		err := dispatch(commands, tc.args)
+		if code := exitCodeFor(err); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d (%v)", tc.args, tc.code, code, err)
+		}
+		if ran != tc.ran || !reflect.DeepEqual(ranArgs, tc.expected) {
+			t.Errorf("%q: expected %q to run with %q, got %q with %q", tc.args, tc.ran, tc.expected, ran, ranArgs)
+		}
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code int
+	}{
+		{nil, exitOK},
+		{errors.New("failed to send message"), exitError},
+		{usagef("usage: chat send <id> <text>"), exitUsage},
+		{fmt.Errorf("chat list: %w", usagef("bad flag")), exitUsage},
+		{errNotLoggedIn, exitNotLogin},
+		{fmt.Errorf("failed to load session: %w", errNotLoggedIn), exitNotLogin},
+	}
+
+	for _, tc := range testCases {
+		if code := exitCodeFor(tc.err); code != tc.code {
+			t.Errorf("exitCodeFor(%v) = %d, expected %d", tc.err, code, tc.code)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/export"
+)
+
+// handleChatExport writes a chat's history to a file:
+// chat export <id> [--format json|md|html|txt] [--since date] [--until date] [--out path] [--media]
+func handleChatExport(args []string) error {
+	const usage = "usage: chat export <id> [--format json|md|html|txt] [--since <date|age>] [--until <date|age>] [--out <path>] [--media]"
+
+	if len(args) < 1 {
+		return usagef(usage)
+	}
+	chatID := args[0]
+
+	format := export.FormatJSON
+	var since, until time.Time
+	var out string
+	download := false
+	now := time.Now()
+
+	for i := 1; i < len(args); i++ {
+		flag := args[i]
+		if flag == "--media" {
+			download = true
+			continue
+		}
+		if i+1 >= len(args) {
+			return usagef(usage)
+		}
+		value := args[i+1]
+		i++
+
+		switch flag {
+		case "--format", "-f":
+			f, err := export.ParseFormat(value)
+			if err != nil {
+				return usagef("%v", err)
+			}
+			format = f
+		case "--since", "--until":
+			at, err := parseDate(value, flag == "--until", now)
+			if err != nil {
+				return usagef("invalid %s: %v", flag, err)
+			}
+			if flag == "--since" {
+				since = at
+			} else {
+				until = at
+			}
+		case "--out":
+			out = value
+		default:
+			return usagef(usage)
+		}
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return usagef("--since must be before --until")
+	}
+
+	c, err := dmInstance.GetChatByInternalID(chatID)
+	if err != nil {
+		return fmt.Errorf("failed to get chat: %v", err)
+	}
+
+	if out == "" {
+		cfg := config.GetInstance()
+		dir := cfg.GetString("advanced.generated_dir", filepath.Join(cfg.GetConfigDir(), "generated"))
+		out = filepath.Join(dir, "exports", export.FileName(c, format, now))
+	}
+
+	messages, err := dmInstance.ChatHistoryRange(chatID, since, until, download, func(n int) {
+		fmt.Fprintf(os.Stderr, "\rFetched %d messages...", n)
+	})
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return fmt.Errorf("failed to get chat history: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %v", err)
+	}
+	file, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %v", err)
+	}
+	defer file.Close()
+
+	opts := export.Options{Dir: filepath.Dir(out), Generated: now, Since: since, Until: until}
+	if err := export.Write(file, format, c, messages, opts); err != nil {
+		return fmt.Errorf("failed to write export: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export: %v", err)
+	}
+
+	fmt.Printf("Exported %d messages from %s to %s\n", len(messages), c.Title, out)
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+)
+
+// handleGroupCreate starts a group: chat group create @user @user... [title]
+func handleGroupCreate(args []string) error {
+	usernames, title := chat.ParseGroupArgs(args)
+	if len(usernames) < 2 {
+		return usagef("usage: chat group create @user @user... [title]")
+	}
+
+	group, err := dmInstance.CreateGroup(usernames, title)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created group %s [%s]\n", group.Title, group.InternalID)
+	return nil
+}
+
+// handleGroupRename sets a group's title
+func handleGroupRename(args []string) error {
+	if len(args) < 2 {
+		return usagef("usage: chat group rename <id> <title>")
+	}
+
+	title := strings.Join(args[1:], " ")
+	if err := dmInstance.RenameGroup(args[0], title); err != nil {
+		return err
+	}
+	fmt.Printf("Renamed group to %s\n", title)
+	return nil
+}
+
+// handleGroupAdd adds members to a group
+func handleGroupAdd(args []string) error {
+	if len(args) < 2 {
+		return usagef("usage: chat group add <id> <username>...")
+	}
+
+	if err := dmInstance.AddGroupMembers(args[0], args[1:]); err != nil {
+		return err
+	}
+	fmt.Printf("Added %s\n", strings.Join(args[1:], ", "))
+	return nil
+}
+
+// handleGroupRemove removes a member from a group
+func handleGroupRemove(args []string) error {
+	if len(args) != 2 {
+		return usagef("usage: chat group remove <id> <username>")
+	}
+
+	if err := dmInstance.RemoveGroupMember(args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %s\n", strings.TrimPrefix(args[1], "@"))
+	return nil
+}
+
+// handleGroupLeave leaves a group
+func handleGroupLeave(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: chat group leave <id>")
+	}
+
+	if err := dmInstance.LeaveGroup(args[0]); err != nil {
+		return err
+	}
+	fmt.Println("Left the group")
+	return nil
+}
+
+// handleGroupMute mutes or unmutes a chat on Instagram
+func handleGroupMute(muted bool) func([]string) error {
+	return func(args []string) error {
+		if len(args) != 1 {
+			if muted {
+				return usagef("usage: chat group mute <id>")
+			}
+			return usagef("usage: chat group unmute <id>")
+		}
+
+		if err := dmInstance.SetMuted(args[0], muted); err != nil {
+			return err
+		}
+		if muted {
+			fmt.Println("Chat muted")
+		} else {
+			fmt.Println("Chat unmuted")
+		}
+		return nil
+	}
+}
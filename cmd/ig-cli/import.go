@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// handleImportArchive merges an Instagram data download into the local
+// message history: import archive <zip|dir> [-o format]
+func handleImportArchive(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usagef("usage: import archive <zip|dir> [-o format]")
+	}
+
+	threads, err := dmInstance.ImportArchive(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to import archive: %v", err)
+	}
+
+	records := make([]output.ImportRecord, 0, len(threads))
+	added, unmatched := 0, 0
+	for _, t := range threads {
+		records = append(records, t.Record())
+		added += t.Added
+		if !t.Matched {
+			unmatched++
+		}
+	}
+
+	if err := output.Write(os.Stdout, format, records, output.ImportTable(records)); err != nil {
+		return err
+	}
+	if format == output.FormatTable {
+		fmt.Printf("\nImported %d new messages from %d chats.", added, len(threads))
+		if unmatched > 0 {
+			fmt.Printf(" %d chats aren't in your inbox; their history is kept under the IDs above.", unmatched)
+		}
+		fmt.Println()
+	}
+	return nil
+}
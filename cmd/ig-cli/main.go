@@ -2,20 +2,28 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/abhi-praj/GoGram/internal/auth"
 	"github.com/abhi-praj/GoGram/internal/chat"
 	"github.com/abhi-praj/GoGram/internal/client"
 	"github.com/abhi-praj/GoGram/internal/config"
 	grpcserver "github.com/abhi-praj/GoGram/internal/grpc"
+	"github.com/abhi-praj/GoGram/internal/output"
 	"github.com/rivo/tview"
+	"golang.org/x/term"
 )
 
 var (
@@ -27,26 +35,50 @@ var (
 	// Command line flags
 	grpcMode    = flag.Bool("grpc", false, "Run in gRPC server mode")
 	grpcAddress = flag.String("grpc-address", ":50051", "gRPC server address")
+	execScript  = flag.String("exec", "", "Run shell commands from a script file and exit")
 )
 
 func main() {
 	flag.Parse()
 
-	displayTitle()
-
 	// Initialize auth
 	authInstance = auth.NewInstagramAuth()
 
 	// Check if gRPC mode is requested
 	if *grpcMode {
+		displayTitle()
 		startGRPCServer()
 		return
 	}
 
+	// Run a script from a file, or from stdin with "-"
+	if *execScript != "" {
+		os.Exit(runScriptFile(*execScript, flag.Args()))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "-" {
+		os.Exit(newScriptRunner("<stdin>", flag.Args()[1:]).run(os.Stdin))
+	}
+
+	// Run a single command and exit when arguments are given
+	if flag.NArg() > 0 {
+		os.Exit(runOneShot(flag.Args()))
+	}
+
+	displayTitle()
+
 	// Start interactive shell
 	startShell()
 }
 
+// runOneShot runs one command non-interactively and returns the exit code
+func runOneShot(args []string) int {
+	err := dispatch(commandTree(), args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	return exitCodeFor(err)
+}
+
 func displayTitle() {
 	fmt.Print(`
    ██████╗  ██████╗   ██████╗ ██████╗  █████╗ ███╗   ███╗
@@ -63,24 +95,37 @@ func displayTitle() {
 }
 
 func startShell() {
-	reader := bufio.NewReader(os.Stdin)
+	reader := newLineReader()
+	defer reader.Close()
+
+	notify := func(text string) { fmt.Print(text) }
+	if tty, ok := reader.(*ttyReader); ok {
+		notify = tty.Notify
+	}
 
 	for {
-		fmt.Print("ig-cli> ")
-		input, err := reader.ReadString('\n')
+		// Send scheduled messages and retry queued ones once logged in
+		startScheduler(notify)
+		startOutbox(notify)
+
+		// Print notifications above the prompt while a line is being edited
+		if dmInstance != nil {
+			dmInstance.SetNotificationHandler(func(c *chat.Chat, msg *chat.Message) {
+				notify(chat.FormatNotification(c, msg))
+			})
+		}
+		if _, ok := reader.(*ttyReader); !ok {
+			fmt.Print(shellPrompt)
+		}
+
+		input, err := reader.ReadLine()
 		if err != nil {
-			if err.Error() == "EOF" {
-				fmt.Println("\nReceived EOF. This usually means stdin was closed.")
-				fmt.Println("Attempting to recover...")
-
-				// Try to recreate the reader
-				reader = bufio.NewReader(os.Stdin)
-				fmt.Println("Reader recreated. Please try your command again.")
-				continue
+			if err == io.EOF {
+				fmt.Println("\nGoodbye!")
+				return
 			}
-			fmt.Printf("Error reading input: %v\n", input)
-			fmt.Println("Continuing... Press Enter to continue or Ctrl+C to exit.")
-			continue
+			fmt.Printf("Error reading input: %v\n", err)
+			return
 		}
 
 		input = strings.TrimSpace(input)
@@ -94,6 +139,10 @@ func startShell() {
 			break
 		}
 
+		if dmInstance != nil {
+			dmInstance.SetNotificationHandler(nil)
+		}
+
 		// Parse and execute command
 		if err := executeCommand(input); err != nil {
 			fmt.Printf("Error: %v\n", err)
@@ -102,59 +151,192 @@ func startShell() {
 }
 
 func executeCommand(input string) error {
-	parts := strings.Fields(input)
-	if len(parts) == 0 {
+	args, err := splitArgs(input)
+	if err != nil {
+		return err
+	}
+
+	return dispatch(commandTree(), args)
+}
+
+// commandTree builds the command tree shared by the shell and one-shot mode
+func commandTree() []*Command {
+	return []*Command{
+		{Name: "help", Description: "Show this help message", Run: func(args []string) error {
+			showHelp()
+			return nil
+		}},
+		{Name: "version", Description: "Show version information", Run: func(args []string) error {
+			fmt.Printf("GoGram v%s\n", version)
+			return nil
+		}},
+		{Name: "login", Description: "Login to Instagram", Hidden: true, Run: noArgs(handleLogin)},
+		{Name: "logout", Description: "Logout from Instagram", Hidden: true, Run: noArgs(handleLogout)},
+		{Name: "status", Usage: "[-o format]", Description: "Show current login status", Hidden: true, Run: showStatus},
+		{
+			Name:        "auth",
+			Description: "Authentication commands",
+			Subcommands: []*Command{
+				{Name: "login", Description: "Login to Instagram", Run: noArgs(handleLogin)},
+				{Name: "logout", Description: "Logout from Instagram", Run: noArgs(handleLogout)},
+				{Name: "status", Usage: "[-o format]", Description: "Show current login status", Run: showStatus},
+				{Name: "migrate-sessions", Description: "Encrypt saved sessions stored in plaintext", Run: noArgs(migrateSessions)},
+			},
+		},
+		{
+			Name:        "chat",
+			Usage:       "<id>",
+			Description: "Open interactive chat with chat ID",
+			NeedsLogin:  true,
+			Run:         handleChatCommand,
+			Subcommands: []*Command{
+				{Name: "list", Usage: "[all] [--pinned] [--muted] [--archived] [--label <label>] [-o format]", Description: "List recent chats (last 5, or all); archived chats only with --archived", Run: handleChatList},
+				{Name: "new", Usage: "@username [message]", Description: "Start a chat with someone and open it", Private: true, Run: handleChatNew},
+				{Name: "history", Usage: "<id> [limit] [-o format]", Description: "Print recent messages in a chat", Run: handleChatHistory},
+				{Name: "export", Usage: "<id> [--format json|md|html|txt] [--since date] [--until date] [--out path] [--media]", Description: "Save a chat's full history to a file", Run: handleChatExport},
+				{Name: "send", Usage: "<id> <message>|-|--file <path>", Description: "Send a message, stdin lines or a file to a chat", Private: true, Run: handleChatSend},
+				{Name: "broadcast", Usage: "<id,id,...|@label> <text> [--yes]", Description: "Send a message to several chats", Private: true, Run: handleChatBroadcast},
+				{Name: "send-media", Usage: "<id> <path>", Description: "Send a photo or video to a chat", Run: handleChatSendMedia},
+				{Name: "react", Usage: "<id> <message-id> [emoji]", Description: "React to a message (default ❤️)", Run: handleChatReact},
+				{Name: "unreact", Usage: "<id> <message-id>", Description: "Remove your reaction from a message", Run: handleChatUnreact},
+				{Name: "search", Usage: "<query> [-o format]", Description: "Search chats by title or username", Run: handleChatSearch},
+				{Name: "pin", Usage: "<id> [on|off]", Description: "Keep a chat at the top of the chat list", Run: handleChatPin},
+				{Name: "mute", Usage: "<id> [on|off]", Description: "Silence a chat's notifications in GoGram only", Run: handleChatMute},
+				{Name: "archive", Usage: "<id> [on|off]", Description: "Hide a chat from the chat list", Run: handleChatArchive},
+				{Name: "label", Usage: "[<id> [label|+label|-label ...]]", Description: "Show or change a chat's labels, or list all labels", Run: handleChatLabel},
+				{
+					Name:        "schedule",
+					Usage:       "<id> [time|+duration] <text>",
+					Description: "Send a message later (e.g. +30m, 18:00, 2025-01-31T09:00)",
+					Private:     true,
+					Run:         handleChatSchedule,
+					Subcommands: []*Command{
+						{Name: "list", Usage: "[all] [-o format]", Description: "List scheduled messages", Run: handleScheduleList},
+						{Name: "cancel", Usage: "<schedule-id>", Description: "Cancel a scheduled message or dismiss a missed one", Run: handleScheduleCancel},
+					},
+				},
+				{
+					Name:        "group",
+					Description: "Create and manage group chats",
+					Subcommands: []*Command{
+						{Name: "create", Usage: "@user @user... [title]", Description: "Start a group chat", Run: handleGroupCreate},
+						{Name: "rename", Usage: "<id> <title>", Description: "Rename a group", Run: handleGroupRename},
+						{Name: "add", Usage: "<id> <username>...", Description: "Add members to a group", Run: handleGroupAdd},
+						{Name: "remove", Usage: "<id> <username>", Description: "Remove a member from a group", Run: handleGroupRemove},
+						{Name: "leave", Usage: "<id>", Description: "Leave a group", Run: handleGroupLeave},
+						{Name: "mute", Usage: "<id>", Description: "Mute a chat on Instagram", Run: handleGroupMute(true)},
+						{Name: "unmute", Usage: "<id>", Description: "Unmute a chat on Instagram", Run: handleGroupMute(false)},
+					},
+				},
+				{
+					Name:        "requests",
+					Usage:       "[-o format]",
+					Description: "List message requests from people you don't follow",
+					Run:         handleRequestsList,
+					Subcommands: []*Command{
+						{Name: "show", Usage: "<id> [limit] [-o format]", Description: "Read a request without marking it seen", Run: handleRequestsShow},
+						{Name: "accept", Usage: "<id>", Description: "Accept a request and move it to your inbox", Run: handleRequestsAccept},
+						{Name: "decline", Usage: "<id>", Description: "Delete a request", Run: handleRequestsDecline},
+						{Name: "block", Usage: "<id>", Description: "Block the sender and delete the request", Run: handleRequestsBlock},
+					},
+				},
+				{Name: "start", Description: "Start the new chat interface", Run: noArgs(startNewChatInterface)},
+			},
+		},
+		{
+			Name:        "notifications",
+			Description: "Background message notifications",
+			NeedsLogin:  true,
+			Subcommands: []*Command{
+				{Name: "start", Description: "Start background message notifications", Run: noArgs(startNotifications)},
+				{Name: "stop", Description: "Stop background message notifications", Run: noArgs(stopNotifications)},
+				{Name: "status", Description: "Check notification status", Run: noArgs(notificationStatus)},
+				{Name: "watch", Usage: "[-o format]", Description: "Print notifications until interrupted", Run: watchNotifications},
+			},
+		},
+		{
+			Name:        "outbox",
+			Description: "Messages waiting to be sent",
+			NeedsLogin:  true,
+			Subcommands: []*Command{
+				{Name: "list", Usage: "[all] [-o format]", Description: "List queued messages", Run: handleOutboxList},
+				{Name: "retry", Usage: "[outbox-id|all]", Description: "Try sending queued messages again now", Run: handleOutboxRetry},
+				{Name: "drop", Usage: "<outbox-id>", Description: "Remove a queued message without sending it", Run: handleOutboxDrop},
+			},
+		},
+		{
+			Name:        "media",
+			Description: "Downloaded media",
+			NeedsLogin:  true,
+			Subcommands: []*Command{
+				{Name: "list", Usage: "[id] [-o format]", Description: "List downloaded media", Run: handleMediaList},
+				{Name: "open", Usage: "<message-id|path>", Description: "Open a downloaded file", Run: handleMediaOpen},
+				{Name: "download", Usage: "<id> [limit]", Description: "Download media from a chat's recent messages", Run: handleMediaDownload},
+				{Name: "prune", Usage: "[--older-than 30d] [--chat id]", Description: "Delete old downloads", Run: handleMediaPrune},
+				{Name: "auto", Usage: "<id> [on|off|default]", Description: "Show or set a chat's auto-download policy", Run: handleMediaAuto},
+			},
+		},
+		{
+			Name:        "import",
+			Description: "Bring in history from elsewhere",
+			NeedsLogin:  true,
+			Subcommands: []*Command{
+				{Name: "archive", Usage: "<zip|dir> [-o format]", Description: "Import messages from Instagram's \"Download your information\" export", Run: handleImportArchive},
+			},
+		},
+		{
+			Name:        "config",
+			Description: "Configuration commands",
+			Subcommands: []*Command{
+				{Name: "list", Usage: "[-o format]", Description: "List configuration values", Run: handleConfigList},
+				{Name: "get", Usage: "<key>", Description: "Get configuration value", Run: handleConfigGet},
+				{Name: "set", Usage: "<key> <val>", Description: "Set configuration value", Run: handleConfigSet},
+			},
+		},
+		{Name: "search", Usage: "<query> [--chat id] [--from user] [--since date] [--until date] [--media] [-o format]", Description: "Search message history stored on this computer", NeedsLogin: true, Run: handleSearch},
+		{Name: "wait-for-message", Usage: "<id> <pattern> <timeout>", Description: "Wait for a reply matching a regular expression", NeedsLogin: true, Run: handleWaitForMessage},
+		{Name: "clear", Description: "Clear screen", Run: func(args []string) error {
+			clearScreen()
+			return nil
+		}},
+	}
+}
+
+// noArgs adapts a handler that takes no arguments
+func noArgs(fn func() error) func([]string) error {
+	return func(args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		return fn()
+	}
+}
+
+// ensureSession logs in from the saved session when a command needs one
+func ensureSession() error {
+	if clientInstance != nil {
 		return nil
 	}
 
-	command := strings.ToLower(parts[0])
-	args := parts[1:]
-
-	switch command {
-	case "help":
-		showHelp()
-	case "version":
-		fmt.Printf("GoGram v%s\n", version)
-	case "login":
-		return handleLogin()
-	case "logout":
-		return handleLogout()
-	case "status":
-		showStatus()
-	case "chat":
-		return handleChatCommand(args)
-	case "config":
-		return handleConfigCommand(args)
-	case "clear":
-		clearScreen()
-	case "notifications":
-		return handleNotificationsCommand(args)
-	default:
-		fmt.Printf("Unknown command: %s. Type 'help' for available commands.\n", command)
+	client := client.NewClientWrapper("")
+	if err := client.LoginBySession(); err != nil {
+		return fmt.Errorf("%w (%v)", errNotLoggedIn, err)
 	}
 
+	clientInstance = client
+	dmInstance = chat.NewDirectMessages(client)
 	return nil
 }
 
 func showHelp() {
 	fmt.Println("Available commands:")
-	fmt.Println("  help                    - Show this help message")
-	fmt.Println("  version                 - Show version information")
-	fmt.Println("  login                   - Login to Instagram")
-	fmt.Println("  logout                  - Logout from Instagram")
-	fmt.Println("  status                  - Show current login status")
-	fmt.Println("  chat <id>               - Open interactive chat with chat ID")
-	fmt.Println("  chat list               - List recent chats (last 5)")
-	fmt.Println("  chat list all           - List all chats")
-	fmt.Println("  chat start              - Start the new chat interface")
-	fmt.Println("  notifications start     - Start background message notifications")
-	fmt.Println("  notifications stop      - Stop background message notifications")
-	fmt.Println("  notifications status      - Check notification status")
-	fmt.Println("  config list             - List configuration values")
-	fmt.Println("  config get <key>        - Get configuration value")
-	fmt.Println("  config set <key> <val>  - Set configuration value")
-	fmt.Println("  clear                   - Clear screen")
-	fmt.Println("  exit/quit               - Exit the application")
+	printHelp(commandTree(), "")
+	fmt.Printf("  %-36s - %s\n", "exit/quit", "Exit the application")
+	fmt.Println()
+	fmt.Println("One-shot mode:")
+	fmt.Println("  Any command can be run directly, e.g. ./ig-cli chat send 100003 \"deploy done\"")
+	fmt.Println("  Exit codes: 0 success, 1 error, 2 usage error, 3 not logged in")
+	fmt.Println("  Use -o/--output json|yaml|table|tsv for machine-readable output")
 	fmt.Println()
 	fmt.Println("gRPC Mode:")
 	fmt.Println("  Use --grpc flag to start in gRPC server mode")
@@ -186,14 +368,16 @@ func handleLogin() error {
 }
 
 func handleLogout() error {
-	if clientInstance == nil {
-		return fmt.Errorf("not logged in")
+	if clientInstance == nil && authInstance.GetCurrentUsername() == "" {
+		return errNotLoggedIn
 	}
 
 	// Stop notifications before logout
 	if dmInstance != nil {
 		fmt.Println("Stopping background message notifications...")
 		dmInstance.StopNotifications()
+		dmInstance.StopScheduler()
+		dmInstance.StopOutbox()
 	}
 
 	if err := authInstance.Logout(""); err != nil {
@@ -205,175 +389,501 @@ func handleLogout() error {
 	return nil
 }
 
-func showStatus() {
+// migrateSessions encrypts plaintext session files for every saved account
+func migrateSessions() error {
+	results, err := authInstance.MigrateSessions()
+	if err != nil {
+		return fmt.Errorf("failed to migrate sessions: %v", err)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No saved sessions found.")
+		return nil
+	}
+
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			failed++
+			fmt.Printf("❌ @%s: %v\n", result.Username, result.Err)
+		case result.Migrated:
+			fmt.Printf("✅ @%s: session encrypted\n", result.Username)
+		default:
+			fmt.Printf("@%s: already encrypted\n", result.Username)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d session(s) could not be migrated", failed)
+	}
+	return nil
+}
+
+func showStatus(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return usagef("usage: status [-o format]")
+	}
+
 	if clientInstance == nil {
-		fmt.Println("Status: Not logged in")
-		return
+		ensureSession()
 	}
 
-	fmt.Printf("Status: Logged in as @%s\n", clientInstance.GetUsername())
+	record := output.StatusRecord{InvisibleMode: client.InvisibleMode()}
+	if clientInstance != nil {
+		record.IsLoggedIn = true
+		record.Username = clientInstance.GetUsername()
+	}
 
 	// Show unread count if available
 	if dmInstance != nil {
 		if count, err := dmInstance.GetUnreadCount(); err == nil {
-			fmt.Printf("Unread messages: %d\n", count)
+			record.UnreadCount = count
 		}
+		record.NotificationsRunning = dmInstance.IsNotificationRunning()
+	}
+
+	if format != output.FormatTable {
+		return output.Write(os.Stdout, format, record, output.StatusTable(record))
+	}
+
+	if !record.IsLoggedIn {
+		fmt.Println("Status: Not logged in")
+		return nil
+	}
+
+	fmt.Printf("Status: Logged in as @%s\n", record.Username)
+	if dmInstance != nil {
+		fmt.Printf("Unread messages: %d\n", record.UnreadCount)
 
 		// Show notification status
-		if dmInstance.IsNotificationRunning() {
+		if record.NotificationsRunning {
 			fmt.Println("Background notifications: RUNNING")
 		} else {
 			fmt.Println("Background notifications: STOPPED")
 		}
 	}
+	if record.InvisibleMode {
+		fmt.Println("Invisible mode: ON (no seen receipts or typing indicators are sent)")
+	} else {
+		fmt.Println("Invisible mode: OFF")
+	}
+
+	return nil
 }
 
+// handleChatCommand opens an interactive chat when given a bare chat ID
 func handleChatCommand(args []string) error {
 	if len(args) == 0 {
-		fmt.Println("Usage: chat <command>")
-		fmt.Println("  <id>     - Open interactive chat with chat ID")
-		fmt.Println("  list     - List recent chats")
-		fmt.Println("  list all - List all chats")
-		fmt.Println("  start    - Start the new chat interface")
+		printCommandUsage(findCommand(commandTree(), "chat"), "chat")
 		return nil
 	}
+	if len(args) > 1 {
+		return usagef("unknown chat command: %s", args[0])
+	}
 
-	if clientInstance == nil {
-		return fmt.Errorf("not logged in. Use 'login' first.")
+	// just make it an interactive chat if theres an id and nothing else
+	return startInteractiveChat(args[0])
+}
+
+// handleChatNew starts or opens a chat with a user, sending message first if
+// given, and opens it when run from a terminal
+func handleChatNew(args []string) error {
+	if len(args) == 0 {
+		return usagef("usage: chat new @username [message]")
+	}
+
+	c, err := dmInstance.StartConversation(args[0], strings.Join(args[1:], " "))
+	var queued *chat.QueuedError
+	if errors.As(err, &queued) {
+		fmt.Printf("Message %v\n", err)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Printf("Chat with %s [%s]\n", c.Title, c.InternalID)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	return startInteractiveChat(c.InternalID)
+}
+
+// handleChatList lists recent chats, or all of them with "all"
+func handleChatList(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+
+	const usage = "usage: chat list [all] [--pinned] [--muted] [--archived] [--label <label>] [-o format]"
+
+	limit := 5
+	if len(args) > 0 && args[0] == "all" {
+		limit = 0 // 0 means no limit
+		args = args[1:]
+	}
+
+	var filter chat.ChatFilter
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--pinned":
+			filter.Pinned = true
+		case "--muted":
+			filter.Muted = true
+		case "--archived":
+			filter.Archived = true
+		case "--label":
+			if i+1 >= len(args) {
+				return usagef(usage)
+			}
+			filter.Label = args[i+1]
+			i++
+		default:
+			return usagef(usage)
+		}
 	}
 
-	if !chat.IsSubcommand(args[0]) {
-		// just make it an interactive chat if theres an id and nothing else
-		return startInteractiveChat(args[0])
+	// Filter the whole inbox first, so the limit applies to matching chats
+	chats, err := dmInstance.GetChatsWithLimit(0)
+	if err != nil {
+		return fmt.Errorf("failed to get chats: %v", err)
 	}
+	chats = filter.Apply(chats)
+	if limit > 0 && len(chats) > limit {
+		chats = chats[:limit]
+	}
+
+	return writeChats(chats, format)
+}
 
-	subcommand := strings.ToLower(args[0])
+// writeChats prints chats in the requested format
+func writeChats(chats []*chat.Chat, format output.Format) error {
+	records := make([]output.ChatRecord, 0, len(chats))
+	for _, c := range chats {
+		records = append(records, c.Record())
+	}
 
-	switch subcommand {
-	case "list":
-		if len(args) > 1 && args[1] == "all" {
-			return listAllChats()
+	if format == output.FormatTable {
+		if len(chats) == 0 {
+			fmt.Println("No chats found.")
+			return nil
 		}
-		return listChats()
-	case "start":
-		return startNewChatInterface()
-	default:
-		fmt.Printf("Unknown chat command: %s\n", subcommand)
-		fmt.Println("Available commands: <id>, list, start")
+		fmt.Printf("Found %d chats:\n", len(chats))
 	}
 
-	return nil
+	return output.Write(os.Stdout, format, records, output.ChatsTable(records))
 }
 
-func listChats() error {
-	chats, err := dmInstance.GetChats()
+// handleChatHistory prints the most recent messages of a chat, oldest first
+func handleChatHistory(args []string) error {
+	format, args, err := parseOutputFlag(args)
 	if err != nil {
-		return fmt.Errorf("failed to get chats: %v", err)
+		return err
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return usagef("usage: chat history <id> [limit] [-o format]")
+	}
+
+	limit := 20
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 {
+			return usagef("invalid limit: %s", args[1])
+		}
+		limit = n
+	}
+
+	messages, err := dmInstance.GetChatHistory(args[0], limit)
+	if err != nil {
+		return fmt.Errorf("failed to get chat history: %v", err)
 	}
+	// Reactions are a nice-to-have; show the history even if they fail to load
+	dmInstance.LoadReactions(args[0], messages)
 
-	if len(chats) == 0 {
-		fmt.Println("No chats found.")
+	records := make([]output.MessageRecord, 0, len(messages))
+	for i := len(messages) - 1; i >= 0; i-- {
+		records = append(records, messages[i].Record(args[0]))
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("No messages found.")
 		return nil
 	}
 
-	fmt.Printf("Found %d chats:\n", len(chats))
-	fmt.Printf("%-8s %-20s %s\n", "ID", "Title", "Last Message")
-	fmt.Printf("%-8s %-20s %s\n", "--", "-----", "------------")
+	return output.Write(os.Stdout, format, records, output.MessagesTable(records))
+}
+
+// sendPause spaces out messages when chat send splits or streams input
+const sendPause = time.Second
+
+// handleChatSend sends a message to a chat. The body comes from the
+// arguments, from stdin with "-", or from a file with --file.
+func handleChatSend(args []string) error {
+	const usage = "usage: chat send <id> <message> | chat send <id> - [--blocks] | chat send <id> --file <path>"
+
+	var filePath string
+	blocks := false
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--file":
+			if i+1 >= len(args) {
+				return usagef(usage)
+			}
+			i++
+			filePath = args[i]
+		case strings.HasPrefix(args[i], "--file="):
+			filePath = strings.TrimPrefix(args[i], "--file=")
+		case args[i] == "--blocks":
+			blocks = true
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	if len(rest) == 0 {
+		return usagef(usage)
+	}
+	chatID := rest[0]
 
-	for _, chat := range chats {
-		lastMsg := chat.LastMessage
-		if lastMsg == "" {
-			lastMsg = "(no message)"
-		} else if len(lastMsg) > 30 {
-			lastMsg = lastMsg[:27] + "..."
+	var messages []string
+	switch {
+	case filePath != "":
+		if len(rest) > 1 {
+			return usagef(usage)
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", filePath, err)
+		}
+		messages = []string{string(data)}
+	case len(rest) == 2 && rest[1] == "-":
+		read, err := readMessages(os.Stdin, blocks)
+		if err != nil {
+			return err
 		}
+		messages = read
+	case len(rest) >= 2:
+		messages = []string{strings.Join(rest[1:], " ")}
+	default:
+		return usagef(usage)
+	}
 
-		// Truncate title if too long
-		title := chat.Title
-		if len(title) > 18 {
-			title = title[:15] + "..."
+	var parts []string
+	for _, message := range messages {
+		parts = append(parts, chat.SplitMessage(message, chat.MaxMessageLength)...)
+	}
+	if len(parts) == 0 {
+		return usagef("message is empty")
+	}
+
+	for i, part := range parts {
+		if i > 0 {
+			time.Sleep(sendPause)
+		}
+		err := dmInstance.SendMessageByInternalID(chatID, part)
+		var queued *chat.QueuedError
+		if errors.As(err, &queued) && queued.Retrying() {
+			fmt.Printf("Message %d of %d %v\n", i+1, len(parts), err)
+			// Queue the rest behind it so they arrive in order
+			for _, rest := range parts[i+1:] {
+				if _, err := dmInstance.QueueMessageByInternalID(chatID, rest); err != nil {
+					return fmt.Errorf("failed to queue message: %v", err)
+				}
+			}
+			if remaining := len(parts) - i - 1; remaining > 0 {
+				fmt.Printf("The remaining %d were queued behind it.\n", remaining)
+			}
+			return nil
 		}
+		if err != nil {
+			if queued != nil {
+				return err
+			}
+			return fmt.Errorf("failed to send message %d of %d: %v", i+1, len(parts), err)
+		}
+	}
+
+	if len(parts) == 1 {
+		fmt.Println("Message sent")
+	} else {
+		fmt.Printf("%d messages sent\n", len(parts))
+	}
+	return nil
+}
+
+// readMessages reads one message per line, or per NUL/blank-line separated
+// block when blocks is set, skipping empty ones
+func readMessages(r io.Reader, blocks bool) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	if blocks {
+		scanner.Split(chat.ScanBlocks)
+	}
+
+	var messages []string
+	for scanner.Scan() {
+		if text := strings.TrimSpace(scanner.Text()); text != "" {
+			messages = append(messages, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read messages: %v", err)
+	}
+
+	return messages, nil
+}
 
-		fmt.Printf("%-8s %-20s %s\n", chat.InternalID, title, lastMsg)
+// handleChatSendMedia uploads a photo or video to a chat
+func handleChatSendMedia(args []string) error {
+	if len(args) != 2 {
+		return usagef("usage: chat send-media <id> <path>")
 	}
 
+	if err := dmInstance.SendMedia(args[0], args[1]); err != nil {
+		return fmt.Errorf("failed to send media: %v", err)
+	}
+
+	fmt.Println("Media sent")
+	return nil
+}
+
+// handleChatReact reacts to a message by ID, as shown by chat history -o json
+func handleChatReact(args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return usagef("usage: chat react <id> <message-id> [emoji]")
+	}
+
+	emoji := ""
+	if len(args) == 3 {
+		emoji = args[2]
+	}
+	if err := dmInstance.React(args[0], args[1], emoji); err != nil {
+		return err
+	}
+
+	fmt.Println("Reaction sent")
+	return nil
+}
+
+// handleChatUnreact removes your reaction from a message
+func handleChatUnreact(args []string) error {
+	if len(args) != 2 {
+		return usagef("usage: chat unreact <id> <message-id>")
+	}
+
+	if err := dmInstance.Unreact(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Println("Reaction removed")
 	return nil
 }
 
-func listAllChats() error {
-	chats, err := dmInstance.GetChatsWithLimit(0) // 0 means no limit
+// handleWaitForMessage blocks until someone else posts a matching message in a chat
+func handleWaitForMessage(args []string) error {
+	_, err := waitForMessage(args)
+	return err
+}
+
+// waitForMessage runs wait-for-message and returns the message it matched
+func waitForMessage(args []string) (*chat.Message, error) {
+	if len(args) != 3 {
+		return nil, usagef("usage: wait-for-message <id> <pattern> <timeout>")
+	}
+
+	pattern, err := regexp.Compile(args[1])
 	if err != nil {
-		return fmt.Errorf("failed to get chats: %v", err)
+		return nil, usagef("invalid pattern: %v", err)
 	}
 
-	if len(chats) == 0 {
-		fmt.Println("No chats found.")
-		return nil
+	timeout, err := time.ParseDuration(args[2])
+	if err != nil {
+		return nil, usagef("invalid timeout %q (use e.g. 30s or 2m): %v", args[2], err)
 	}
 
-	fmt.Printf("Found %d chats:\n", len(chats))
-	fmt.Printf("%-8s %-20s %s\n", "ID", "Title", "Last Message")
-	fmt.Printf("%-8s %-20s %s\n", "--", "-----", "------------")
+	msg, err := dmInstance.WaitForMessage(args[0], pattern, timeout, waitPollInterval)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, chat := range chats {
-		lastMsg := chat.LastMessage
-		if lastMsg == "" {
-			lastMsg = "(no message)"
-		} else if len(lastMsg) > 30 {
-			lastMsg = lastMsg[:27] + "..."
-		}
+	fmt.Printf("%s: %s\n", msg.Sender, msg.Text)
+	return msg, nil
+}
 
-		// Truncate title if too long
-		title := chat.Title
-		if len(title) > 18 {
-			title = title[:15] + "..."
-		}
+// handleChatSearch searches chats by title or username
+func handleChatSearch(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return usagef("usage: chat search <query> [-o format]")
+	}
 
-		fmt.Printf("%-8s %-20s %s\n", chat.InternalID, title, lastMsg)
+	chats, err := dmInstance.SearchChats(strings.Join(args, " "))
+	if err != nil {
+		return fmt.Errorf("failed to search chats: %v", err)
+	}
+
+	return writeChats(chats, format)
+}
+
+func handleConfigList(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return usagef("usage: config list [-o format]")
+	}
+
+	values := config.GetInstance().List()
+	records := make([]output.ConfigRecord, 0, len(values))
+	for _, kv := range values {
+		records = append(records, kv.Record())
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	if format != output.FormatTable {
+		return output.Write(os.Stdout, format, records, output.ConfigTable(records))
 	}
 
+	for _, kv := range records {
+		fmt.Printf("%s = %s\n", kv.Key, kv.Value)
+	}
 	return nil
 }
 
-func handleConfigCommand(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: config <command> [args]")
-		fmt.Println("Commands: list, get, set")
-		return nil
+func handleConfigGet(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: config get <key>")
 	}
 
-	subcommand := strings.ToLower(args[0])
-	cfg := config.GetInstance()
+	value := config.GetInstance().Get(args[0], nil)
+	if value == nil {
+		return fmt.Errorf("configuration key '%s' not found", args[0])
+	}
 
-	switch subcommand {
-	case "list":
-		values := cfg.List()
-		for _, kv := range values {
-			fmt.Printf("%s = %v\n", kv.Key, kv.Value)
-		}
-	case "get":
-		if len(args) < 2 {
-			return fmt.Errorf("usage: config get <key>")
-		}
-		value := cfg.Get(args[1], nil)
-		if value != nil {
-			fmt.Println(value)
-		} else {
-			fmt.Printf("Configuration key '%s' not found\n", args[1])
-		}
-	case "set":
-		if len(args) < 3 {
-			return fmt.Errorf("usage: config set <key> <value>")
-		}
-		if err := cfg.Set(args[1], args[2]); err != nil {
-			return fmt.Errorf("failed to set config: %v", err)
-		}
-		fmt.Printf("✅ Set %s = %s\n", args[1], args[2])
-	default:
-		fmt.Printf("Unknown config command: %s\n", subcommand)
-		fmt.Println("Available commands: list, get, set")
+	fmt.Println(value)
+	return nil
+}
+
+func handleConfigSet(args []string) error {
+	if len(args) < 2 {
+		return usagef("usage: config set <key> <value>")
 	}
 
+	value := strings.Join(args[1:], " ")
+	if err := config.GetInstance().Set(args[0], value); err != nil {
+		return fmt.Errorf("failed to set config: %v", err)
+	}
+	fmt.Printf("✅ Set %s = %s\n", args[0], value)
 	return nil
 }
 
@@ -416,6 +926,7 @@ func startNewChatInterface() error {
 			// Handle message unsending - implement when unsend functionality is available
 			return fmt.Errorf("unsend functionality not yet implemented")
 		},
+		dmInstance,
 	)
 
 	// Load chats into the interface
@@ -431,55 +942,95 @@ func startNewChatInterface() error {
 	return chatInterface.Run()
 }
 
-// handleNotificationsCommand handles notification-related commands
-func handleNotificationsCommand(args []string) error {
-	if clientInstance == nil {
-		return fmt.Errorf("not logged in. Use 'login' first.")
+// startNotifications starts background message notifications
+func startNotifications() error {
+	if dmInstance.IsNotificationRunning() {
+		fmt.Println("Notifications are already running")
+		return nil
 	}
 
-	if len(args) == 0 {
-		fmt.Println("Usage: notifications <command>")
-		fmt.Println("Commands: start, stop, status")
+	fmt.Println("Starting background message notifications...")
+	if err := dmInstance.StartNotifications(); err != nil {
+		return fmt.Errorf("failed to start notifications: %v", err)
+	}
+	fmt.Println("Background message notifications started")
+	return nil
+}
+
+// stopNotifications stops background message notifications
+func stopNotifications() error {
+	if !dmInstance.IsNotificationRunning() {
+		fmt.Println("Notifications are not running")
 		return nil
 	}
 
-	command := strings.ToLower(args[0])
+	fmt.Println("Stopping background message notifications...")
+	dmInstance.StopNotifications()
+	fmt.Println("Background message notifications stopped")
+	return nil
+}
+
+// notificationStatus prints whether notifications are running
+func notificationStatus() error {
+	if dmInstance.IsNotificationRunning() {
+		fmt.Println("Background message notifications: RUNNING")
+	} else {
+		fmt.Println("Background message notifications: STOPPED")
+	}
 
-	switch command {
-	case "start":
-		if dmInstance.IsNotificationRunning() {
-			fmt.Println("Notifications are already running")
-			return nil
+	errs := dmInstance.NotificationSinkErrors()
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("Sink %s failed: %s\n", name, errs[name])
+	}
+	return nil
+}
+
+// watchNotifications runs notifications in the foreground until Ctrl+C
+func watchNotifications(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return usagef("usage: notifications watch [-o format]")
+	}
+
+	// Anything but the default table streams one record per notification
+	dmInstance.SetNotificationHandler(func(c *chat.Chat, m *chat.Message) {
+		if format == output.FormatTable {
+			fmt.Print(chat.FormatNotification(c, m))
+			return
+		}
+		record := c.NotificationRecord(m)
+		if err := output.WriteRecord(os.Stdout, format, record, output.NotificationRow(record)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing notification: %v\n", err)
 		}
+	})
+	defer dmInstance.SetNotificationHandler(nil)
 
-		fmt.Println("Starting background message notifications...")
+	wasRunning := dmInstance.IsNotificationRunning()
+	if !wasRunning {
 		if err := dmInstance.StartNotifications(); err != nil {
 			return fmt.Errorf("failed to start notifications: %v", err)
 		}
-		fmt.Println("Background message notifications started")
-
-	case "stop":
-		if !dmInstance.IsNotificationRunning() {
-			fmt.Println("Notifications are not running")
-			return nil
-		}
+	}
 
-		fmt.Println("Stopping background message notifications...")
-		dmInstance.StopNotifications()
-		fmt.Println("Background message notifications stopped")
+	fmt.Fprintln(os.Stderr, "Watching for new messages, press Ctrl+C to stop...")
 
-	case "status":
-		if dmInstance.IsNotificationRunning() {
-			fmt.Println("Background message notifications: RUNNING")
-		} else {
-			fmt.Println("Background message notifications: STOPPED")
-		}
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+	signal.Stop(c)
 
-	default:
-		fmt.Printf("Unknown notifications command: %s\n", command)
-		fmt.Println("Available commands: start, stop, status")
+	if !wasRunning {
+		dmInstance.StopNotifications()
 	}
-
+	fmt.Fprintln(os.Stderr, "\nStopped watching")
 	return nil
 }
 
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// handleMediaList lists downloaded media for one chat or all chats
+func handleMediaList(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return usagef("usage: media list [id] [-o format]")
+	}
+
+	chatID := ""
+	if len(args) == 1 {
+		chatID = args[0]
+	}
+
+	entries, err := dmInstance.MediaEntries(chatID)
+	if err != nil {
+		return fmt.Errorf("failed to list media: %v", err)
+	}
+
+	// Populate internal IDs so records show the IDs other commands accept
+	dmInstance.CachedChats()
+
+	records := make([]output.MediaRecord, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, entry.Record(dmInstance.InternalIDForThread(entry.ChatID)))
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("No downloaded media.")
+		return nil
+	}
+
+	return output.Write(os.Stdout, format, records, output.MediaTable(records))
+}
+
+// handleMediaOpen opens a downloaded file with the system viewer
+func handleMediaOpen(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: media open <message-id|path>")
+	}
+
+	path := args[0]
+	if entry := dmInstance.Media().Find(args[0]); entry != nil {
+		path = entry.Path
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("no downloaded media matches %s", args[0])
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	go cmd.Wait()
+
+	fmt.Printf("Opened %s\n", path)
+	return nil
+}
+
+// defaultPruneAge is what media prune removes without --older-than
+const defaultPruneAge = 30 * 24 * time.Hour
+
+// handleMediaPrune deletes old downloads
+func handleMediaPrune(args []string) error {
+	const usage = "usage: media prune [--older-than <age>] [--chat <id>]"
+
+	maxAge := defaultPruneAge
+	chatID := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--older-than":
+			if i+1 >= len(args) {
+				return usagef(usage)
+			}
+			age, err := parseAge(args[i+1])
+			if err != nil {
+				return usagef("invalid age %q: use e.g. 30d, 12h or 0 for everything", args[i+1])
+			}
+			maxAge = age
+			i++
+		case "--chat":
+			if i+1 >= len(args) {
+				return usagef(usage)
+			}
+			chatID = args[i+1]
+			i++
+		default:
+			return usagef(usage)
+		}
+	}
+
+	removed, freed, err := dmInstance.PruneMedia(maxAge, chatID)
+	if err != nil {
+		return fmt.Errorf("failed to prune media: %v", err)
+	}
+
+	fmt.Printf("Removed %d downloads, freed %s\n", removed, output.FormatSize(freed))
+	return nil
+}
+
+// handleMediaDownload downloads the attachments among a chat's recent messages
+func handleMediaDownload(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return usagef("usage: media download <id> [limit]")
+	}
+
+	limit := 20
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 {
+			return usagef("invalid limit: %s", args[1])
+		}
+		limit = n
+	}
+
+	results, err := dmInstance.DownloadChatMedia(args[0], limit)
+	if err != nil {
+		return fmt.Errorf("failed to download media: %v", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("Error: %v\n", result.Err)
+			continue
+		}
+		fmt.Println(result.Entry.Path)
+	}
+
+	fmt.Printf("%d of %d attachments downloaded\n", len(results)-failed, len(results))
+	if failed > 0 {
+		return fmt.Errorf("%d downloads failed", failed)
+	}
+	return nil
+}
+
+// handleMediaAuto sets or shows a chat's auto-download policy
+func handleMediaAuto(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return usagef("usage: media auto <id> [on|off|default]")
+	}
+
+	if len(args) == 2 {
+		if err := dmInstance.SetAutoDownload(args[0], strings.ToLower(args[1])); err != nil {
+			return err
+		}
+	}
+
+	policy, err := dmInstance.AutoDownloadPolicy(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Auto-download for chat %s: %s\n", args[0], policy)
+	return nil
+}
+
+// parseAge accepts Go durations plus a "d" suffix for days
+func parseAge(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days")
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration")
+	}
+	return d, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// parseOnOff reads the optional on/off argument of pin, mute and archive
+func parseOnOff(args []string, usage string) (string, bool, error) {
+	switch {
+	case len(args) == 1:
+		return args[0], true, nil
+	case len(args) == 2 && (args[1] == "on" || args[1] == "off"):
+		return args[0], args[1] == "on", nil
+	}
+	return "", false, usagef("%s", usage)
+}
+
+// handleChatPin pins a chat to the top of the chat list: chat pin <id> [on|off]
+func handleChatPin(args []string) error {
+	id, on, err := parseOnOff(args, "usage: chat pin <id> [on|off]")
+	if err != nil {
+		return err
+	}
+
+	c, err := dmInstance.SetPinned(id, on)
+	if err != nil {
+		return err
+	}
+	if on {
+		fmt.Printf("Pinned %s\n", c.Title)
+	} else {
+		fmt.Printf("Unpinned %s\n", c.Title)
+	}
+	return nil
+}
+
+// handleChatMute silences GoGram's notifications for a chat without muting
+// it on Instagram: chat mute <id> [on|off]
+func handleChatMute(args []string) error {
+	id, on, err := parseOnOff(args, "usage: chat mute <id> [on|off]")
+	if err != nil {
+		return err
+	}
+
+	c, err := dmInstance.SetMutedLocally(id, on)
+	if err != nil {
+		return err
+	}
+	if on {
+		fmt.Printf("Muted %s in GoGram. Use chat group mute to mute it on Instagram too.\n", c.Title)
+	} else {
+		fmt.Printf("Unmuted %s in GoGram\n", c.Title)
+	}
+	return nil
+}
+
+// handleChatArchive hides a chat from chat lists: chat archive <id> [on|off]
+func handleChatArchive(args []string) error {
+	id, on, err := parseOnOff(args, "usage: chat archive <id> [on|off]")
+	if err != nil {
+		return err
+	}
+
+	c, err := dmInstance.SetArchived(id, on)
+	if err != nil {
+		return err
+	}
+	if on {
+		fmt.Printf("Archived %s. See it with chat list --archived.\n", c.Title)
+	} else {
+		fmt.Printf("Moved %s back to your chats\n", c.Title)
+	}
+	return nil
+}
+
+// handleChatLabel changes or shows a chat's labels, or lists every label:
+// chat label [<id> [label|+label|-label ...]]
+func handleChatLabel(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return listLabels(format)
+	}
+
+	var c *chat.Chat
+	if len(args) == 1 {
+		c, err = dmInstance.GetChatByInternalID(args[0])
+	} else {
+		add, remove := chat.ParseLabelArgs(args[1:])
+		c, err = dmInstance.LabelChat(args[0], add, remove)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(c.Labels) == 0 {
+		fmt.Printf("%s has no labels\n", c.Title)
+		return nil
+	}
+	fmt.Printf("%s: #%s\n", c.Title, strings.Join(c.Labels, " #"))
+	return nil
+}
+
+func listLabels(format output.Format) error {
+	counts, err := dmInstance.ChatLabels()
+	if err != nil {
+		return err
+	}
+
+	records := make([]output.LabelRecord, 0, len(counts))
+	for _, label := range chat.SortedLabels(counts) {
+		records = append(records, output.LabelRecord{Label: label, Chats: counts[label]})
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("No labels yet. Add one with chat label <id> <label>.")
+		return nil
+	}
+	return output.Write(os.Stdout, format, records, output.LabelsTable(records))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abhi-praj/GoGram/internal/outbox"
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// handleOutboxList lists messages still waiting to be sent, or all of them
+func handleOutboxList(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+
+	all := false
+	if len(args) == 1 && args[0] == "all" {
+		all = true
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		return usagef("usage: outbox list [all] [-o format]")
+	}
+
+	items, err := dmInstance.ListOutbox(all)
+	if err != nil {
+		return err
+	}
+
+	// Populate internal IDs so records show the IDs other commands accept
+	dmInstance.CachedChats()
+
+	records := make([]output.OutboxRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, item.Record(dmInstance.InternalIDForThread(item.ChatID)))
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("The outbox is empty.")
+		return nil
+	}
+
+	return output.Write(os.Stdout, format, records, output.OutboxTable(records))
+}
+
+// handleOutboxRetry makes one queued message, or all of them, due now and
+// sends them straight away
+func handleOutboxRetry(args []string) error {
+	if len(args) > 1 {
+		return usagef("usage: outbox retry [outbox-id|all]")
+	}
+
+	if len(args) == 0 || args[0] == "all" {
+		items, err := dmInstance.RetryAllOutbox()
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			fmt.Println("Nothing to retry.")
+			return nil
+		}
+		fmt.Printf("Retrying %d queued message(s)...\n", len(items))
+	} else {
+		item, err := dmInstance.RetryOutbox(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Retrying %s to %s...\n", item.ID, item.ChatTitle)
+	}
+
+	err := dmInstance.FlushOutbox(func(item *outbox.Item) {
+		fmt.Println(item.Outcome())
+	})
+	if err != nil {
+		return err
+	}
+
+	items, err := dmInstance.ListOutbox(false)
+	if err != nil {
+		return err
+	}
+	waiting := 0
+	for _, item := range items {
+		if item.Status == outbox.StatusPending {
+			waiting++
+		}
+	}
+	if waiting > 0 {
+		fmt.Printf("%d message(s) still waiting; they are retried while the shell, TUI or gRPC server runs.\n", waiting)
+	}
+	return nil
+}
+
+// handleOutboxDrop removes a queued message so it is never sent
+func handleOutboxDrop(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: outbox drop <outbox-id>")
+	}
+
+	item, err := dmInstance.DropOutbox(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Dropped queued message %s to %s\n", item.ID, item.ChatTitle)
+	return nil
+}
+
+// startOutbox retries queued messages from this process, passing a line
+// about each one that is delivered or given up on to notify
+func startOutbox(notify func(string)) {
+	if dmInstance == nil || dmInstance.IsOutboxRunning() {
+		return
+	}
+
+	err := dmInstance.StartOutbox(func(item *outbox.Item) {
+		notify(fmt.Sprintf("\n📤 %s\n", item.Outcome()))
+	})
+	if err != nil {
+		fmt.Printf("Warning: Could not start the outbox: %v\n", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// handleRequestsList lists pending message requests
+func handleRequestsList(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return usagef("usage: chat requests [-o format]")
+	}
+
+	requests, err := dmInstance.GetMessageRequests()
+	if err != nil {
+		return err
+	}
+
+	if format == output.FormatTable && len(requests) == 0 {
+		fmt.Println("No message requests.")
+		return nil
+	}
+	return writeChats(requests, format)
+}
+
+// handleRequestsShow prints a request's messages without marking them seen
+func handleRequestsShow(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return usagef("usage: chat requests show <id> [limit] [-o format]")
+	}
+
+	limit := 20
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 {
+			return usagef("invalid limit: %s", args[1])
+		}
+		limit = n
+	}
+
+	messages, err := dmInstance.PreviewMessageRequest(args[0], limit)
+	if err != nil {
+		return err
+	}
+
+	records := make([]output.MessageRecord, 0, len(messages))
+	for i := len(messages) - 1; i >= 0; i-- {
+		records = append(records, messages[i].Record(args[0]))
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("No messages found.")
+		return nil
+	}
+	return output.Write(os.Stdout, format, records, output.MessagesTable(records))
+}
+
+// handleRequestsAccept moves a request into the inbox
+func handleRequestsAccept(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: chat requests accept <id>")
+	}
+
+	accepted, err := dmInstance.AcceptMessageRequest(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Accepted request from %s [%s]\n", accepted.Title, accepted.InternalID)
+	return nil
+}
+
+// handleRequestsDecline deletes a request
+func handleRequestsDecline(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: chat requests decline <id>")
+	}
+
+	if err := dmInstance.DeclineMessageRequest(args[0]); err != nil {
+		return err
+	}
+	fmt.Println("Declined request")
+	return nil
+}
+
+// handleRequestsBlock blocks the sender and deletes the request
+func handleRequestsBlock(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: chat requests block <id>")
+	}
+
+	if err := dmInstance.BlockMessageRequest(args[0]); err != nil {
+		return err
+	}
+	fmt.Println("Blocked sender and declined request")
+	return nil
+}
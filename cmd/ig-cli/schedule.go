@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+	"github.com/abhi-praj/GoGram/internal/output"
+	"github.com/abhi-praj/GoGram/internal/schedule"
+)
+
+// handleChatSchedule queues a message: chat schedule <id> [time|+duration] <text>
+func handleChatSchedule(args []string) error {
+	if len(args) < 2 {
+		return usagef("usage: chat schedule <id> [time|+duration] <text>")
+	}
+
+	sendAt, text, err := chat.ParseScheduleArgs(strings.Join(args[1:], " "))
+	if err != nil {
+		return usagef("%v", err)
+	}
+
+	msg, err := dmInstance.ScheduleMessage(args[0], text, sendAt)
+	if err != nil {
+		return fmt.Errorf("failed to schedule message: %v", err)
+	}
+
+	fmt.Printf("Scheduled %s to %s for %s\n", msg.ID, msg.ChatTitle, sendAt.Format("Mon Jan 2 15:04"))
+	if !dmInstance.IsSchedulerRunning() {
+		fmt.Println("It will be sent by the gRPC server, shell or TUI, whichever is running at that time.")
+	}
+	return nil
+}
+
+// handleScheduleList lists scheduled messages that still need attention, or all of them
+func handleScheduleList(args []string) error {
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+
+	all := false
+	if len(args) == 1 && args[0] == "all" {
+		all = true
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		return usagef("usage: chat schedule list [all] [-o format]")
+	}
+
+	messages, err := dmInstance.ListScheduled(all)
+	if err != nil {
+		return err
+	}
+
+	// Populate internal IDs so records show the IDs other commands accept
+	dmInstance.CachedChats()
+
+	records := make([]output.ScheduledRecord, 0, len(messages))
+	for _, msg := range messages {
+		records = append(records, msg.Record(dmInstance.InternalIDForThread(msg.ChatID)))
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("No scheduled messages.")
+		return nil
+	}
+
+	return output.Write(os.Stdout, format, records, output.ScheduledTable(records))
+}
+
+// handleScheduleCancel cancels a pending message or dismisses a missed one
+func handleScheduleCancel(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: chat schedule cancel <schedule-id>")
+	}
+
+	msg, err := dmInstance.CancelScheduled(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cancelled scheduled message %s to %s\n", msg.ID, msg.ChatTitle)
+	return nil
+}
+
+// startScheduler sends due scheduled messages from this process, passing a
+// line about each one to notify
+func startScheduler(notify func(string)) {
+	if dmInstance == nil || dmInstance.IsSchedulerRunning() {
+		return
+	}
+
+	err := dmInstance.StartScheduler(func(msg *schedule.Message) {
+		notify(fmt.Sprintf("\n⏰ %s\n", msg.Outcome()))
+	})
+	if err != nil {
+		fmt.Printf("Warning: Could not start scheduled messages: %v\n", err)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// waitPollInterval is how often wait-for-message checks the chat
+const waitPollInterval = 3 * time.Second
+
+var assignmentPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+// scriptRunner executes shell commands from a script, one per line
+type scriptRunner struct {
+	name     string
+	vars     map[string]string
+	errexit  bool
+	lastCode int
+}
+
+func newScriptRunner(name string, params []string) *scriptRunner {
+	runner := &scriptRunner{
+		name: name,
+		vars: make(map[string]string),
+	}
+	for i, param := range params {
+		runner.vars[strconv.Itoa(i+1)] = param
+	}
+	runner.vars["#"] = strconv.Itoa(len(params))
+	return runner
+}
+
+// runScriptFile runs the script at path and returns the exit code
+func runScriptFile(path string, params []string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	defer file.Close()
+
+	return newScriptRunner(path, params).run(file)
+}
+
+// run executes every line of r. With set -e it stops at the first failing
+// command; otherwise it returns the exit code of the last command.
+func (s *scriptRunner) run(r io.Reader) int {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		err := s.execLine(line)
+		s.lastCode = exitCodeFor(err)
+		if err == nil {
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "%s:%d: Error: %v\n", s.name, lineNo, err)
+		if s.errexit {
+			return s.lastCode
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", s.name, err)
+		return exitError
+	}
+
+	return s.lastCode
+}
+
+// execLine runs one script line: a builtin, an assignment or a shell command
+func (s *scriptRunner) execLine(line string) error {
+	if match := assignmentPattern.FindStringSubmatch(line); match != nil {
+		args, err := splitWords(match[2], s.lookup)
+		if err != nil {
+			return err
+		}
+		s.vars[match[1]] = strings.Join(args, " ")
+		return nil
+	}
+
+	args, err := splitWords(line, s.lookup)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch args[0] {
+	case "set":
+		return s.setOption(args[1:])
+	case "echo":
+		fmt.Println(strings.Join(args[1:], " "))
+		return nil
+	case "sleep":
+		if len(args) != 2 {
+			return usagef("usage: sleep <duration>")
+		}
+		d, err := time.ParseDuration(args[1])
+		if err != nil {
+			return usagef("invalid duration %q: %v", args[1], err)
+		}
+		time.Sleep(d)
+		return nil
+	case "exit", "quit":
+		return usagef("%s is not allowed in scripts", args[0])
+	case "wait-for-message":
+		// Like the command, but keeps the matched text as $MATCH
+		if err := ensureSession(); err != nil {
+			return err
+		}
+		msg, err := waitForMessage(args[1:])
+		if err != nil {
+			return err
+		}
+		s.vars["MATCH"] = msg.Text
+		return nil
+	}
+
+	return dispatch(commandTree(), args)
+}
+
+// setOption handles `set -e` and `set +e`
+func (s *scriptRunner) setOption(args []string) error {
+	if len(args) != 1 {
+		return usagef("usage: set -e | set +e")
+	}
+
+	switch args[0] {
+	case "-e":
+		s.errexit = true
+	case "+e":
+		s.errexit = false
+	default:
+		return usagef("unknown option: %s", args[0])
+	}
+	return nil
+}
+
+// lookup returns the value of a variable. Script variables take precedence
+// over the environment.
+func (s *scriptRunner) lookup(name string) string {
+	if name == "?" {
+		return strconv.Itoa(s.lastCode)
+	}
+	if value, ok := s.vars[name]; ok {
+		return value
+	}
+	return os.Getenv(name)
+}
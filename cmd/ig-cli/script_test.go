@@ -0,0 +1,101 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitWordsExpands(t *testing.T) {
+	vars := map[string]string{
+		"MATCH": `hi" there --flag`,
+		"X":     "a b",
+		"EMPTY": "",
+		"?":     "2",
+	}
+	lookup := func(name string) string { return vars[name] }
+
+	testCases := []struct {
+		line     string
+		expected []string
+	}{
+		{`chat send 1 $MATCH`, []string{"chat", "send", "1", `hi" there --flag`}},
+		{`echo $X`, []string{"echo", "a b"}},
+		{`echo "pre-$X-post" ${X}y`, []string{"echo", "pre-a b-post", "a by"}},
+		{`echo '$X' \$X`, []string{"echo", "$X", "$X"}},
+		{`echo a $EMPTY b`, []string{"echo", "a", "", "b"}},
+		{`echo $? $ costs`, []string{"echo", "2", "$", "costs"}},
+		{`echo ${} $UNSET`, []string{"echo", "${}", ""}},
+	}
+
+	for _, tc := range testCases {
+		args, err := splitWords(tc.line, lookup)
+		if err != nil {
+			t.Errorf("%s: %v", tc.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, tc.expected) {
+			t.Errorf("%s: expected %q, got %q", tc.line, tc.expected, args)
+		}
+	}
+}
+
+func TestScriptRunner(t *testing.T) {
+	testCases := []struct {
+		name     string
+		script   string
+		code     int
+		expected map[string]string // variables after the run
+		unset    []string
+	}{
+		{
+			name:     "assignment",
+			script:   "X=\"a b\"\nY=$X\nZ='$X' $1",
+			expected: map[string]string{"X": "a b", "Y": "a b", "Z": "$X first"},
+		},
+		{
+			name:     "exit status",
+			script:   "no-such-command\nCODE=$?\necho ok\nAFTER=$?",
+			expected: map[string]string{"CODE": "2", "AFTER": "0"},
+		},
+		{
+			name:     "keeps going without set -e",
+			script:   "no-such-command\nDONE=yes\nno-such-command",
+			code:     exitUsage,
+			expected: map[string]string{"DONE": "yes"},
+		},
+		{
+			name:   "set -e",
+			script: "set -e\nno-such-command\nDONE=yes",
+			code:   exitUsage,
+			unset:  []string{"DONE"},
+		},
+		{
+			name:     "set +e",
+			script:   "set -e\nset +e\nno-such-command\nDONE=yes",
+			expected: map[string]string{"DONE": "yes"},
+		},
+		{
+			name:   "exit is refused",
+			script: "set -e\nexit",
+			code:   exitUsage,
+		},
+	}
+
+	for _, tc := range testCases {
+		runner := newScriptRunner("test.igs", []string{"first"})
+		if code := runner.run(strings.NewReader(tc.script)); code != tc.code {
+			t.Errorf("%s: expected exit code %d, got %d", tc.name, tc.code, code)
+		}
+		for name, value := range tc.expected {
+			if runner.vars[name] != value {
+				t.Errorf("%s: expected %s=%q, got %q", tc.name, name, value, runner.vars[name])
+			}
+		}
+		for _, name := range tc.unset {
+			if _, ok := runner.vars[name]; ok {
+				t.Errorf("%s: expected %s to be unset", tc.name, name)
+			}
+		}
+	}
+}
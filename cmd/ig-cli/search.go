@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/history"
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// defaultSearchLimit is how many hits search prints without --limit
+const defaultSearchLimit = 20
+
+// handleSearch searches the local message history:
+// search <query> [--chat id] [--from user] [--since date] [--until date] [--media] [--limit n] [-o format]
+func handleSearch(args []string) error {
+	const usage = "usage: search <query> [--chat <id>] [--from <user>] [--since <date|age>] [--until <date|age>] [--media] [--limit <n>] [-o format]"
+
+	format, args, err := parseOutputFlag(args)
+	if err != nil {
+		return err
+	}
+
+	q := history.Query{Limit: defaultSearchLimit}
+	var words []string
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if flag == "--media" {
+			q.HasMedia = true
+			continue
+		}
+		if !strings.HasPrefix(flag, "--") {
+			words = append(words, flag)
+			continue
+		}
+		if i+1 >= len(args) {
+			return usagef(usage)
+		}
+		value := args[i+1]
+		i++
+
+		switch flag {
+		case "--chat":
+			q.ThreadID = value
+		case "--from":
+			q.Sender = strings.TrimPrefix(value, "@")
+		case "--since", "--until":
+			at, err := parseDate(value, flag == "--until", time.Now())
+			if err != nil {
+				return usagef("invalid %s: %v", flag, err)
+			}
+			if flag == "--since" {
+				q.Since = at
+			} else {
+				q.Until = at
+			}
+		case "--limit":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return usagef("invalid limit: %s", value)
+			}
+			q.Limit = n
+		default:
+			return usagef(usage)
+		}
+	}
+	q.Text = strings.Join(words, " ")
+	if q.Text == "" && q.ThreadID == "" && q.Sender == "" && !q.HasMedia {
+		return usagef(usage)
+	}
+
+	results, err := dmInstance.SearchMessages(q)
+	if err != nil {
+		return fmt.Errorf("failed to search: %v", err)
+	}
+
+	records := make([]output.SearchResultRecord, 0, len(results))
+	for _, r := range results {
+		records = append(records, r.Record(dmInstance.InternalIDForThread(r.Message.ThreadID)))
+	}
+
+	if format == output.FormatTable && len(records) == 0 {
+		fmt.Println("No messages found. Search covers chats you have opened or imported.")
+		return nil
+	}
+	return output.Write(os.Stdout, format, records, output.SearchTable(records))
+}
+
+// parseDate reads a date for filters: an age like 7d or 12h counts back from
+// now, otherwise 2006-01-02, 2006-01-02T15:04 or RFC 3339. With endOfDay, a
+// bare date means the end of that day, so ranges include it.
+func parseDate(s string, endOfDay bool, now time.Time) (time.Time, error) {
+	if age, err := parseAge(s); err == nil {
+		return now.Add(-age), nil
+	}
+
+	if day, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		if endOfDay {
+			day = day.AddDate(0, 0, 1)
+		}
+		return day, nil
+	}
+	if at, err := time.ParseInLocation("2006-01-02T15:04", s, now.Location()); err == nil {
+		return at, nil
+	}
+	if at, err := time.Parse(time.RFC3339, s); err == nil {
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date (use 2006-01-02, 2006-01-02T15:04 or an age like 7d)", s)
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/storage"
+	"golang.org/x/term"
+)
+
+const (
+	shellPrompt    = "ig-cli> "
+	maxHistorySize = 1000
+)
+
+// lineReader reads shell input one line at a time
+type lineReader interface {
+	ReadLine() (string, error)
+	Close() error
+}
+
+// newLineReader returns a line-editing reader on a terminal and a plain
+// reader when stdin is a pipe or file
+func newLineReader() lineReader {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return &plainReader{scanner: bufio.NewScanner(os.Stdin)}
+	}
+
+	history := loadHistory(historyPath())
+	history.private = func(line string) bool { return isPrivate(commandTree(), line) }
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, shellPrompt)
+	t.History = history
+
+	reader := &ttyReader{fd: fd, terminal: t, history: history}
+	t.AutoCompleteCallback = reader.autoComplete
+
+	if width, height, err := term.GetSize(fd); err == nil {
+		t.SetSize(width, height)
+	}
+
+	return reader
+}
+
+// historyPath is where shell history persists between sessions
+func historyPath() string {
+	dataDir := config.GetInstance().GetString("advanced.data_dir", "")
+	if dataDir == "" {
+		return ""
+	}
+	return filepath.Join(dataDir, "history")
+}
+
+// plainReader reads lines from a non-interactive stdin
+type plainReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *plainReader) ReadLine() (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+func (r *plainReader) Close() error {
+	return nil
+}
+
+// ttyReader provides readline-style editing, history and tab completion.
+// The terminal is only in raw mode while a line is being read, so command
+// output and interactive chats behave normally.
+type ttyReader struct {
+	fd       int
+	terminal *term.Terminal
+	history  *fileHistory
+}
+
+func (r *ttyReader) ReadLine() (string, error) {
+	oldState, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to enter raw mode: %v", err)
+	}
+	defer term.Restore(r.fd, oldState)
+
+	if width, height, err := term.GetSize(r.fd); err == nil {
+		r.terminal.SetSize(width, height)
+	}
+
+	line, err := r.terminal.ReadLine()
+	if err == term.ErrPasteIndicator {
+		err = nil
+	}
+	return line, err
+}
+
+func (r *ttyReader) Close() error {
+	return r.history.Close()
+}
+
+// Notify prints text above the prompt without clobbering the line being edited
+func (r *ttyReader) Notify(text string) {
+	r.terminal.Write([]byte(text))
+}
+
+// autoComplete is the terminal's AutoCompleteCallback; it only reacts to Tab
+func (r *ttyReader) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	newLine, newPos, candidates := completeLine(line, pos)
+	if newLine != line {
+		return newLine, newPos, true
+	}
+
+	if len(candidates) > 1 {
+		// The terminal lock is held during this callback, so print the
+		// candidates once it has been released
+		listing := strings.Join(candidates, "  ") + "\n"
+		go r.terminal.Write([]byte(listing))
+	}
+
+	return "", 0, false
+}
+
+// completeLine completes the word under the cursor using the command tree,
+// config keys and known chats. It returns the new line, cursor position and
+// the candidates that matched.
+func completeLine(line string, pos int) (string, int, []string) {
+	before, after := line[:pos], line[pos:]
+
+	words := strings.Fields(before)
+	partial := ""
+	if len(words) > 0 && !strings.HasSuffix(before, " ") {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	candidates := completionCandidates(words)
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(partial)) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	// Let a chat title or @username stand in for the chat ID
+	if len(matches) == 0 && partial != "" && expectsChatID(words) {
+		matches = chatIDsMatching(partial)
+	}
+
+	if len(matches) == 0 {
+		return line, pos, nil
+	}
+
+	sort.Strings(matches)
+	completion := matches[0]
+	if len(matches) > 1 {
+		completion = commonPrefix(matches)
+		if len(completion) <= len(partial) {
+			return line, pos, matches
+		}
+	} else {
+		completion += " "
+	}
+
+	head := before[:len(before)-len(partial)]
+	newLine := head + completion + after
+	return newLine, len(head) + len(completion), matches
+}
+
+// completionCandidates lists the words that may follow words
+func completionCandidates(words []string) []string {
+	commands := commandTree()
+	var current *Command
+
+	for _, word := range words {
+		var next *Command
+		if current == nil {
+			next = findCommand(commands, word)
+		} else {
+			next = findCommand(current.Subcommands, word)
+		}
+		if next == nil {
+			break
+		}
+		current = next
+	}
+
+	var candidates []string
+	if current == nil {
+		if len(words) > 0 {
+			return nil
+		}
+		for _, cmd := range commands {
+			if !cmd.Hidden {
+				candidates = append(candidates, cmd.Name)
+			}
+		}
+		return append(candidates, "exit", "quit")
+	}
+
+	path := commandPath(words)
+	consumed := len(strings.Fields(path))
+
+	// Only offer subcommands right after the command itself
+	if consumed == len(words) {
+		for _, sub := range current.Subcommands {
+			if !sub.Hidden {
+				candidates = append(candidates, sub.Name)
+			}
+		}
+	}
+
+	switch {
+	case (path == "config get" || path == "config set") && consumed == len(words):
+		for _, kv := range config.GetInstance().List() {
+			candidates = append(candidates, kv.Key)
+		}
+	case expectsChatID(words):
+		for _, c := range cachedChats() {
+			candidates = append(candidates, c.InternalID)
+		}
+	}
+
+	return candidates
+}
+
+// commandPath returns the command names at the start of words, e.g. "chat send"
+func commandPath(words []string) string {
+	commands := commandTree()
+	var path []string
+
+	for _, word := range words {
+		cmd := findCommand(commands, word)
+		if cmd == nil {
+			break
+		}
+		path = append(path, cmd.Name)
+		commands = cmd.Subcommands
+	}
+
+	return strings.Join(path, " ")
+}
+
+// expectsChatID reports whether the next argument is a chat ID
+func expectsChatID(words []string) bool {
+	path := commandPath(words)
+	consumed := len(strings.Fields(path))
+	if consumed != len(words) {
+		return false
+	}
+
+	switch path {
+	case "chat", "chat history", "chat export", "chat send", "chat send-media", "chat broadcast", "chat pin", "chat mute", "chat archive", "chat label", "chat react", "chat unreact", "chat schedule",
+		"chat group rename", "chat group add", "chat group remove", "chat group leave", "chat group mute", "chat group unmute",
+		"media list", "media download", "media auto":
+		return true
+	}
+	return false
+}
+
+// chatIDsMatching finds chats whose title or participant usernames start with query
+func chatIDsMatching(query string) []string {
+	query = strings.ToLower(strings.TrimPrefix(query, "@"))
+	var ids []string
+
+	for _, c := range cachedChats() {
+		if strings.HasPrefix(strings.ToLower(c.Title), query) {
+			ids = append(ids, c.InternalID)
+			continue
+		}
+		for _, user := range c.Users {
+			if strings.HasPrefix(strings.ToLower(user.Username), query) {
+				ids = append(ids, c.InternalID)
+				break
+			}
+		}
+	}
+
+	return ids
+}
+
+// cachedChats returns chats from the last sync, if logged in
+func cachedChats() []*chat.Chat {
+	if dmInstance == nil {
+		return nil
+	}
+	return dmInstance.CachedChats()
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// fileHistory is a bounded term.History that appends entries to a file,
+// rewriting it whenever it would hold more than maxHistorySize lines.
+// Entries private reports true for are only kept for the session.
+type fileHistory struct {
+	entries   []string
+	path      string
+	file      *os.File
+	fileLines int
+	private   func(string) bool
+	session   map[int]bool // indexes in entries of session-only entries
+	mutex     sync.Mutex
+}
+
+// loadHistory reads previous entries and opens the file for appending
+func loadHistory(path string) *fileHistory {
+	h := &fileHistory{path: path}
+	if path == "" {
+		return h
+	}
+
+	if data, err := os.ReadFile(path); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.TrimSpace(line) != "" {
+				h.entries = append(h.entries, line)
+			}
+		}
+		h.fileLines = len(h.entries)
+		if len(h.entries) > maxHistorySize {
+			h.entries = h.entries[len(h.entries)-maxHistorySize:]
+		}
+	}
+
+	if h.fileLines > maxHistorySize {
+		h.rewrite()
+	} else if err := storage.EnsureDir(filepath.Dir(path)); err == nil {
+		h.file, _ = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, storage.PrivateFileMode)
+		// Files from older versions may have been readable by others
+		os.Chmod(path, storage.PrivateFileMode)
+	}
+
+	return h
+}
+
+// Add records a line, skipping blanks and immediate repeats
+func (h *fileHistory) Add(entry string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if strings.TrimSpace(entry) == "" {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == entry {
+		return
+	}
+
+	private := h.private != nil && h.private(entry)
+	h.entries = append(h.entries, entry)
+	if private {
+		if h.session == nil {
+			h.session = make(map[int]bool)
+		}
+		h.session[len(h.entries)-1] = true
+	}
+	if len(h.entries) > maxHistorySize {
+		h.entries = h.entries[1:]
+		h.shiftSession()
+	}
+
+	if h.file == nil || private {
+		return
+	}
+	if h.fileLines >= maxHistorySize {
+		h.rewrite()
+		return
+	}
+	if _, err := fmt.Fprintln(h.file, entry); err == nil {
+		h.fileLines++
+	}
+}
+
+// rewrite replaces the file with the entries kept in memory and reopens it
+// for appending
+func (h *fileHistory) rewrite() {
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+
+	var data strings.Builder
+	lines := 0
+	for i, entry := range h.entries {
+		if !h.session[i] {
+			data.WriteString(entry + "\n")
+			lines++
+		}
+	}
+	if err := storage.WriteFileAtomic(h.path, []byte(data.String()), storage.PrivateFileMode); err != nil {
+		return
+	}
+	h.fileLines = lines
+	h.file, _ = os.OpenFile(h.path, os.O_APPEND|os.O_WRONLY, storage.PrivateFileMode)
+}
+
+// shiftSession renumbers session-only entries after the oldest entry is dropped
+func (h *fileHistory) shiftSession() {
+	if len(h.session) == 0 {
+		return
+	}
+	shifted := make(map[int]bool, len(h.session))
+	for i := range h.session {
+		if i > 0 {
+			shifted[i-1] = true
+		}
+	}
+	h.session = shifted
+}
+
+// Len returns the number of entries
+func (h *fileHistory) Len() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.entries)
+}
+
+// At returns the idx-th most recent entry
+func (h *fileHistory) At(idx int) string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.entries[len(h.entries)-1-idx]
+}
+
+// Close closes the history file
+func (h *fileHistory) Close() error {
+	if h.file == nil {
+		return nil
+	}
+	return h.file.Close()
+}
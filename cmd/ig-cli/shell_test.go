@@ -0,0 +1,178 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCompleteLine(t *testing.T) {
+	testCases := []struct {
+		line       string
+		pos        int
+		expected   string
+		expectedAt int
+		matches    []string
+	}{
+		{"ch", 2, "chat ", 5, []string{"chat"}},
+		{"CHAT sen", 8, "CHAT send", 9, []string{"send", "send-media"}},
+		{"chat send-m", 11, "chat send-media ", 16, []string{"send-media"}},
+		{"chat se", 7, "chat se", 7, []string{"search", "send", "send-media"}},
+		{"ch 100", 2, "chat  100", 5, []string{"chat"}},
+		{"nothing", 7, "nothing", 7, nil},
+		{"chat send 1 hel", 15, "chat send 1 hel", 15, nil},
+	}
+
+	for _, tc := range testCases {
+		line, pos, matches := completeLine(tc.line, tc.pos)
+		if line != tc.expected || pos != tc.expectedAt || !reflect.DeepEqual(matches, tc.matches) {
+			t.Errorf("completeLine(%q, %d) = %q, %d, %v; expected %q, %d, %v",
+				tc.line, tc.pos, line, pos, matches, tc.expected, tc.expectedAt, tc.matches)
+		}
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	testCases := []struct {
+		words    []string
+		expected string
+	}{
+		{[]string{"send"}, "send"},
+		{[]string{"send", "send-media"}, "send"},
+		{[]string{"search", "send", "schedule"}, "s"},
+		{[]string{"chat", "media"}, ""},
+	}
+
+	for _, tc := range testCases {
+		if got := commonPrefix(tc.words); got != tc.expected {
+			t.Errorf("commonPrefix(%v) = %q, expected %q", tc.words, got, tc.expected)
+		}
+	}
+}
+
+func TestExpectsChatID(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected bool
+	}{
+		{"chat", true},
+		{"chat send", true},
+		{"chat group rename", true},
+		{"media download", true},
+		{"chat send 100", false},
+		{"chat list", false},
+		{"chat group", false},
+		{"config get", false},
+		{"unknown", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		if got := expectsChatID(strings.Fields(tc.line)); got != tc.expected {
+			t.Errorf("expectsChatID(%q) = %v, expected %v", tc.line, got, tc.expected)
+		}
+	}
+}
+
+func TestFileHistoryStaysBounded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h := loadHistory(path)
+	for i := 0; i < maxHistorySize+5; i++ {
+		h.Add("chat send 1 message " + strconv.Itoa(i))
+	}
+	h.Add("chat send 1 message 1004")
+	h.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != maxHistorySize || lines[0] != "chat send 1 message 5" {
+		t.Errorf("Expected the last %d entries in the file, got %d starting with %q", maxHistorySize, len(lines), lines[0])
+	}
+
+	reloaded := loadHistory(path)
+	defer reloaded.Close()
+	if reloaded.Len() != maxHistorySize || reloaded.At(0) != "chat send 1 message 1004" {
+		t.Errorf("Unexpected reloaded history: %d entries, newest %q", reloaded.Len(), reloaded.At(0))
+	}
+}
+
+func TestLoadHistoryTrimsOversizedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	var data strings.Builder
+	for i := 0; i < maxHistorySize*2; i++ {
+		data.WriteString("status " + strconv.Itoa(i) + "\n")
+	}
+	if err := os.WriteFile(path, []byte(data.String()), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	h := loadHistory(path)
+	h.Add("status")
+	h.Close()
+
+	content, _ := os.ReadFile(path)
+	if lines := strings.Count(string(content), "\n"); lines != maxHistorySize {
+		t.Errorf("Expected the file to be cut to %d lines, got %d", maxHistorySize, lines)
+	}
+}
+
+func TestIsPrivate(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected bool
+	}{
+		{"chat send 100003 see you at 6", true},
+		{"CHAT SEND 100003 hi", true},
+		{"chat broadcast @work offsite moved", true},
+		{"chat new @alice hi", true},
+		{"chat schedule 100003 +30m call me", true},
+		{"chat schedule list", false},
+		{"chat send-media 100003 photo.jpg", false},
+		{"chat list all", false},
+		{"chat 100003", false},
+		{"status", false},
+		{"", false},
+		{`chat send 100003 "unterminated`, true},
+	}
+
+	commands := commandTree()
+	for _, tc := range testCases {
+		if got := isPrivate(commands, tc.line); got != tc.expected {
+			t.Errorf("isPrivate(%q) = %v, expected %v", tc.line, got, tc.expected)
+		}
+	}
+}
+
+func TestFileHistoryKeepsMessagesOutOfFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	if err := os.WriteFile(path, []byte("status\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := loadHistory(path)
+	h.private = func(line string) bool { return isPrivate(commandTree(), line) }
+	h.Add("chat send 100003 see you at 6")
+	h.Add("chat list")
+	if h.Len() != 3 || h.At(1) != "chat send 100003 see you at 6" {
+		t.Errorf("Expected the message to be recallable this session, got %d entries", h.Len())
+	}
+	h.rewrite()
+	h.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "status\nchat list\n" {
+		t.Errorf("Expected the message to stay out of the file, got %q", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the history file to be private, got %v (%v)", info.Mode().Perm(), err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/abhi-praj/GoGram/internal/auth"
 	"github.com/abhi-praj/GoGram/internal/chat"
 	"github.com/abhi-praj/GoGram/internal/client"
+	"github.com/abhi-praj/GoGram/internal/outbox"
+	"github.com/abhi-praj/GoGram/internal/schedule"
 	"github.com/rivo/tview"
 )
 
@@ -71,6 +73,23 @@ func startTUI() error {
 	// Set chats in the interface
 	chatInterface.SetChats(chats)
 
+	// Send scheduled messages while the TUI is open
+	if err := dmInstance.StartScheduler(func(msg *schedule.Message) {
+		chatInterface.GetStatusBar().Update("⏰ " + msg.Outcome())
+	}); err != nil {
+		chatInterface.GetStatusBar().Update(fmt.Sprintf("Scheduled messages unavailable: %v", err))
+	}
+	defer dmInstance.StopScheduler()
+
+	// Retry queued messages in the background
+	if err := dmInstance.StartOutbox(func(item *outbox.Item) {
+		chatInterface.GetStatusBar().Update("📤 " + item.Outcome())
+		chatInterface.ShowPending()
+	}); err != nil {
+		chatInterface.GetStatusBar().Update(fmt.Sprintf("Outbox unavailable: %v", err))
+	}
+	defer dmInstance.StopOutbox()
+
 	// Run the interface
 	return chatInterface.Run()
 }
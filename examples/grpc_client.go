@@ -40,6 +40,7 @@ func main() {
 			fmt.Printf("   Username: %s\n", authStatus.Username)
 			fmt.Printf("   Unread count: %d\n", authStatus.UnreadCount)
 			fmt.Printf("   Notifications running: %v\n", authStatus.NotificationsRunning)
+			fmt.Printf("   Invisible mode: %v\n", authStatus.InvisibleMode)
 		}
 	}
 	fmt.Println()
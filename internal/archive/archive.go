@@ -0,0 +1,257 @@
+// Package archive reads the direct messages in Instagram's "Download your
+// information" export
+package archive
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/abhi-praj/GoGram/internal/history"
+)
+
+// Thread is one conversation from the archive
+type Thread struct {
+	// Folder is the thread's directory under messages/inbox, such as
+	// "alice_1234567890". It's the only stable ID the archive gives a thread.
+	Folder       string
+	Title        string
+	Participants []string
+	// Messages are oldest first and have IDs made from the folder and the
+	// send time, so importing a later archive finds the same IDs again
+	Messages []history.Message
+}
+
+// file is the layout of messages/inbox/<folder>/message_<n>.json
+type file struct {
+	Participants []struct {
+		Name string `json:"name"`
+	} `json:"participants"`
+	Messages []struct {
+		SenderName  string `json:"sender_name"`
+		TimestampMS int64  `json:"timestamp_ms"`
+		Content     string `json:"content"`
+		Photos      []any  `json:"photos"`
+		Videos      []any  `json:"videos"`
+		AudioFiles  []any  `json:"audio_files"`
+		GIFs        []any  `json:"gifs"`
+		Share       *struct {
+			Link      string `json:"link"`
+			ShareText string `json:"share_text"`
+		} `json:"share"`
+	} `json:"messages"`
+	Title string `json:"title"`
+}
+
+// messageFile matches message JSON files wherever the inbox sits in the
+// archive; newer exports nest it under your_instagram_activity/
+var messageFile = regexp.MustCompile(`(^|/)messages/inbox/([^/]+)/message_(\d+)\.json$`)
+
+// Open reads every thread in an archive, given as the downloaded zip or the
+// directory it was extracted to
+func Open(name string) ([]*Thread, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return Read(os.DirFS(name))
+	}
+
+	reader, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %v", err)
+	}
+	defer reader.Close()
+	return Read(reader)
+}
+
+// Read reads every thread in an extracted archive, sorted by title
+func Read(fsys fs.FS) ([]*Thread, error) {
+	type part struct {
+		number int
+		path   string
+	}
+	parts := make(map[string][]part)
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if m := messageFile.FindStringSubmatch(p); m != nil {
+			n, _ := strconv.Atoi(m[3])
+			parts[m[2]] = append(parts[m[2]], part{n, p})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %v", err)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("no messages found; expected messages/inbox/*/message_*.json")
+	}
+
+	threads := make([]*Thread, 0, len(parts))
+	for folder, files := range parts {
+		sort.Slice(files, func(i, j int) bool { return files[i].number < files[j].number })
+
+		thread := &Thread{Folder: folder}
+		for _, f := range files {
+			if err := thread.readFile(fsys, f.path); err != nil {
+				return nil, err
+			}
+		}
+		thread.finish()
+		threads = append(threads, thread)
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		if threads[i].Title != threads[j].Title {
+			return threads[i].Title < threads[j].Title
+		}
+		return threads[i].Folder < threads[j].Folder
+	})
+	return threads, nil
+}
+
+func (t *Thread) readFile(fsys fs.FS, name string) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", name, err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", name, err)
+	}
+
+	if t.Title == "" {
+		t.Title = FixEncoding(f.Title)
+	}
+	if t.Participants == nil {
+		for _, p := range f.Participants {
+			t.Participants = append(t.Participants, FixEncoding(p.Name))
+		}
+	}
+
+	for _, m := range f.Messages {
+		msg := history.Message{
+			Sender: FixEncoding(m.SenderName),
+			Text:   FixEncoding(m.Content),
+			Time:   time.UnixMilli(m.TimestampMS),
+			Kind:   "text",
+		}
+		switch {
+		case len(m.Photos) > 0:
+			msg.Kind, msg.HasMedia = "photo", true
+		case len(m.Videos) > 0:
+			msg.Kind, msg.HasMedia = "video", true
+		case len(m.AudioFiles) > 0:
+			msg.Kind, msg.HasMedia = "voice", true
+		case len(m.GIFs) > 0:
+			msg.Kind, msg.HasMedia = "animated", true
+		case m.Share != nil:
+			classifyShare(&msg, m.Share.Link, FixEncoding(m.Share.ShareText))
+		case msg.Text == likeText:
+			msg.Kind = "like"
+		}
+		t.Messages = append(t.Messages, msg)
+	}
+	return nil
+}
+
+// likeText is how the archive keeps a double-tap like. The API reports
+// likes as their own kind, so they are stored the same way.
+const likeText = "❤️"
+
+// attachmentText is the placeholder the archive puts in content for shares
+// sent without a message
+var attachmentText = regexp.MustCompile(`^.+ sent an attachment\.$`)
+
+// classifyShare gives a shared post, reel, story or link the kind and text
+// the API stores for it, so both copies are recognised as the same message
+func classifyShare(msg *history.Message, link, shareText string) {
+	if attachmentText.MatchString(msg.Text) {
+		msg.Text = ""
+	}
+	msg.Caption = shareText
+
+	switch {
+	case strings.Contains(link, "instagram.com/stories/"):
+		msg.Kind, msg.HasMedia = "story_reply", true
+	case strings.Contains(link, "instagram.com/reel/"):
+		msg.Kind, msg.HasMedia = "reel_share", true
+	case strings.Contains(link, "instagram.com/p/"):
+		msg.Kind, msg.HasMedia = "post_share", true
+	default:
+		// The API's link text is the message with the URL in it
+		msg.Kind = "link"
+		if msg.Text == "" {
+			msg.Text = link
+		}
+	}
+}
+
+// finish sorts the messages oldest first and gives them IDs. Messages sent in
+// the same millisecond are told apart by their order in the archive.
+func (t *Thread) finish() {
+	sort.SliceStable(t.Messages, func(i, j int) bool {
+		return t.Messages[i].Time.Before(t.Messages[j].Time)
+	})
+
+	n := 0
+	for i := range t.Messages {
+		if i > 0 && t.Messages[i].Time.Equal(t.Messages[i-1].Time) {
+			n++
+		} else {
+			n = 0
+		}
+		t.Messages[i].ID = fmt.Sprintf("%s%s:%d:%d", history.ImportedPrefix, t.Folder, t.Messages[i].Time.UnixMilli(), n)
+	}
+
+	if t.Title == "" {
+		t.Title = t.Folder
+	}
+}
+
+// FolderName is the name a thread's folder was made from: the other person's
+// username or the chat title, lowercased and without punctuation. The folder
+// adds "_" and a number to it.
+func (t *Thread) FolderName() string {
+	name := t.Folder
+	if i := strings.LastIndex(name, "_"); i > 0 {
+		if _, err := strconv.ParseUint(name[i+1:], 10, 64); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// FixEncoding undoes the mojibake in Instagram's exports, which write each
+// byte of UTF-8 text as its own Latin-1 character: "cafÃ©" becomes "café".
+// Text that isn't mojibake is returned unchanged.
+func FixEncoding(s string) string {
+	b := make([]byte, 0, len(s))
+	changed := false
+	for _, r := range s {
+		if r > 0xff {
+			return s
+		}
+		if r >= 0x80 {
+			changed = true
+		}
+		b = append(b, byte(r))
+	}
+	if !changed || !utf8.Valid(b) {
+		return s
+	}
+	return string(b)
+}
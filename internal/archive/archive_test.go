@@ -0,0 +1,156 @@
+package archive
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/history"
+)
+
+func TestFixEncoding(t *testing.T) {
+	testCases := map[string]string{
+		"cafÃ©":               "café",
+		"ð\u009f\u0098\u008a": "😊",
+		"plain ascii":         "plain ascii",
+		"already café":        "already café",
+		"snowman ☃":           "snowman ☃",
+		"Ã alone isn't UTF-8": "Ã alone isn't UTF-8",
+	}
+	for in, expected := range testCases {
+		if got := FixEncoding(in); got != expected {
+			t.Errorf("FixEncoding(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func checkThreads(t *testing.T, threads []*Thread) {
+	t.Helper()
+	if len(threads) != 2 {
+		t.Fatalf("Expected 2 threads, got %d", len(threads))
+	}
+
+	alice := threads[0]
+	if alice.Title != "Alice Smith" || alice.Folder != "alicesmith_1234567890" || alice.FolderName() != "alicesmith" {
+		t.Errorf("Unexpected thread %+v", alice)
+	}
+	if len(alice.Participants) != 2 || len(alice.Messages) != 4 {
+		t.Fatalf("Expected 2 participants and 4 messages across both files, got %v and %d", alice.Participants, len(alice.Messages))
+	}
+
+	first, last := alice.Messages[0], alice.Messages[3]
+	if first.Text != "Lunch tomorrow?" || !first.Time.Equal(time.UnixMilli(1583064000000)) {
+		t.Errorf("Expected messages oldest first, got %+v", first)
+	}
+	if last.Text != "See you at the café 😊" || last.Sender != "Sam" {
+		t.Errorf("Expected mojibake to be fixed, got %+v", last)
+	}
+	if share := alice.Messages[1]; share.Kind != "post_share" || !share.HasMedia || share.Caption != "Best brunch in town" || share.Text != "" {
+		t.Errorf("Unexpected share %+v", share)
+	}
+	if photo := alice.Messages[2]; photo.Kind != "photo" || !photo.HasMedia {
+		t.Errorf("Unexpected photo %+v", photo)
+	}
+
+	trip := threads[1]
+	if trip.Title != "Weekend trip ⛺" || trip.Messages[0].Kind != "voice" {
+		t.Errorf("Unexpected thread %+v", trip)
+	}
+	// Identical messages in the same millisecond are both kept
+	if a, b := trip.Messages[1].ID, trip.Messages[2].ID; a == b || !strings.HasPrefix(a, "archive:weekendtrip_9876543210:") {
+		t.Errorf("Expected distinct archive IDs, got %q and %q", a, b)
+	}
+}
+
+func TestClassifyShare(t *testing.T) {
+	testCases := []struct {
+		content, link string
+		kind, text    string
+	}{
+		{"", "https://www.instagram.com/p/B9Abc/", "post_share", ""},
+		{"Alice Smith sent an attachment.", "https://www.instagram.com/reel/C1xyz/", "reel_share", ""},
+		{"so good", "https://www.instagram.com/stories/brunchclub/3141/", "story_reply", "so good"},
+		{"look https://example.com/menu", "https://example.com/menu", "link", "look https://example.com/menu"},
+		{"", "https://example.com/menu", "link", "https://example.com/menu"},
+	}
+
+	for _, tc := range testCases {
+		msg := history.Message{Text: tc.content, Kind: "text"}
+		classifyShare(&msg, tc.link, "caption")
+		if msg.Kind != tc.kind || msg.Text != tc.text || msg.Caption != "caption" {
+			t.Errorf("%s: expected %s %q, got %s %q", tc.link, tc.kind, tc.text, msg.Kind, msg.Text)
+		}
+	}
+}
+
+func TestOpenDir(t *testing.T) {
+	threads, err := Open(filepath.Join("testdata", "archive"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkThreads(t, threads)
+}
+
+// zipFixture packs the fixture archive under prefix, the way newer exports
+// nest it
+func zipFixture(t *testing.T, prefix string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "instagram.zip")
+	file, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	w := zip.NewWriter(file)
+	root := filepath.Join("testdata", "archive")
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		entry, err := w.Create(prefix + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(entry, src)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestOpenZip(t *testing.T) {
+	threads, err := Open(zipFixture(t, "your_instagram_activity/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkThreads(t, threads)
+
+	// IDs don't depend on where the inbox sits, so both layouts merge
+	dirThreads, _ := Open(filepath.Join("testdata", "archive"))
+	if threads[0].Messages[0].ID != dirThreads[0].Messages[0].ID {
+		t.Errorf("Expected the same IDs from the zip and the directory")
+	}
+}
+
+func TestOpenWithoutMessages(t *testing.T) {
+	if _, err := Open(t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without messages")
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/abhi-praj/GoGram/internal/client"
 	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/storage"
 )
 
 // InstagramAuth handles Instagram authentication operations
@@ -170,3 +172,51 @@ func (a *InstagramAuth) IsLoggedIn() bool {
 func (a *InstagramAuth) GetClient() *client.ClientWrapper {
 	return a.client
 }
+
+// MigrationResult describes what happened to one account's session file
+type MigrationResult struct {
+	Username string
+	Migrated bool
+	Err      error
+}
+
+// MigrateSessions encrypts any plaintext session files under advanced.users_dir
+// and tightens permissions on the account directories
+func (a *InstagramAuth) MigrateSessions() ([]MigrationResult, error) {
+	store, err := client.NewSessionStoreFromConfig(a.config)
+	if err != nil {
+		return nil, err
+	}
+
+	usersDir := a.config.GetString("advanced.users_dir", "")
+	entries, err := os.ReadDir(usersDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read users directory: %v", err)
+	}
+
+	var results []MigrationResult
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		userDir := filepath.Join(usersDir, entry.Name())
+		sessionPath := filepath.Join(userDir, "session.json")
+		if _, err := os.Stat(sessionPath); err != nil {
+			continue
+		}
+
+		result := MigrationResult{Username: entry.Name()}
+		if err := os.Chmod(userDir, storage.PrivateDirMode); err != nil {
+			result.Err = fmt.Errorf("failed to set directory permissions: %v", err)
+		} else {
+			result.Migrated, result.Err = store.Migrate(sessionPath)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
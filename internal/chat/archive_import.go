@@ -0,0 +1,150 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/abhi-praj/GoGram/internal/archive"
+	"github.com/abhi-praj/GoGram/internal/history"
+)
+
+// archiveThreadPrefix starts the thread IDs of archived conversations that
+// aren't in the inbox any more, such as chats that were deleted
+const archiveThreadPrefix = "archive_"
+
+// ImportedThread reports how one archived conversation was imported
+type ImportedThread struct {
+	Title    string
+	ChatID   string // internal ID, usable with chat history, search and export
+	ThreadID string
+	// Matched is set when the conversation was found in the inbox. Others are
+	// kept under their own thread so their history is still searchable.
+	Matched  bool
+	Messages int
+	Added    int
+}
+
+// ImportArchive merges the direct messages in an Instagram data download, a
+// zip or the directory it was extracted to, into the local history.
+// Conversations are matched to inbox chats by title or by the username their
+// folder is named after. Messages already stored are skipped, so importing a
+// newer archive later only adds what's new.
+func (dm *DirectMessages) ImportArchive(path string) ([]ImportedThread, error) {
+	if dm.insta == nil || dm.history == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	threads, err := archive.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	chats, err := dm.GetChatsWithLimit(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// The archive names you by your full name, or by your username if you
+	// haven't set one
+	ownUsername := dm.client.GetUsername()
+	ownNames := []string{ownUsername}
+	if dm.insta.Account != nil {
+		ownNames = append(ownNames, dm.insta.Account.FullName, dm.insta.Account.Username)
+	}
+
+	results := make([]ImportedThread, 0, len(threads))
+	for _, thread := range threads {
+		result := ImportedThread{Title: thread.Title, Messages: len(thread.Messages)}
+
+		chat := matchArchiveThread(thread, chats)
+		if chat != nil {
+			result.Matched = true
+			result.ThreadID = chat.ID
+			result.Title = chat.Title
+		} else {
+			result.ThreadID = archiveThreadPrefix + thread.Folder
+		}
+		result.ChatID = dm.chatIDs.assign([]string{result.ThreadID})[result.ThreadID]
+
+		usernames := make(map[string]string)
+		if chat != nil {
+			for _, user := range chat.Users {
+				if user.FullName != "" {
+					usernames[user.FullName] = user.Username
+				}
+			}
+		}
+		nameSenders(thread.Messages, ownNames, ownUsername, usernames)
+
+		title := result.Title
+		if chat != nil {
+			// Keep the title the API last reported
+			title = ""
+		}
+		added, err := dm.history.Add(result.ThreadID, title, thread.Messages)
+		if err != nil {
+			return results, fmt.Errorf("failed to import %s: %v", thread.Title, err)
+		}
+		result.Added = added
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// nameSenders names the senders of archived messages the way messages from
+// the API are named: "You" for your own, and the others by their name along
+// with the username from usernames, keyed by full name
+func nameSenders(messages []history.Message, ownNames []string, ownUsername string, usernames map[string]string) {
+	own := make(map[string]bool, len(ownNames))
+	for _, name := range ownNames {
+		if name != "" {
+			own[name] = true
+		}
+	}
+
+	for i := range messages {
+		msg := &messages[i]
+		if own[msg.Sender] {
+			msg.Sender, msg.Username = "You", ownUsername
+		} else {
+			msg.Username = usernames[msg.Sender]
+		}
+	}
+}
+
+// matchArchiveThread finds the inbox chat an archived conversation belongs
+// to. The archive has no thread IDs, so it goes by the chat title, or for
+// one-to-one chats the other person's username or name, which the folder is
+// named after. Ambiguous matches are left unmatched rather than guessed.
+func matchArchiveThread(thread *archive.Thread, chats []*Chat) *Chat {
+	folder := folderKey(thread.FolderName())
+
+	var match *Chat
+	for _, chat := range chats {
+		matched := strings.EqualFold(chat.Title, thread.Title)
+		if !matched && !chat.IsGroup && len(chat.Users) == 1 {
+			user := chat.Users[0]
+			matched = folder != "" && (folder == folderKey(user.Username) || folder == folderKey(user.FullName))
+		}
+		if !matched {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = chat
+	}
+	return match
+}
+
+// folderKey reduces a name the way archive folder names are made, keeping
+// only lowercase letters and digits
+func folderKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
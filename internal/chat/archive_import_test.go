@@ -0,0 +1,127 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/archive"
+	"github.com/abhi-praj/GoGram/internal/history"
+)
+
+func TestMatchArchiveThread(t *testing.T) {
+	chats := []*Chat{
+		{ID: "t1", Title: "Alice Smith", Users: []*goinsta.User{{Username: "alice.smith", FullName: "Alice Smith"}}},
+		{ID: "t2", Title: "bo_k", Users: []*goinsta.User{{Username: "bo_k", FullName: "Bo"}}},
+		{ID: "t3", Title: "Weekend trip", IsGroup: true, Users: []*goinsta.User{{Username: "bo_k"}, {Username: "alice.smith"}}},
+		{ID: "t4", Title: "Book club", IsGroup: true},
+		{ID: "t5", Title: "Book club", IsGroup: true},
+	}
+
+	testCases := []struct {
+		title, folder string
+		expected      string
+	}{
+		{"Alice Smith", "alicesmith_1234567890", "t1"},
+		// Renamed since the archive was made; the folder still has the username
+		{"Bo K", "bok_555", "t2"},
+		{"weekend TRIP", "weekendtrip_987", "t3"},
+		{"Book club", "bookclub_1", ""},
+		{"Old friend", "oldfriend_42", ""},
+	}
+
+	for _, tc := range testCases {
+		thread := &archive.Thread{Title: tc.title, Folder: tc.folder}
+		got := matchArchiveThread(thread, chats)
+		switch {
+		case tc.expected == "" && got != nil:
+			t.Errorf("%s: expected no match, got %s", tc.title, got.ID)
+		case tc.expected != "" && (got == nil || got.ID != tc.expected):
+			t.Errorf("%s: expected %s, got %v", tc.title, tc.expected, got)
+		}
+	}
+}
+
+// archiveFixture is a data download holding a like, a shared post, a shared
+// reel, a photo and a text from Alice, and a text from you
+const archiveFixture = `{
+  "participants": [{"name": "Alice Smith"}, {"name": "sam.k"}],
+  "messages": [
+    {"sender_name": "sam.k", "timestamp_ms": 1700000005100, "content": "on my way"},
+    {"sender_name": "Alice Smith", "timestamp_ms": 1700000004100, "content": "â\u009d¤ï¸\u008f"},
+    {"sender_name": "Alice Smith", "timestamp_ms": 1700000003100, "content": "Alice Smith sent an attachment.",
+     "share": {"link": "https://www.instagram.com/reel/C1xyz/", "share_text": "Try this", "original_content_owner": "chef"}},
+    {"sender_name": "Alice Smith", "timestamp_ms": 1700000002100,
+     "share": {"link": "https://www.instagram.com/p/B9Abc/", "share_text": "Best brunch in town", "original_content_owner": "brunchclub"}},
+    {"sender_name": "Alice Smith", "timestamp_ms": 1700000001100, "photos": [{"uri": "photos/1.jpg"}]},
+    {"sender_name": "Alice Smith", "timestamp_ms": 1700000000100, "content": "Lunch tomorrow?"}
+  ],
+  "title": "Alice Smith"
+}`
+
+func TestArchiveMergesWithAPIHistory(t *testing.T) {
+	// The same messages as the API returns them
+	var items []*goinsta.InboxItem
+	err := json.Unmarshal([]byte(`[
+		{"item_id": "1", "item_type": "text", "text": "Lunch tomorrow?", "timestamp": 1700000000000000},
+		{"item_id": "2", "item_type": "media", "media": {"media_type": 1}, "timestamp": 1700000001000000},
+		{"item_id": "3", "item_type": "media_share", "media_share": {"caption": {"text": "Best brunch in town"}}, "timestamp": 1700000002000000},
+		{"item_id": "4", "item_type": "clip", "clip": {"clip": {"caption": {"text": "Try this"}}}, "timestamp": 1700000003000000},
+		{"item_id": "5", "item_type": "like", "like": "❤️", "timestamp": 1700000004000000},
+		{"item_id": "6", "item_type": "text", "text": "on my way", "timestamp": 1700000005000000}
+	]`), &items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var live []history.Message
+	for _, item := range items {
+		msg := newMessageFromItem(item)
+		msg.Sender, msg.Username = "Alice Smith", "alice.smith"
+		if item.ID == "6" {
+			msg.Sender, msg.Username = "You", "sam.k"
+		}
+		live = append(live, historyMessage(msg))
+	}
+
+	store := history.NewStore(t.TempDir())
+	if added, err := store.Add("t1", "Alice Smith", live); err != nil || added != len(live) {
+		t.Fatalf("Expected %d messages from the API, got %d, %v", len(live), added, err)
+	}
+
+	threads, err := archive.Read(fstest.MapFS{
+		"messages/inbox/alicesmith_1234567890/message_1.json": {Data: []byte(archiveFixture)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := threads[0].Messages
+	// No full name set, so your messages carry your username
+	nameSenders(messages, []string{"sam.k", ""}, "sam.k", map[string]string{"Alice Smith": "alice.smith"})
+
+	added, err := store.Add("t1", "", messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 0 {
+		thread, _ := store.Thread("t1")
+		for _, msg := range thread.Messages {
+			if msg.Imported() {
+				t.Errorf("Imported a duplicate: %s %s %q", msg.Sender, msg.Kind, msg.Text)
+			}
+		}
+	}
+}
+
+func TestNameSenders(t *testing.T) {
+	messages := []history.Message{{Sender: "Sam K"}, {Sender: "sam.k"}, {Sender: "Alice Smith"}, {Sender: "bo_k"}}
+	nameSenders(messages, []string{"sam.k", "Sam K"}, "sam.k", map[string]string{"Alice Smith": "alice.smith"})
+
+	expected := []struct{ sender, username string }{{"You", "sam.k"}, {"You", "sam.k"}, {"Alice Smith", "alice.smith"}, {"bo_k", ""}}
+	for i, e := range expected {
+		if messages[i].Sender != e.sender || messages[i].Username != e.username {
+			t.Errorf("Message %d: expected %s (%s), got %s (%s)", i, e.sender, e.username, messages[i].Sender, messages[i].Username)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/client"
+	"github.com/abhi-praj/GoGram/internal/config"
+)
+
+// maxBroadcastDelay caps how far a broadcast slows down after rate limits
+const maxBroadcastDelay = time.Minute
+
+// BroadcastResult is the outcome of sending a broadcast to one chat
+type BroadcastResult struct {
+	Chat     *Chat
+	Err      error
+	Attempts int
+}
+
+// Pacing spaces out the sends of a broadcast
+type Pacing struct {
+	Delay      time.Duration // between chats, doubled after each rate limit
+	MaxRetries int           // per chat, for sends that surely didn't go through
+}
+
+// BroadcastPacing reads broadcast.delay_seconds and broadcast.max_retries
+func BroadcastPacing() Pacing {
+	cfg := config.GetInstance()
+	return Pacing{
+		Delay:      time.Duration(cfg.GetInt("broadcast.delay_seconds", 3)) * time.Second,
+		MaxRetries: cfg.GetInt("broadcast.max_retries", 3),
+	}
+}
+
+// BroadcastConfirmAbove is how many chats a broadcast can go to before the
+// CLI asks for confirmation
+func BroadcastConfirmAbove() int {
+	return config.GetInstance().GetInt("broadcast.confirm_above", 5)
+}
+
+// ParseBroadcastTargets splits "id,id,..." into chat IDs, dropping blanks and
+// duplicates, or returns the label for "@label"
+func ParseBroadcastTargets(arg string) (ids []string, label string) {
+	if strings.HasPrefix(arg, "@") {
+		return nil, strings.TrimPrefix(arg, "@")
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(arg, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, ""
+}
+
+// BroadcastTargets resolves chat IDs into chats, syncing the inbox once. If
+// label is set, it returns every chat with that label instead.
+func (dm *DirectMessages) BroadcastTargets(ids []string, label string) ([]*Chat, error) {
+	if label != "" {
+		return dm.labelledChats(label)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no chats given")
+	}
+
+	chats, err := dm.GetChatsWithLimit(0)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]*Chat, 0, len(ids))
+	for _, id := range ids {
+		target := chatWithID(chats, id)
+		if target == nil {
+			return nil, fmt.Errorf("chat %s not found", id)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func chatWithID(chats []*Chat, id string) *Chat {
+	for _, chat := range chats {
+		if chat.InternalID == id || chat.ID == id {
+			return chat
+		}
+	}
+	return nil
+}
+
+// chatWithUser finds your one-on-one chat with "@username"
+func chatWithUser(chats []*Chat, target string) *Chat {
+	username, ok := strings.CutPrefix(target, "@")
+	if !ok {
+		return nil
+	}
+	for _, chat := range chats {
+		if !chat.IsGroup && len(chat.Users) == 1 && strings.EqualFold(chat.Users[0].Username, username) {
+			return chat
+		}
+	}
+	return nil
+}
+
+// Broadcast sends text to each target in turn, calling progress after every
+// chat. Cancelling ctx stops it; the chats not reached yet are reported with
+// ctx's error.
+func (dm *DirectMessages) Broadcast(ctx context.Context, targets []*Chat, text string, progress func(BroadcastResult)) []BroadcastResult {
+	return dm.newBroadcaster(text).run(ctx, targets, progress)
+}
+
+// newBroadcaster sends text with one client context per chat, kept across
+// attempts like an outbox item's key, so Instagram ignores a retry of a send
+// that actually got through. That makes any temporary failure safe to retry.
+func (dm *DirectMessages) newBroadcaster(text string) *broadcaster {
+	keys := make(map[string]string)
+	return &broadcaster{
+		send: func(target *Chat) error {
+			if dm.insta == nil {
+				return fmt.Errorf("not logged in")
+			}
+			if keys[target.ID] == "" {
+				keys[target.ID] = client.NewClientContext()
+			}
+			return dm.sendText(target.ID, text, keys[target.ID])
+		},
+		retry:  client.IsTemporary,
+		pacing: BroadcastPacing(),
+		sleep:  sleepContext,
+	}
+}
+
+// broadcaster sends to one chat after another, slowing down when sends fail.
+// Only failures retry reports true for are tried again.
+type broadcaster struct {
+	send   func(*Chat) error
+	retry  func(error) bool
+	pacing Pacing
+	sleep  func(context.Context, time.Duration) error
+}
+
+func (b *broadcaster) run(ctx context.Context, targets []*Chat, progress func(BroadcastResult)) []BroadcastResult {
+	results := make([]BroadcastResult, 0, len(targets))
+	report := func(result BroadcastResult) {
+		results = append(results, result)
+		if progress != nil {
+			progress(result)
+		}
+	}
+
+	delay := b.pacing.Delay
+	for i, target := range targets {
+		if i > 0 {
+			if err := b.sleep(ctx, delay); err != nil {
+				for _, skipped := range targets[i:] {
+					report(BroadcastResult{Chat: skipped, Err: err})
+				}
+				break
+			}
+		}
+
+		result := BroadcastResult{Chat: target}
+		for {
+			result.Attempts++
+			result.Err = b.send(target)
+			if result.Err == nil || !b.retry(result.Err) || result.Attempts > b.pacing.MaxRetries {
+				break
+			}
+
+			// Instagram is pushing back, so wait longer for this chat and the rest
+			delay = slowDown(delay)
+			if err := b.sleep(ctx, delay); err != nil {
+				result.Err = err
+				break
+			}
+		}
+		report(result)
+	}
+	return results
+}
+
+// slowDown doubles a broadcast delay, starting from a second, up to maxBroadcastDelay
+func slowDown(delay time.Duration) time.Duration {
+	if delay < time.Second {
+		delay = time.Second
+	}
+	if delay *= 2; delay > maxBroadcastDelay {
+		delay = maxBroadcastDelay
+	}
+	return delay
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ForwardMessage forwards a message from one chat to another, keeping its
+// media or shared post intact. The target is a chat ID or "@username" for
+// a one-on-one chat.
+func (dm *DirectMessages) ForwardMessage(fromChatID, messageID, toChatID string) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+
+	chats, err := dm.GetChatsWithLimit(0)
+	if err != nil {
+		return err
+	}
+	from := chatWithID(chats, fromChatID)
+	if from == nil {
+		return fmt.Errorf("chat %s not found", fromChatID)
+	}
+	to := chatWithID(chats, toChatID)
+	if to == nil {
+		to = chatWithUser(chats, toChatID)
+	}
+	if to == nil {
+		return fmt.Errorf("chat %s not found", toChatID)
+	}
+
+	form := url.Values{}
+	form.Set("forwarded_from_thread_id", from.ID)
+	form.Set("forwarded_from_thread_item_id", messageID)
+	if err := dm.broadcast(to.ID, "forward", form); err != nil {
+		return fmt.Errorf("failed to forward message: %v", err)
+	}
+	return nil
+}
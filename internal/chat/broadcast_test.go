@@ -0,0 +1,144 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errRateLimited = errors.New("rate limited")
+
+func TestParseBroadcastTargets(t *testing.T) {
+	ids, label := ParseBroadcastTargets("100000, 100001,,100000")
+	if !reflect.DeepEqual(ids, []string{"100000", "100001"}) || label != "" {
+		t.Errorf("Unexpected targets %v %q", ids, label)
+	}
+
+	ids, label = ParseBroadcastTargets("@customers")
+	if ids != nil || label != "customers" {
+		t.Errorf("Expected label customers, got %v %q", ids, label)
+	}
+
+	if ids, label := ParseBroadcastTargets(" , "); ids != nil || label != "" {
+		t.Errorf("Expected no targets, got %v %q", ids, label)
+	}
+}
+
+func TestBroadcasterRetriesAndSlowsDown(t *testing.T) {
+	failures := map[string]int{"b": 2}
+	var sleeps []time.Duration
+	var sent []string
+
+	b := &broadcaster{
+		send: func(c *Chat) error {
+			if failures[c.ID] > 0 {
+				failures[c.ID]--
+				return errRateLimited
+			}
+			sent = append(sent, c.ID)
+			return nil
+		},
+		retry:  func(err error) bool { return errors.Is(err, errRateLimited) },
+		pacing: Pacing{Delay: 3 * time.Second, MaxRetries: 3},
+		sleep: func(ctx context.Context, d time.Duration) error {
+			sleeps = append(sleeps, d)
+			return nil
+		},
+	}
+
+	var reported int
+	results := b.run(context.Background(), []*Chat{{ID: "a"}, {ID: "b"}, {ID: "c"}}, func(BroadcastResult) { reported++ })
+
+	if !reflect.DeepEqual(sent, []string{"a", "b", "c"}) {
+		t.Errorf("Expected every chat to be sent to, got %v", sent)
+	}
+	if reported != 3 || results[1].Attempts != 3 || results[1].Err != nil {
+		t.Errorf("Unexpected results %+v", results)
+	}
+
+	// The pause before c stays at the slowed-down delay
+	expected := []time.Duration{3 * time.Second, 6 * time.Second, 12 * time.Second, 12 * time.Second}
+	if !reflect.DeepEqual(sleeps, expected) {
+		t.Errorf("Expected sleeps %v, got %v", expected, sleeps)
+	}
+}
+
+func TestBroadcasterGivesUp(t *testing.T) {
+	refused := errors.New("refused")
+	b := &broadcaster{
+		send: func(c *Chat) error {
+			if c.ID == "a" {
+				return refused
+			}
+			return errRateLimited
+		},
+		retry:  func(err error) bool { return errors.Is(err, errRateLimited) },
+		pacing: Pacing{MaxRetries: 1},
+		sleep:  func(context.Context, time.Duration) error { return nil },
+	}
+
+	results := b.run(context.Background(), []*Chat{{ID: "a"}, {ID: "b"}}, nil)
+	if results[0].Err != refused || results[0].Attempts != 1 {
+		t.Errorf("Expected a permanent error not to be retried, got %+v", results[0])
+	}
+	if results[1].Err != errRateLimited || results[1].Attempts != 2 {
+		t.Errorf("Expected b to be tried twice, got %+v", results[1])
+	}
+}
+
+func TestBroadcasterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &broadcaster{
+		send: func(*Chat) error {
+			cancel()
+			return nil
+		},
+		retry: func(error) bool { return false },
+		sleep: sleepContext,
+	}
+
+	results := b.run(ctx, []*Chat{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil)
+	if len(results) != 3 || results[0].Err != nil {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	for _, result := range results[1:] {
+		if !errors.Is(result.Err, context.Canceled) || result.Attempts != 0 {
+			t.Errorf("Expected %s to be skipped, got %+v", result.Chat.ID, result)
+		}
+	}
+}
+
+func TestBroadcastReusesClientContextOnRetry(t *testing.T) {
+	var forms []url.Values
+	timeouts := 1
+	dm := stubInbox(t, &stubPost{})
+	dm.post = func(path string, form url.Values) ([]byte, error) {
+		forms = append(forms, form)
+		if timeouts > 0 {
+			timeouts--
+			return nil, errors.New("i/o timeout")
+		}
+		return []byte(`{"status": "ok"}`), nil
+	}
+
+	b := dm.newBroadcaster("hello")
+	b.pacing = Pacing{MaxRetries: 3}
+	b.sleep = func(context.Context, time.Duration) error { return nil }
+	results := b.run(context.Background(), []*Chat{{ID: "a"}, {ID: "b"}}, nil)
+
+	if results[0].Err != nil || results[0].Attempts != 2 || results[1].Err != nil {
+		t.Fatalf("Expected a to be retried after timing out, got %+v", results)
+	}
+	if len(forms) != 3 || forms[0].Get("text") != "hello" {
+		t.Fatalf("Unexpected sends %v", forms)
+	}
+	if forms[0].Get("client_context") != forms[1].Get("client_context") {
+		t.Error("Expected a retry to reuse the chat's client context")
+	}
+	if forms[1].Get("client_context") == forms[2].Get("client_context") {
+		t.Error("Expected each chat to get its own client context")
+	}
+}
@@ -0,0 +1,147 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+// firstInternalID is the first internal ID handed out to an account
+const firstInternalID = 100000
+
+// userFile is where an account keeps a file under advanced.users_dir
+func userFile(username, name string) string {
+	cfg := config.GetInstance()
+	usersDir := cfg.GetString("advanced.users_dir", filepath.Join(cfg.GetConfigDir(), "users"))
+	if username == "" {
+		username = "default"
+	}
+	return filepath.Join(usersDir, username, name)
+}
+
+// chatIDs hands out short internal IDs for threads. They are saved per
+// account, so a chat keeps its ID across sessions and scripts can rely on it.
+type chatIDs struct {
+	path  string
+	mutex sync.Mutex
+	state chatIDState
+}
+
+type chatIDState struct {
+	Next int               `json:"next"`
+	IDs  map[string]string `json:"ids"` // thread ID -> internal ID
+}
+
+// loadChatIDs reads the saved IDs at path. With no path, IDs only last for
+// this process.
+func loadChatIDs(path string) *chatIDs {
+	c := &chatIDs{path: path}
+	c.state = c.read()
+	return c
+}
+
+func (c *chatIDs) read() chatIDState {
+	state := chatIDState{Next: firstInternalID, IDs: make(map[string]string)}
+	if c.path == "" {
+		return state
+	}
+	if err := storage.ReadJSON(c.path, &state); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return chatIDState{Next: firstInternalID, IDs: make(map[string]string)}
+	}
+	if state.IDs == nil {
+		state.IDs = make(map[string]string)
+	}
+	if state.Next < firstInternalID {
+		state.Next = firstInternalID
+	}
+	return state
+}
+
+// get returns the internal ID of a thread that already has one
+func (c *chatIDs) get(threadID string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	id, ok := c.state.IDs[threadID]
+	return id, ok
+}
+
+// thread returns the thread an internal ID was given to
+func (c *chatIDs) thread(internalID string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for threadID, id := range c.state.IDs {
+		if id == internalID {
+			return threadID, true
+		}
+	}
+	return "", false
+}
+
+// assign returns internal IDs for the threads, giving new ones the next free
+// IDs in order. New IDs are saved under a lock, merged with any another
+// GoGram process saved in the meantime.
+func (c *chatIDs) assign(threadIDs []string) map[string]string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.missing(threadIDs) && c.path != "" {
+		if unlock, err := storage.Lock(c.path); err == nil {
+			c.merge(c.read())
+			if c.missing(threadIDs) {
+				c.add(threadIDs)
+				storage.WriteJSON(c.path, c.state)
+			}
+			unlock()
+		}
+	}
+	// Without the file, IDs still have to work for this session
+	c.add(threadIDs)
+
+	ids := make(map[string]string, len(threadIDs))
+	for _, threadID := range threadIDs {
+		ids[threadID] = c.state.IDs[threadID]
+	}
+	return ids
+}
+
+func (c *chatIDs) missing(threadIDs []string) bool {
+	for _, threadID := range threadIDs {
+		if _, ok := c.state.IDs[threadID]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *chatIDs) add(threadIDs []string) {
+	for _, threadID := range threadIDs {
+		if _, ok := c.state.IDs[threadID]; !ok {
+			c.state.IDs[threadID] = fmt.Sprintf("%06d", c.state.Next)
+			c.state.Next++
+		}
+	}
+}
+
+// merge takes saved IDs over ours, since other processes may already be
+// using them
+func (c *chatIDs) merge(saved chatIDState) {
+	taken := make(map[string]bool, len(saved.IDs))
+	for threadID, id := range saved.IDs {
+		taken[id] = true
+		c.state.IDs[threadID] = id
+	}
+	if saved.Next > c.state.Next {
+		c.state.Next = saved.Next
+	}
+	// Drop IDs we handed out that now belong to another thread
+	for threadID, id := range c.state.IDs {
+		if _, ok := saved.IDs[threadID]; !ok && taken[id] {
+			delete(c.state.IDs, threadID)
+		}
+	}
+}
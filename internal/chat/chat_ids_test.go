@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestChatIDsStableAcrossSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat_ids.json")
+
+	first := loadChatIDs(path).assign([]string{"t1", "t2"})
+	if first["t1"] != "100000" || first["t2"] != "100001" {
+		t.Fatalf("Unexpected first IDs %v", first)
+	}
+
+	// A later session sees the chats in a different order plus a new one
+	again := loadChatIDs(path).assign([]string{"t3", "t2", "t1"})
+	if again["t1"] != first["t1"] || again["t2"] != first["t2"] || again["t3"] != "100002" {
+		t.Errorf("Expected IDs to survive a restart, got %v", again)
+	}
+}
+
+func TestChatIDsMergeConcurrentProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat_ids.json")
+	a := loadChatIDs(path)
+	b := loadChatIDs(path)
+
+	idsA := a.assign([]string{"t1"})
+	idsB := b.assign([]string{"t2"})
+	if idsA["t1"] == idsB["t2"] {
+		t.Fatalf("Expected different threads to get different IDs, both got %s", idsA["t1"])
+	}
+
+	// Each process picks up the other's IDs
+	if ids := a.assign([]string{"t2"}); ids["t2"] != idsB["t2"] {
+		t.Errorf("Expected t2 to keep %s, got %s", idsB["t2"], ids["t2"])
+	}
+}
+
+func TestChatIDsWithoutFile(t *testing.T) {
+	ids := loadChatIDs("").assign([]string{"t1"})
+	if ids["t1"] != "100000" {
+		t.Errorf("Expected in-memory IDs without a file, got %v", ids)
+	}
+}
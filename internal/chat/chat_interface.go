@@ -1,11 +1,15 @@
 package chat
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/history"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -25,13 +29,15 @@ type ChatInterface struct {
 	stopRefresh          chan bool
 	refreshEnabled       bool
 	currentChat          *Chat
+	dm                   *DirectMessages
+	typing               *TypingIndicator
 	onMessageSend        func(string, string) error
 	onReplySend          func(string, string, string) error
 	onUnsendMessage      func(string) error
 }
 
 // NewChatInterface creates a new chat interface
-func NewChatInterface(app *tview.Application, onMessageSend func(string, string) error, onReplySend func(string, string, string) error, onUnsendMessage func(string) error) *ChatInterface {
+func NewChatInterface(app *tview.Application, onMessageSend func(string, string) error, onReplySend func(string, string, string) error, onUnsendMessage func(string) error, dm *DirectMessages) *ChatInterface {
 	ci := &ChatInterface{
 		app:                  app,
 		mode:                 ChatModeChat,
@@ -42,6 +48,7 @@ func NewChatInterface(app *tview.Application, onMessageSend func(string, string)
 		onMessageSend:        onMessageSend,
 		onReplySend:          onReplySend,
 		onUnsendMessage:      onUnsendMessage,
+		dm:                   dm,
 	}
 
 	// Initialize components
@@ -52,10 +59,71 @@ func NewChatInterface(app *tview.Application, onMessageSend func(string, string)
 
 	// Set up the layout
 	ci.setupLayout()
+	ci.inputBox.SetInputCapture(ci.handleInputKey)
+	ci.chatMenu.SetActionHandler(ci.handleChatAction)
+	ci.chatWindow.SetSearchHandler(ci.searchChat, func() {
+		ci.app.SetFocus(ci.inputBox)
+	})
+	if dm != nil {
+		ci.typing = NewTypingIndicator(dm.SendTyping)
+		ci.inputBox.SetOnChange(ci.handleInputChanged)
+	}
 
 	return ci
 }
 
+// handleInputKey adds chat keybindings to the input box: Ctrl+E picks a
+// message to react to, Ctrl+F searches the chat, and Up/Down move the
+// selection while one is needed
+func (ci *ChatInterface) handleInputKey(event *tcell.EventKey) *tcell.EventKey {
+	selecting := ci.mode == ChatModeReply || ci.mode == ChatModeReact
+
+	switch {
+	case event.Key() == tcell.KeyCtrlE:
+		ci.enterReactMode()
+		return nil
+	case event.Key() == tcell.KeyCtrlF:
+		ci.chatWindow.OpenSearch("")
+		return nil
+	case selecting && event.Key() == tcell.KeyUp:
+		ci.chatWindow.MoveSelection(-1)
+		ci.chatWindow.Update()
+		return nil
+	case selecting && event.Key() == tcell.KeyDown:
+		ci.chatWindow.MoveSelection(1)
+		ci.chatWindow.Update()
+		return nil
+	case selecting && event.Key() == tcell.KeyEscape:
+		ci.SetMode(ChatModeChat)
+		ci.chatWindow.Update()
+		return nil
+	}
+	return event
+}
+
+// handleInputChanged sends typing indicators while a message is being written.
+// Commands and reactions aren't messages, so they count as not typing.
+func (ci *ChatInterface) handleInputChanged(text string) {
+	if ci.currentChat == nil {
+		return
+	}
+	text = strings.TrimSpace(text)
+	typing := text != "" && !strings.HasPrefix(text, "/") && ci.mode != ChatModeReact
+	ci.typing.Update(ci.currentChat.InternalID, typing)
+}
+
+// enterReactMode selects the newest message for reacting
+func (ci *ChatInterface) enterReactMode() {
+	if ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return
+	}
+
+	ci.chatWindow.SelectLast()
+	ci.SetMode(ChatModeReact)
+	ci.chatWindow.Update()
+}
+
 // setupLayout sets up the application layout
 func (ci *ChatInterface) setupLayout() {
 	// Create a flex layout
@@ -68,7 +136,7 @@ func (ci *ChatInterface) setupLayout() {
 	flex.AddItem(ci.chatMenu.GetSearchInput(), 3, 0, false)
 
 	// Add chat window in the middle (2/3 of height)
-	flex.AddItem(ci.chatWindow, 0, 2, false)
+	flex.AddItem(ci.chatWindow.Pane(), 0, 2, false)
 
 	// Add input box at the bottom
 	flex.AddItem(ci.inputBox, 6, 0, false)
@@ -92,6 +160,16 @@ func (ci *ChatInterface) SetChats(chats []*Chat) {
 func (ci *ChatInterface) SetMessages(messages []*Message) {
 	ci.chatWindow.SetMessages(messages)
 	ci.chatWindow.Update()
+
+	// Fetch photos in the background and redraw once they're on disk
+	if ci.dm != nil && ci.currentChat != nil && ci.chatWindow.ImagesEnabled() {
+		threadID := ci.currentChat.ID
+		go func() {
+			ci.dm.cacheImages(threadID, messages)
+			ci.chatWindow.Rebuild()
+			ci.chatWindow.Update()
+		}()
+	}
 }
 
 // SetCurrentChat sets the current active chat
@@ -104,8 +182,99 @@ func (ci *ChatInterface) SetCurrentChat(chat *Chat) {
 // handleChatSelect handles when a chat is selected from the menu
 func (ci *ChatInterface) handleChatSelect(chat *Chat) {
 	ci.SetCurrentChat(chat)
-	ci.app.SetFocus(ci.inputBox)
+	// Leave focus on the list so a request can be answered after reading it
+	if !chat.Pending {
+		ci.app.SetFocus(ci.inputBox)
+	}
 	ci.statusBar.Update(fmt.Sprintf("Switched to chat: %s", chat.Title))
+	ci.statusBar.SetPresence("")
+	go ci.loadMessages()
+}
+
+// loadMessages fetches the current chat's recent messages, oldest first
+func (ci *ChatInterface) loadMessages() {
+	if ci.dm == nil || ci.currentChat == nil {
+		return
+	}
+
+	// Requests are only previewed, so the sender doesn't see them as read
+	if ci.currentChat.Pending {
+		messages, err := ci.dm.PreviewMessageRequest(ci.currentChat.ID, ci.messagesPerFetch)
+		if err != nil {
+			ci.statusBar.Update(fmt.Sprintf("Failed to load messages: %v", err))
+			return
+		}
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+		ci.SetMessages(messages)
+		ci.statusBar.Update("Message request: y to accept, d to decline, b to block")
+		return
+	}
+
+	chatID := ci.currentChat.InternalID
+	messages, err := ci.dm.GetChatHistory(chatID, ci.messagesPerFetch)
+	if err != nil {
+		ci.statusBar.Update(fmt.Sprintf("Failed to load messages: %v", err))
+		return
+	}
+	ci.dm.LoadReactions(chatID, messages)
+	ci.dm.MarkAsSeen(chatID)
+	ci.updatePresence()
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	messages = append(messages, ci.dm.PendingMessages(chatID)...)
+	ci.SetMessages(messages)
+}
+
+// ShowPending refreshes the outbox messages shown at the end of the current chat
+func (ci *ChatInterface) ShowPending() {
+	if ci.dm == nil || ci.currentChat == nil {
+		return
+	}
+	ci.chatWindow.SetPending(ci.dm.PendingMessages(ci.currentChat.InternalID))
+	ci.chatWindow.Update()
+}
+
+// updatePresence shows who has seen your latest message
+func (ci *ChatInterface) updatePresence() {
+	if ci.dm == nil || ci.currentChat == nil || ci.currentChat.Pending {
+		return
+	}
+
+	chatID := ci.currentChat.InternalID
+	presence, err := ci.dm.GetPresence(chatID)
+	if err != nil || ci.currentChat == nil || ci.currentChat.InternalID != chatID {
+		return
+	}
+	ci.statusBar.SetPresence(presence.String())
+}
+
+// reactToSelected reacts to the selected message with emoji, or removes your
+// reaction when emoji is "-"
+func (ci *ChatInterface) reactToSelected(emoji string) {
+	msg := ci.chatWindow.SelectedMessage()
+	if ci.dm == nil || ci.currentChat == nil || msg == nil {
+		ci.statusBar.Update("No message selected")
+		return
+	}
+
+	var err error
+	if emoji == "-" {
+		err = ci.dm.Unreact(ci.currentChat.InternalID, msg.ID)
+	} else {
+		err = ci.dm.React(ci.currentChat.InternalID, msg.ID, emoji)
+	}
+	if err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+
+	ci.SetMode(ChatModeChat)
+	ci.statusBar.Update("Reaction updated")
+	go ci.loadMessages()
 }
 
 // handleMessageSubmit handles message submission from the input box
@@ -119,6 +288,21 @@ func (ci *ChatInterface) handleMessageSubmit(message string) {
 		return
 	}
 
+	if strings.HasPrefix(message, "/") {
+		ci.HandleCommand(strings.TrimPrefix(message, "/"))
+		return
+	}
+
+	if ci.currentChat.Pending {
+		ci.statusBar.Update("Accept the request before replying")
+		return
+	}
+
+	if ci.mode == ChatModeReact {
+		ci.reactToSelected(strings.TrimSpace(message))
+		return
+	}
+
 	// Check if we're in reply mode
 	if ci.mode == ChatModeReply && ci.chatWindow.GetSelectedMessageID() != "" {
 		// Send reply
@@ -136,9 +320,15 @@ func (ci *ChatInterface) handleMessageSubmit(message string) {
 		// Send regular message
 		if ci.onMessageSend != nil {
 			ci.statusBar.Update("Sending...")
-			if err := ci.onMessageSend(ci.currentChat.InternalID, message); err != nil {
+			err := ci.onMessageSend(ci.currentChat.InternalID, message)
+			var queued *QueuedError
+			switch {
+			case errors.As(err, &queued):
+				ci.statusBar.Update(fmt.Sprintf("Message queued: %v", err))
+				ci.ShowPending()
+			case err != nil:
 				ci.statusBar.Update(fmt.Sprintf("Failed to send message: %v", err))
-			} else {
+			default:
 				ci.statusBar.Update("Message sent")
 			}
 		}
@@ -169,6 +359,8 @@ func (ci *ChatInterface) StopRefresh() {
 func (ci *ChatInterface) refreshChat() {
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
+	presenceTicker := time.NewTicker(presenceInterval)
+	defer presenceTicker.Stop()
 
 	for {
 		select {
@@ -182,6 +374,10 @@ func (ci *ChatInterface) refreshChat() {
 				ci.chatWindow.Update()
 				ci.refreshLock.Unlock()
 			}
+		case <-presenceTicker.C:
+			if ci.refreshEnabled {
+				ci.updatePresence()
+			}
 		}
 	}
 }
@@ -209,6 +405,30 @@ func (ci *ChatInterface) HandleCommand(command string) {
 	case "chat":
 		ci.SetMode(ChatModeChat)
 		ci.statusBar.Update("Back to chat mode")
+	case "react":
+		// "/react <emoji>" outside react mode reacts to the newest message
+		if len(parts) == 1 {
+			ci.enterReactMode()
+			break
+		}
+		if ci.mode != ChatModeReact {
+			ci.chatWindow.SelectLast()
+		}
+		ci.reactToSelected(strings.Join(parts[1:], " "))
+	case "send-photo", "send-video":
+		ci.sendMedia(cmd, MediaPathArg(command))
+	case "forward":
+		ci.forwardSelected(parts[1:])
+	case "search":
+		ci.chatWindow.OpenSearch(strings.Join(parts[1:], " "))
+	case "schedule":
+		ci.scheduleMessage(strings.TrimSpace(strings.TrimPrefix(command, parts[0])))
+	case "group":
+		ci.groupCommand(parts[1:])
+	case "label":
+		ci.labelCurrentChat(parts[1:])
+	case "new":
+		ci.startConversation(parts[1:])
 	case "help":
 		ci.showHelp()
 	default:
@@ -216,6 +436,451 @@ func (ci *ChatInterface) HandleCommand(command string) {
 	}
 }
 
+// handleChatAction runs an action key from the chat menu. Actions that need
+// more input open the chat and start the matching /group command in the
+// input box; Enter runs it.
+func (ci *ChatInterface) handleChatAction(action ChatMenuAction, chat *Chat) {
+	switch action {
+	case ChatMenuActionNewChat:
+		ci.chatMenu.StartUserSearch(ci.searchUsers, ci.pickNewChat)
+		return
+	case ChatMenuActionToggleRequests:
+		if ci.chatMenu.ShowingRequests() {
+			ci.chatMenu.ShowInbox()
+		} else {
+			go ci.showRequests()
+		}
+		return
+	case ChatMenuActionAccept, ChatMenuActionDecline, ChatMenuActionBlock:
+		go ci.answerRequest(action, chat)
+		return
+	case ChatMenuActionTogglePin, ChatMenuActionToggleLocalMute, ChatMenuActionToggleArchive:
+		ci.toggleLocal(action, chat)
+		return
+	case ChatMenuActionLabel:
+		ci.handleChatSelect(chat)
+		ci.inputBox.SetText("/label ")
+		ci.app.SetFocus(ci.inputBox)
+		ci.statusBar.Update("Type labels to add, -label to remove, and press Enter")
+		return
+	}
+
+	if chat != nil && action != ChatMenuActionNewGroup && action != ChatMenuActionToggleMute {
+		if !chat.IsGroup {
+			ci.statusBar.Update(fmt.Sprintf("%s is not a group chat", chat.Title))
+			return
+		}
+		ci.handleChatSelect(chat)
+	}
+
+	prompts := map[ChatMenuAction]string{
+		ChatMenuActionNewGroup:     "/group create @",
+		ChatMenuActionRename:       "/group rename ",
+		ChatMenuActionAddMember:    "/group add @",
+		ChatMenuActionRemoveMember: "/group remove @",
+		ChatMenuActionLeave:        "/group leave",
+	}
+
+	if action == ChatMenuActionToggleMute {
+		ci.setMuted(chat, !chat.Muted)
+		return
+	}
+
+	ci.inputBox.SetText(prompts[action])
+	ci.app.SetFocus(ci.inputBox)
+	switch action {
+	case ChatMenuActionNewGroup:
+		ci.statusBar.Update("List members as @username, then an optional title, and press Enter")
+	case ChatMenuActionLeave:
+		ci.statusBar.Update(fmt.Sprintf("Press Enter to leave %s, Esc to cancel", chat.Title))
+	}
+}
+
+// showRequests loads message requests into the chat menu
+func (ci *ChatInterface) showRequests() {
+	requests, err := ci.dm.GetMessageRequests()
+	if err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+	ci.app.QueueUpdateDraw(func() {
+		ci.chatMenu.ShowRequests(requests)
+	})
+}
+
+// answerRequest accepts, declines or blocks a message request. An accepted
+// request opens as a normal chat.
+func (ci *ChatInterface) answerRequest(action ChatMenuAction, chat *Chat) {
+	var accepted *Chat
+	var err error
+	switch action {
+	case ChatMenuActionAccept:
+		accepted, err = ci.dm.AcceptMessageRequest(chat.ID)
+	case ChatMenuActionDecline:
+		err = ci.dm.DeclineMessageRequest(chat.ID)
+	case ChatMenuActionBlock:
+		err = ci.dm.BlockMessageRequest(chat.ID)
+	}
+	if err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+
+	if ci.currentChat != nil && ci.currentChat.ID == chat.ID {
+		ci.currentChat = nil
+		ci.app.QueueUpdateDraw(func() {
+			ci.chatWindow.SetMessages(nil)
+			ci.chatWindow.SetTitle("Chat")
+		})
+	}
+	ci.showRequests()
+	ci.reloadChats()
+
+	switch action {
+	case ChatMenuActionAccept:
+		ci.statusBar.Update(fmt.Sprintf("Accepted request from %s", accepted.Title))
+		ci.app.QueueUpdateDraw(func() {
+			ci.handleChatSelect(accepted)
+		})
+	case ChatMenuActionDecline:
+		ci.statusBar.Update(fmt.Sprintf("Declined request from %s", chat.Title))
+	case ChatMenuActionBlock:
+		ci.statusBar.Update(fmt.Sprintf("Blocked %s", chat.Title))
+	}
+}
+
+// searchUsers lists users matching query for the "new chat" search. Users
+// you already talk to one-on-one come back as that chat.
+func (ci *ChatInterface) searchUsers(query string) []*Chat {
+	users, err := ci.dm.SearchUsers(query)
+	if err != nil {
+		ci.statusBar.Update(err.Error())
+		return nil
+	}
+
+	existing := make(map[int64]*Chat)
+	for _, chat := range ci.dm.CachedChats() {
+		if !chat.IsGroup && len(chat.Users) == 1 {
+			existing[chat.Users[0].ID] = chat
+		}
+	}
+
+	results := make([]*Chat, 0, len(users))
+	for _, user := range users {
+		if chat, ok := existing[user.ID]; ok {
+			results = append(results, chat)
+			continue
+		}
+		title := "@" + user.Username
+		if user.FullName != "" {
+			title += " (" + user.FullName + ")"
+		}
+		results = append(results, &Chat{Title: title, Users: []*goinsta.User{user}})
+	}
+	return results
+}
+
+// pickNewChat opens the chosen chat, or starts a /new command for someone
+// you haven't talked to, since their chat only exists after a first message
+func (ci *ChatInterface) pickNewChat(chat *Chat) {
+	if chat.ID != "" {
+		ci.handleChatSelect(chat)
+		return
+	}
+
+	username := chat.Users[0].Username
+	ci.inputBox.SetText(fmt.Sprintf("/new @%s ", username))
+	ci.app.SetFocus(ci.inputBox)
+	ci.statusBar.Update(fmt.Sprintf("Type your first message to @%s and press Enter", username))
+}
+
+// startConversation handles "/new @username <message>"
+func (ci *ChatInterface) startConversation(args []string) {
+	if ci.dm == nil || len(args) == 0 {
+		ci.statusBar.Update("Usage: /new @username <message>")
+		return
+	}
+
+	ci.statusBar.Update("Starting chat...")
+	chat, err := ci.dm.StartConversation(args[0], strings.Join(args[1:], " "))
+	var queued *QueuedError
+	if err != nil && !errors.As(err, &queued) {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+
+	ci.reloadChats()
+	ci.handleChatSelect(chat)
+	if queued != nil {
+		ci.statusBar.Update(fmt.Sprintf("Message queued: %v", err))
+	}
+}
+
+// groupCommand handles "/group create|rename|add|remove|leave|mute|unmute"
+func (ci *ChatInterface) groupCommand(args []string) {
+	const usage = "Usage: /group create @user @user [title] | rename <title> | add @user | remove @user | leave | mute | unmute"
+	if ci.dm == nil || len(args) == 0 {
+		ci.statusBar.Update(usage)
+		return
+	}
+
+	if args[0] == "create" {
+		usernames, title := ParseGroupArgs(args[1:])
+		ci.statusBar.Update("Creating group...")
+		chat, err := ci.dm.CreateGroup(usernames, title)
+		if err != nil {
+			ci.statusBar.Update(err.Error())
+			return
+		}
+		ci.reloadChats()
+		ci.handleChatSelect(chat)
+		return
+	}
+
+	if ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return
+	}
+	chat := ci.currentChat
+
+	var err error
+	var done string
+	switch args[0] {
+	case "rename":
+		title := strings.Join(args[1:], " ")
+		err = ci.dm.RenameGroup(chat.InternalID, title)
+		done = fmt.Sprintf("Renamed to %s", title)
+	case "add":
+		usernames, _ := ParseGroupArgs(args[1:])
+		err = ci.dm.AddGroupMembers(chat.InternalID, usernames)
+		done = fmt.Sprintf("Added %s", strings.Join(usernames, ", "))
+	case "remove":
+		if len(args) != 2 {
+			ci.statusBar.Update("Usage: /group remove @user")
+			return
+		}
+		err = ci.dm.RemoveGroupMember(chat.InternalID, args[1])
+		done = fmt.Sprintf("Removed %s", strings.TrimPrefix(args[1], "@"))
+	case "leave":
+		err = ci.dm.LeaveGroup(chat.InternalID)
+		done = fmt.Sprintf("Left %s", chat.Title)
+	case "mute", "unmute":
+		ci.setMuted(chat, args[0] == "mute")
+		return
+	default:
+		ci.statusBar.Update(usage)
+		return
+	}
+
+	if err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+	ci.statusBar.Update(done)
+	ci.reloadChats()
+	go ci.loadMessages()
+}
+
+// setMuted mutes or unmutes a chat and shows the result in the chat list
+func (ci *ChatInterface) setMuted(chat *Chat, muted bool) {
+	if err := ci.dm.SetMuted(chat.InternalID, muted); err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+	if muted {
+		ci.statusBar.Update(fmt.Sprintf("Muted %s", chat.Title))
+	} else {
+		ci.statusBar.Update(fmt.Sprintf("Unmuted %s", chat.Title))
+	}
+	ci.reloadChats()
+}
+
+// toggleLocal flips a chat's pin, GoGram-only mute or archived setting
+func (ci *ChatInterface) toggleLocal(action ChatMenuAction, chat *Chat) {
+	var updated *Chat
+	var err error
+	var done string
+	switch action {
+	case ChatMenuActionTogglePin:
+		updated, err = ci.dm.SetPinned(chat.InternalID, !chat.Pinned)
+		done = "Pinned %s"
+		if chat.Pinned {
+			done = "Unpinned %s"
+		}
+	case ChatMenuActionToggleLocalMute:
+		updated, err = ci.dm.SetMutedLocally(chat.InternalID, !chat.MutedLocally)
+		done = "Muted %s in GoGram"
+		if chat.MutedLocally {
+			done = "Unmuted %s in GoGram"
+		}
+	case ChatMenuActionToggleArchive:
+		updated, err = ci.dm.SetArchived(chat.InternalID, !chat.Archived)
+		done = "Archived %s, A shows archived chats"
+		if chat.Archived {
+			done = "Moved %s back to your chats"
+		}
+	}
+	if err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+
+	if ci.currentChat != nil && ci.currentChat.ID == updated.ID {
+		ci.currentChat = updated
+	}
+	ci.statusBar.Update(fmt.Sprintf(done, chat.Title))
+	ci.reloadChats()
+}
+
+// labelCurrentChat handles "/label [label|+label|-label ...]". Without
+// arguments it shows the chat's labels.
+func (ci *ChatInterface) labelCurrentChat(args []string) {
+	if ci.dm == nil || ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return
+	}
+	chat := ci.currentChat
+
+	if len(args) > 0 {
+		add, remove := ParseLabelArgs(args)
+		updated, err := ci.dm.LabelChat(chat.InternalID, add, remove)
+		if err != nil {
+			ci.statusBar.Update(err.Error())
+			return
+		}
+		chat = updated
+		ci.currentChat = updated
+		ci.reloadChats()
+	}
+
+	if len(chat.Labels) == 0 {
+		ci.statusBar.Update(fmt.Sprintf("%s has no labels", chat.Title))
+		return
+	}
+	ci.statusBar.Update(fmt.Sprintf("%s: #%s", chat.Title, strings.Join(chat.Labels, " #")))
+}
+
+// reloadChats refreshes the chat list after a change to a chat
+func (ci *ChatInterface) reloadChats() {
+	chats, err := ci.dm.GetChats()
+	if err != nil {
+		ci.statusBar.Update(fmt.Sprintf("Failed to reload chats: %v", err))
+		return
+	}
+	ci.chatMenu.SetChats(chats)
+}
+
+// scheduleMessage handles "/schedule <time|+duration> <text>" for the current chat
+func (ci *ChatInterface) scheduleMessage(args string) {
+	if ci.dm == nil || ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return
+	}
+
+	sendAt, text, err := ParseScheduleArgs(args)
+	if err != nil {
+		ci.statusBar.Update(fmt.Sprintf("Usage: /schedule <time|+duration> <text> (%v)", err))
+		return
+	}
+
+	msg, err := ci.dm.ScheduleMessage(ci.currentChat.InternalID, text, sendAt)
+	if err != nil {
+		ci.statusBar.Update(fmt.Sprintf("Failed to schedule message: %v", err))
+		return
+	}
+	ci.statusBar.Update(fmt.Sprintf("Scheduled %s for %s", msg.ID, sendAt.Format("Mon Jan 2 15:04")))
+}
+
+// sendMedia uploads a photo or video to the current chat
+func (ci *ChatInterface) sendMedia(cmd, path string) {
+	if ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return
+	}
+	if ci.dm == nil {
+		ci.statusBar.Update("Not logged in")
+		return
+	}
+	if path == "" {
+		ci.statusBar.Update(fmt.Sprintf("Usage: /%s <path>", cmd))
+		return
+	}
+
+	send := ci.dm.SendPhoto
+	if cmd == "send-video" {
+		send = ci.dm.SendVideo
+	}
+
+	ci.statusBar.Update("Uploading...")
+	if err := send(ci.currentChat.InternalID, path); err != nil {
+		ci.statusBar.Update(fmt.Sprintf("Failed to send media: %v", err))
+		return
+	}
+	ci.statusBar.Update("Media sent")
+}
+
+// forwardSelected handles "/forward <chat-id|@username>" for the selected
+// message, or the newest one when nothing is selected
+func (ci *ChatInterface) forwardSelected(args []string) {
+	if ci.dm == nil || ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return
+	}
+	if len(args) != 1 {
+		ci.statusBar.Update("Usage: /forward <chat-id|@username>")
+		return
+	}
+
+	msg := ci.chatWindow.SelectedMessage()
+	if msg == nil {
+		ci.chatWindow.SelectLast()
+		msg = ci.chatWindow.SelectedMessage()
+	}
+	if msg == nil {
+		ci.statusBar.Update("No message selected")
+		return
+	}
+
+	if err := ci.dm.ForwardMessage(ci.currentChat.InternalID, msg.ID, args[0]); err != nil {
+		ci.statusBar.Update(err.Error())
+		return
+	}
+	ci.statusBar.Update(fmt.Sprintf("Forwarded to %s", args[0]))
+}
+
+// searchChat finds messages in the current chat's stored history for the
+// chat window's search bar. When a match is older than the messages shown,
+// the whole stored history is shown so every match can be reached.
+func (ci *ChatInterface) searchChat(query string) []string {
+	if ci.dm == nil || ci.currentChat == nil {
+		ci.statusBar.Update("No chat selected")
+		return nil
+	}
+
+	results, err := ci.dm.SearchMessages(history.Query{Text: query, ThreadID: ci.currentChat.ID})
+	if err != nil {
+		ci.statusBar.Update(fmt.Sprintf("Search failed: %v", err))
+		return nil
+	}
+	if len(results) == 0 {
+		ci.statusBar.Update(fmt.Sprintf("No messages match %q", query))
+		return nil
+	}
+
+	if oldest := results[len(results)-1].Message.ID; !ci.chatWindow.HasMessage(oldest) {
+		if stored, err := ci.dm.StoredHistory(ci.currentChat.ID); err == nil && len(stored) > 0 {
+			ci.chatWindow.SetMessages(stored)
+		}
+	}
+
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Message.ID
+	}
+	ci.statusBar.Update(fmt.Sprintf("%d matches: Up/Enter for older, Down for newer, Esc to close", len(results)))
+	return ids
+}
+
 // showHelp displays available commands
 func (ci *ChatInterface) showHelp() {
 	ci.statusBar.Update("Help displayed")
@@ -242,8 +907,13 @@ func (ci *ChatInterface) GetChatMenu() *ChatMenu {
 	return ci.chatMenu
 }
 
-// Run starts the chat interface
+// Run starts the chat interface. The terminal notification sink is off
+// while it runs, as its escape sequences would corrupt the screen.
 func (ci *ChatInterface) Run() error {
+	if ci.dm != nil {
+		previous := ci.dm.SetTerminalOutput(nil)
+		defer ci.dm.SetTerminalOutput(previous)
+	}
 	ci.StartRefresh()
 	return ci.app.Run()
 }
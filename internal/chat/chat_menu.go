@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/abhi-praj/GoGram/internal/chatmeta"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -21,6 +23,11 @@ type ChatMenu struct {
 	mutex        sync.RWMutex
 	app          *tview.Application
 	onChatSelect func(*Chat)
+	onAction     func(ChatMenuAction, *Chat)
+	userSearch   *userSearch
+	all          []*Chat // every chat, before the filter
+	filter       ChatFilter
+	requests     bool
 	searchInput  *tview.InputField
 	statusBar    *tview.TextView
 }
@@ -36,7 +43,7 @@ func NewChatMenu(app *tview.Application, onChatSelect func(*Chat)) *ChatMenu {
 	cm := &ChatMenu{
 		List:         list,
 		chats:        make([]*Chat, 0),
-		placeholder:  "Search for chat by @username or /title + ENTER",
+		placeholder:  "Search for chat by @username, /title or #label + ENTER",
 		app:          app,
 		onChatSelect: onChatSelect,
 	}
@@ -66,17 +73,256 @@ func NewChatMenu(app *tview.Application, onChatSelect func(*Chat)) *ChatMenu {
 	// Set up input handling
 	cm.searchInput.SetDoneFunc(cm.handleSearchDone)
 	list.SetSelectedFunc(cm.handleChatSelect)
+	list.SetInputCapture(cm.handleKey)
 
 	return cm
 }
 
+// chatMenuKeys maps keys on the chat list to actions on the selected chat
+var chatMenuKeys = map[rune]ChatMenuAction{
+	'n': ChatMenuActionNewChat,
+	'g': ChatMenuActionNewGroup,
+	'r': ChatMenuActionRename,
+	'a': ChatMenuActionAddMember,
+	'x': ChatMenuActionRemoveMember,
+	'l': ChatMenuActionLeave,
+	'm': ChatMenuActionToggleMute,
+	'M': ChatMenuActionToggleLocalMute,
+	'p': ChatMenuActionTogglePin,
+	'e': ChatMenuActionToggleArchive,
+	't': ChatMenuActionLabel,
+}
+
+// requestMenuKeys replace chatMenuKeys while message requests are shown
+var requestMenuKeys = map[rune]ChatMenuAction{
+	'y': ChatMenuActionAccept,
+	'd': ChatMenuActionDecline,
+	'b': ChatMenuActionBlock,
+}
+
+// SetActionHandler calls fn when a chat action key is pressed. The chat is
+// nil for actions that don't need one, like starting a chat or group.
+func (cm *ChatMenu) SetActionHandler(fn func(ChatMenuAction, *Chat)) {
+	cm.onAction = fn
+}
+
+// handleKey turns action keys into calls to the action handler
+func (cm *ChatMenu) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if cm.mode == ChatMenuModeNewChat {
+		if event.Key() == tcell.KeyEscape {
+			cm.endUserSearch()
+			return nil
+		}
+		return event
+	}
+
+	if cm.onAction == nil {
+		return event
+	}
+	if event.Key() == tcell.KeyTab {
+		cm.onAction(ChatMenuActionToggleRequests, nil)
+		return nil
+	}
+	if event.Key() == tcell.KeyRune && event.Rune() == 'A' && !cm.ShowingRequests() {
+		cm.ToggleArchived()
+		return nil
+	}
+
+	keys := chatMenuKeys
+	if cm.ShowingRequests() {
+		keys = requestMenuKeys
+	}
+	action, ok := keys[event.Rune()]
+	if event.Key() != tcell.KeyRune || !ok {
+		return event
+	}
+
+	var selected *Chat
+	cm.mutex.RLock()
+	if index := cm.GetCurrentItem(); index >= 0 && index < len(cm.chats) {
+		selected = cm.chats[index]
+	}
+	cm.mutex.RUnlock()
+
+	if selected == nil && action != ChatMenuActionNewChat && action != ChatMenuActionNewGroup {
+		return nil
+	}
+	cm.onAction(action, selected)
+	return nil
+}
+
+// userSearchDelay is how long typing has to pause before users are searched
+const userSearchDelay = 300 * time.Millisecond
+
+// userSearch is the state of a "new chat" search in the chat menu
+type userSearch struct {
+	search func(query string) []*Chat
+	pick   func(*Chat)
+	query  string
+	timer  *time.Timer
+}
+
+// StartUserSearch turns the search box into a live user search. Results from
+// search replace the chat list as you type, and pick is called with the one
+// chosen. Esc puts the chat list back.
+func (cm *ChatMenu) StartUserSearch(search func(query string) []*Chat, pick func(*Chat)) {
+	cm.mutex.Lock()
+	if cm.userSearch == nil {
+		cm.userSearch = &userSearch{}
+	}
+	cm.userSearch.search = search
+	cm.userSearch.pick = pick
+	cm.mode = ChatMenuModeNewChat
+	cm.chats = nil
+	cm.updateChatList()
+	cm.mutex.Unlock()
+
+	cm.searchInput.SetLabel("New chat: ")
+	cm.searchInput.SetText("")
+	cm.searchInput.SetChangedFunc(cm.handleUserQuery)
+	cm.updateStatusBar()
+	cm.app.SetFocus(cm.searchInput)
+}
+
+// handleUserQuery searches once typing pauses, dropping results for queries
+// that have since changed
+func (cm *ChatMenu) handleUserQuery(text string) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	us := cm.userSearch
+	if us == nil {
+		return
+	}
+	us.query = text
+	if us.timer != nil {
+		us.timer.Stop()
+	}
+	us.timer = time.AfterFunc(userSearchDelay, func() {
+		results := us.search(text)
+
+		cm.app.QueueUpdateDraw(func() {
+			cm.mutex.Lock()
+			defer cm.mutex.Unlock()
+			if cm.userSearch != us || us.query != text {
+				return
+			}
+			cm.chats = results
+			cm.selection = 0
+			cm.updateChatList()
+		})
+	})
+}
+
+// endUserSearch leaves the user search and brings the chat list back
+func (cm *ChatMenu) endUserSearch() {
+	cm.mutex.Lock()
+	if us := cm.userSearch; us != nil {
+		if us.timer != nil {
+			us.timer.Stop()
+		}
+		cm.chats = cm.filter.Apply(cm.all)
+		cm.userSearch = nil
+	}
+	cm.mode = ChatMenuModeDefault
+	cm.selection = 0
+	cm.updateChatList()
+	cm.mutex.Unlock()
+
+	cm.searchInput.SetChangedFunc(nil)
+	cm.searchInput.SetText("")
+	cm.searchInput.SetLabel("Search: ")
+	cm.updateStatusBar()
+	cm.app.SetFocus(cm.List)
+}
+
 // SetChats updates the chat list
 func (cm *ChatMenu) SetChats(chats []*Chat) {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
-	cm.chats = chats
+	// Keep showing search results or requests; the new chats come back afterwards
+	cm.all = chats
+	if cm.userSearch != nil || cm.requests {
+		return
+	}
+	cm.chats = cm.filter.Apply(chats)
+	cm.updateChatList()
+}
+
+// SetFilter shows only the chats that pass f
+func (cm *ChatMenu) SetFilter(f ChatFilter) {
+	cm.mutex.Lock()
+	cm.filter = f
+	if cm.userSearch == nil && !cm.requests {
+		cm.chats = f.Apply(cm.all)
+		cm.selection = 0
+		cm.SetTitle(cm.title())
+		cm.updateChatList()
+	}
+	cm.mutex.Unlock()
+
+	cm.updateStatusBar()
+}
+
+// Filter returns the filter the chat list is shown with
+func (cm *ChatMenu) Filter() ChatFilter {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return cm.filter
+}
+
+// ToggleArchived switches between archived chats and the rest
+func (cm *ChatMenu) ToggleArchived() {
+	f := cm.Filter()
+	f.Archived = !f.Archived
+	cm.SetFilter(f)
+}
+
+// title names the chats the list shows
+func (cm *ChatMenu) title() string {
+	title := "Chat List"
+	if cm.filter.Archived {
+		title = "Archived Chats"
+	}
+	if cm.filter.Label != "" {
+		title += " #" + chatmeta.NormalizeLabel(cm.filter.Label)
+	}
+	return title
+}
+
+// ShowRequests switches the list to message requests until ShowInbox is
+// called. Calling it again refreshes the requests.
+func (cm *ChatMenu) ShowRequests(requests []*Chat) {
+	cm.mutex.Lock()
+	cm.requests = true
+	cm.chats = requests
+	cm.selection = 0
+	cm.SetTitle(fmt.Sprintf("Message Requests (%d)", len(requests)))
+	cm.updateChatList()
+	cm.mutex.Unlock()
+
+	cm.updateStatusBar()
+}
+
+// ShowInbox switches the list back from message requests to your chats
+func (cm *ChatMenu) ShowInbox() {
+	cm.mutex.Lock()
+	cm.requests = false
+	cm.chats = cm.filter.Apply(cm.all)
+	cm.selection = 0
+	cm.SetTitle(cm.title())
 	cm.updateChatList()
+	cm.mutex.Unlock()
+
+	cm.updateStatusBar()
+}
+
+// ShowingRequests reports whether the list shows message requests
+func (cm *ChatMenu) ShowingRequests() bool {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return cm.requests
 }
 
 // updateChatList updates the displayed chat list
@@ -91,6 +337,19 @@ func (cm *ChatMenu) updateChatList() {
 			title = fmt.Sprintf("👤 %s", title)
 		}
 
+		if chat.Pinned {
+			title = "📌 " + title
+		}
+		if chat.Muted {
+			title += " 🔕"
+		}
+		if chat.MutedLocally {
+			title += " 🔇"
+		}
+		for _, label := range chat.Labels {
+			title += " #" + label
+		}
+
 		// Add unread indicator
 		if chat.UnreadCount > 0 {
 			title = fmt.Sprintf("🔴 %s (%d unread)", title, chat.UnreadCount)
@@ -107,6 +366,16 @@ func (cm *ChatMenu) updateChatList() {
 
 // handleSearchDone processes search input completion
 func (cm *ChatMenu) handleSearchDone(key tcell.Key) {
+	if cm.mode == ChatMenuModeNewChat {
+		switch key {
+		case tcell.KeyEnter, tcell.KeyTab:
+			cm.app.SetFocus(cm.List)
+		case tcell.KeyEscape:
+			cm.endUserSearch()
+		}
+		return
+	}
+
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
@@ -128,6 +397,17 @@ func (cm *ChatMenu) handleSearchDone(key tcell.Key) {
 
 // performSearch performs the search operation
 func (cm *ChatMenu) performSearch(query string) {
+	// "#label" filters the list by label until it's replaced or cleared with "#"
+	if strings.HasPrefix(query, "#") {
+		cm.filter.Label = strings.TrimPrefix(query, "#")
+		cm.chats = cm.filter.Apply(cm.all)
+		cm.selection = 0
+		cm.SetTitle(cm.title())
+		cm.updateChatList()
+		cm.updateStatusBar(fmt.Sprintf("%d chats", len(cm.chats)))
+		return
+	}
+
 	// Simple search implementation - can be enhanced later
 	var results []*Chat
 
@@ -179,8 +459,19 @@ func (cm *ChatMenu) performSearch(query string) {
 
 // handleChatSelect processes chat selection
 func (cm *ChatMenu) handleChatSelect(index int, mainText, secondaryText string, shortcut rune) {
-	if index >= 0 && index < len(cm.chats) && cm.onChatSelect != nil {
-		cm.onChatSelect(cm.chats[index])
+	if index < 0 || index >= len(cm.chats) {
+		return
+	}
+
+	chat := cm.chats[index]
+	if cm.mode == ChatMenuModeNewChat {
+		pick := cm.userSearch.pick
+		cm.endUserSearch()
+		pick(chat)
+		return
+	}
+	if cm.onChatSelect != nil {
+		cm.onChatSelect(chat)
 	}
 }
 
@@ -199,12 +490,16 @@ func (cm *ChatMenu) updateStatusBar(message ...string) {
 	if len(message) > 0 {
 		msg = message[0]
 	} else {
-		switch cm.mode {
-		case ChatMenuModeDefault:
-			msg = "Use arrow keys to navigate, Enter to select, @ to search username, / to search title"
-		case ChatMenuModeSearchUsername:
+		switch {
+		case cm.mode == ChatMenuModeDefault && cm.requests:
+			msg = "Enter to preview, y accept, d decline, b block, Tab back to chats"
+		case cm.mode == ChatMenuModeDefault:
+			msg = "Enter to select, n new chat, g new group, r rename, a/x add/remove member, l leave, m mute, M mute here, p pin, e archive, t labels, A archived, Tab requests"
+		case cm.mode == ChatMenuModeNewChat:
+			msg = "Type a username, Down to pick from the results, Esc to cancel"
+		case cm.mode == ChatMenuModeSearchUsername:
 			msg = "Type username and press Enter to search"
-		case ChatMenuModeSearchTitle:
+		case cm.mode == ChatMenuModeSearchTitle:
 			msg = "Type title and press Enter to search"
 		}
 	}
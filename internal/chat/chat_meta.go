@@ -0,0 +1,179 @@
+package chat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/chatmeta"
+)
+
+// chatMeta loads the local settings of every chat. Chats show up without
+// them rather than not at all if the file can't be read.
+func (dm *DirectMessages) chatMeta() map[string]chatmeta.Meta {
+	if dm.meta == nil {
+		return nil
+	}
+	all, err := dm.meta.All()
+	if err != nil {
+		return nil
+	}
+	return all
+}
+
+// applyMeta copies a chat's local settings onto it
+func (c *Chat) applyMeta(meta chatmeta.Meta) {
+	c.Pinned = meta.Pinned
+	c.MutedLocally = meta.Muted
+	c.Archived = meta.Archived
+	c.Labels = meta.Labels
+}
+
+// updateMeta changes a chat's local settings, given its internal or thread ID
+func (dm *DirectMessages) updateMeta(chatID string, fn func(*chatmeta.Meta)) (*Chat, error) {
+	if dm.meta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	chat, err := dm.GetChatByInternalID(chatID)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := dm.meta.Update(chat.ID, fn)
+	if err != nil {
+		return nil, err
+	}
+	chat.applyMeta(meta)
+	return chat, nil
+}
+
+// SetPinned pins a chat to the top of the chat list, or unpins it
+func (dm *DirectMessages) SetPinned(chatID string, pinned bool) (*Chat, error) {
+	return dm.updateMeta(chatID, func(m *chatmeta.Meta) { m.Pinned = pinned })
+}
+
+// SetMutedLocally stops or restarts GoGram's notifications for a chat without
+// muting it on Instagram
+func (dm *DirectMessages) SetMutedLocally(chatID string, muted bool) (*Chat, error) {
+	return dm.updateMeta(chatID, func(m *chatmeta.Meta) { m.Muted = muted })
+}
+
+// SetArchived hides a chat from the chat list, or brings it back
+func (dm *DirectMessages) SetArchived(chatID string, archived bool) (*Chat, error) {
+	return dm.updateMeta(chatID, func(m *chatmeta.Meta) { m.Archived = archived })
+}
+
+// LabelChat adds and removes labels on a chat
+func (dm *DirectMessages) LabelChat(chatID string, add, remove []string) (*Chat, error) {
+	for _, label := range add {
+		if err := chatmeta.ValidateLabel(label); err != nil {
+			return nil, err
+		}
+	}
+
+	return dm.updateMeta(chatID, func(m *chatmeta.Meta) {
+		var kept []string
+		for _, label := range m.Labels {
+			if !containsLabel(remove, label) {
+				kept = append(kept, label)
+			}
+		}
+		m.Labels = append(kept, add...)
+	})
+}
+
+// ChatLabels counts how many chats carry each label
+func (dm *DirectMessages) ChatLabels() (map[string]int, error) {
+	if dm.meta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+	return dm.meta.Labels()
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if chatmeta.NormalizeLabel(l) == label {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLabelArgs splits label arguments into labels to add and remove:
+// "-label" removes, "label" or "+label" adds. Commas separate labels too.
+func ParseLabelArgs(args []string) (add, remove []string) {
+	for _, arg := range args {
+		for _, label := range strings.Split(arg, ",") {
+			switch {
+			case label == "":
+			case strings.HasPrefix(label, "-"):
+				remove = append(remove, chatmeta.NormalizeLabel(label[1:]))
+			default:
+				add = append(add, strings.TrimPrefix(label, "+"))
+			}
+		}
+	}
+	return add, remove
+}
+
+// labelledChats returns the chats carrying a label
+func (dm *DirectMessages) labelledChats(label string) ([]*Chat, error) {
+	chats, err := dm.GetChatsWithLimit(0)
+	if err != nil {
+		return nil, err
+	}
+
+	labelled := ChatFilter{Label: label, All: true}.Apply(chats)
+	if len(labelled) == 0 {
+		return nil, fmt.Errorf("no chats are labelled %q", chatmeta.NormalizeLabel(label))
+	}
+	return labelled, nil
+}
+
+// ChatFilter picks chats by their local settings. The zero value keeps every
+// chat that isn't archived.
+type ChatFilter struct {
+	Pinned   bool   // only pinned chats
+	Muted    bool   // only chats muted in GoGram
+	Archived bool   // only archived chats
+	All      bool   // archived chats as well as the rest
+	Label    string // only chats with this label
+}
+
+// Match reports whether a chat passes the filter
+func (f ChatFilter) Match(c *Chat) bool {
+	switch {
+	case f.Archived && !c.Archived:
+		return false
+	case !f.Archived && !f.All && c.Archived:
+		return false
+	case f.Pinned && !c.Pinned:
+		return false
+	case f.Muted && !c.MutedLocally:
+		return false
+	case f.Label != "" && !(chatmeta.Meta{Labels: c.Labels}).HasLabel(f.Label):
+		return false
+	}
+	return true
+}
+
+// Apply returns the chats that pass the filter, in order
+func (f ChatFilter) Apply(chats []*Chat) []*Chat {
+	var kept []*Chat
+	for _, chat := range chats {
+		if f.Match(chat) {
+			kept = append(kept, chat)
+		}
+	}
+	return kept
+}
+
+// SortedLabels returns label names from ChatLabels in order
+func SortedLabels(counts map[string]int) []string {
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/chatmeta"
+)
+
+func TestChatsFromPinsFirst(t *testing.T) {
+	meta := chatmeta.NewStore(filepath.Join(t.TempDir(), "chat_meta.json"))
+	meta.Update("t3", func(m *chatmeta.Meta) { m.Pinned = true })
+	meta.Update("t2", func(m *chatmeta.Meta) { m.Labels = []string{"work"}; m.Muted = true })
+
+	dm := &DirectMessages{chatIDs: loadChatIDs(""), meta: meta}
+	conversations := []*goinsta.Conversation{
+		{ID: "t1", LastActivityAt: 300},
+		{ID: "t2", LastActivityAt: 200},
+		{ID: "t3", LastActivityAt: 100},
+	}
+
+	// The pinned chat isn't cut off by the limit even though it's the oldest
+	chats := dm.chatsFrom(conversations, 2)
+	if len(chats) != 2 || chats[0].ID != "t3" || !chats[0].Pinned || chats[1].ID != "t1" {
+		t.Fatalf("Expected t3 then t1, got %v", chatIDsOf(chats))
+	}
+
+	chats = dm.chatsFrom(conversations, 0)
+	if c := chats[2]; c.ID != "t2" || !c.MutedLocally || !reflect.DeepEqual(c.Labels, []string{"work"}) {
+		t.Errorf("Expected t2's local settings to be applied, got %+v", c)
+	}
+}
+
+func chatIDsOf(chats []*Chat) []string {
+	ids := make([]string, len(chats))
+	for i, c := range chats {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestChatFilter(t *testing.T) {
+	chats := []*Chat{
+		{ID: "a", Pinned: true, Labels: []string{"work"}},
+		{ID: "b", MutedLocally: true},
+		{ID: "c", Archived: true, Labels: []string{"work"}},
+		{ID: "d"},
+	}
+
+	testCases := []struct {
+		filter   ChatFilter
+		expected []string
+	}{
+		{ChatFilter{}, []string{"a", "b", "d"}},
+		{ChatFilter{All: true}, []string{"a", "b", "c", "d"}},
+		{ChatFilter{Archived: true}, []string{"c"}},
+		{ChatFilter{Pinned: true}, []string{"a"}},
+		{ChatFilter{Muted: true}, []string{"b"}},
+		{ChatFilter{Label: "#Work"}, []string{"a"}},
+		{ChatFilter{Label: "work", All: true}, []string{"a", "c"}},
+	}
+
+	for _, tc := range testCases {
+		if got := chatIDsOf(tc.filter.Apply(chats)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%+v: expected %v, got %v", tc.filter, tc.expected, got)
+		}
+	}
+}
+
+func TestParseLabelArgs(t *testing.T) {
+	add, remove := ParseLabelArgs([]string{"work,+vip", "-Old", "#clients"})
+	if !reflect.DeepEqual(add, []string{"work", "vip", "#clients"}) || !reflect.DeepEqual(remove, []string{"old"}) {
+		t.Errorf("Unexpected add %v, remove %v", add, remove)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/abhi-praj/GoGram/internal/termimg"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -21,6 +23,16 @@ type ChatWindow struct {
 	mode                 ChatMode
 	mutex                sync.RWMutex
 	app                  *tview.Application
+	imageOpts            termimg.Options
+	imagesEnabled        bool
+	imageRows            map[string][]string // rendered photos by local path
+	pane                 *tview.Flex
+	searchBar            *tview.InputField
+	onSearch             func(query string) []string
+	onSearchDone         func()
+	searchQuery          string
+	hits                 []int // indexes of messages matching the search, oldest first
+	hit                  int   // the hit being shown
 }
 
 // NewChatWindow creates a new chat window
@@ -31,27 +43,249 @@ func NewChatWindow(app *tview.Application) *ChatWindow {
 		SetWordWrap(true)
 
 	cw := &ChatWindow{
-		TextView: tv,
-		app:      app,
+		TextView:  tv,
+		app:       app,
+		imageRows: make(map[string][]string),
 	}
+	cw.imageOpts, cw.imagesEnabled = inlineImageOptions()
 
 	// Set up the text view
 	tv.SetBorder(true)
 	tv.SetTitle("Chat")
 	tv.SetTitleAlign(tview.AlignCenter)
 
+	// The search bar sits under the messages and only takes space while open
+	cw.searchBar = tview.NewInputField().
+		SetLabel("Search: ").
+		SetPlaceholder("words to find, Enter to search").
+		SetFieldWidth(0)
+	cw.searchBar.SetDoneFunc(cw.handleSearchDone)
+	cw.searchBar.SetInputCapture(cw.handleSearchKey)
+	cw.pane = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tv, 0, 1, false).
+		AddItem(cw.searchBar, 0, 0, false)
+	tv.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == '/' {
+			cw.OpenSearch("")
+			return nil
+		}
+		return event
+	})
+
 	return cw
 }
 
+// Pane is the chat window together with its search bar, for layouts
+func (cw *ChatWindow) Pane() tview.Primitive {
+	return cw.pane
+}
+
+// SetSearchHandler sets how the search bar finds messages: search returns the
+// IDs of matching messages, and done is called when the bar is closed
+func (cw *ChatWindow) SetSearchHandler(search func(query string) []string, done func()) {
+	cw.onSearch = search
+	cw.onSearchDone = done
+}
+
+// OpenSearch shows the search bar and focuses it, running query right away
+// if one is given
+func (cw *ChatWindow) OpenSearch(query string) {
+	cw.pane.ResizeItem(cw.searchBar, 1, 0)
+	cw.searchBar.SetText(query)
+	cw.app.SetFocus(cw.searchBar)
+	if query != "" {
+		cw.runSearch(query)
+	}
+}
+
+// CloseSearch hides the search bar and clears the hits
+func (cw *ChatWindow) CloseSearch() {
+	cw.pane.ResizeItem(cw.searchBar, 0, 0)
+
+	cw.mutex.Lock()
+	cw.hits = nil
+	cw.searchQuery = ""
+	cw.scrollOffset = 0
+	cw.buildMessageLines()
+	cw.mutex.Unlock()
+	cw.Update()
+
+	if cw.onSearchDone != nil {
+		cw.onSearchDone()
+	}
+}
+
+// handleSearchDone searches on Enter, or moves to the next older hit when
+// the query hasn't changed, and closes the bar on Esc
+func (cw *ChatWindow) handleSearchDone(key tcell.Key) {
+	switch key {
+	case tcell.KeyEnter:
+		query := strings.TrimSpace(cw.searchBar.GetText())
+		if query == "" {
+			return
+		}
+		if query == cw.searchQuery {
+			cw.moveHit(-1)
+			return
+		}
+		cw.runSearch(query)
+	case tcell.KeyEscape:
+		cw.CloseSearch()
+	}
+}
+
+// handleSearchKey steps through hits with Up (older) and Down (newer)
+func (cw *ChatWindow) handleSearchKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyUp:
+		cw.moveHit(-1)
+		return nil
+	case tcell.KeyDown:
+		cw.moveHit(1)
+		return nil
+	}
+	return event
+}
+
+func (cw *ChatWindow) runSearch(query string) {
+	if cw.onSearch == nil {
+		return
+	}
+	ids := cw.onSearch(query)
+
+	cw.mutex.Lock()
+	cw.searchQuery = query
+	cw.hits = cw.hits[:0]
+	matching := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		matching[id] = true
+	}
+	for i, msg := range cw.messages {
+		if matching[msg.ID] {
+			cw.hits = append(cw.hits, i)
+		}
+	}
+	// Start from the newest hit, like searching up from the bottom of a chat
+	cw.hit = len(cw.hits) - 1
+	cw.showHit()
+	cw.mutex.Unlock()
+	cw.Update()
+}
+
+// moveHit moves delta hits, staying on the first or last one
+func (cw *ChatWindow) moveHit(delta int) {
+	cw.mutex.Lock()
+	if len(cw.hits) > 0 {
+		cw.hit = max(0, min(cw.hit+delta, len(cw.hits)-1))
+		cw.showHit()
+	}
+	cw.mutex.Unlock()
+	cw.Update()
+}
+
+// SearchPosition returns which hit is shown, counting from the newest, and
+// how many there are
+func (cw *ChatWindow) SearchPosition() (int, int) {
+	cw.mutex.RLock()
+	defer cw.mutex.RUnlock()
+	if len(cw.hits) == 0 {
+		return 0, 0
+	}
+	return len(cw.hits) - cw.hit, len(cw.hits)
+}
+
+// HasMessage reports whether a message is among those shown
+func (cw *ChatWindow) HasMessage(id string) bool {
+	cw.mutex.RLock()
+	defer cw.mutex.RUnlock()
+	for _, msg := range cw.messages {
+		if msg.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// showHit selects the current hit and scrolls so its last line is at the
+// bottom of the window. The caller holds the mutex.
+func (cw *ChatWindow) showHit() {
+	if len(cw.hits) == 0 {
+		cw.scrollOffset = 0
+		cw.buildMessageLines()
+		return
+	}
+
+	cw.selection = cw.hits[cw.hit]
+	cw.buildMessageLines()
+
+	last := len(cw.messagesLines) - 1
+	for i, line := range cw.messagesLines {
+		if line.MessageIdx == cw.selection {
+			last = i
+		}
+	}
+	cw.scrollOffset = max(0, len(cw.messagesLines)-1-last)
+}
+
 // SetMessages updates the messages list
 func (cw *ChatWindow) SetMessages(messages []*Message) {
 	cw.mutex.Lock()
 	defer cw.mutex.Unlock()
 
 	cw.messages = messages
+	// Hits point into the old messages
+	cw.hits = nil
+	cw.buildMessageLines()
+}
+
+// SetPending replaces the pending messages shown after the chat history
+func (cw *ChatWindow) SetPending(pending []*Message) {
+	cw.mutex.Lock()
+	defer cw.mutex.Unlock()
+
+	messages := make([]*Message, 0, len(cw.messages)+len(pending))
+	for _, msg := range cw.messages {
+		if !msg.Pending {
+			messages = append(messages, msg)
+		}
+	}
+	cw.messages = append(messages, pending...)
 	cw.buildMessageLines()
 }
 
+// Rebuild re-wraps the current messages, e.g. after their photos are downloaded
+func (cw *ChatWindow) Rebuild() {
+	cw.mutex.Lock()
+	defer cw.mutex.Unlock()
+	cw.buildMessageLines()
+}
+
+// ImagesEnabled reports whether photos are drawn inline
+func (cw *ChatWindow) ImagesEnabled() bool {
+	return cw.imagesEnabled
+}
+
+// photoLines returns a message's photo as half-block rows. The TUI always
+// uses half blocks: tview owns the screen, so graphics protocol escapes
+// can't be written through it.
+func (cw *ChatWindow) photoLines(msg *Message, width int) []string {
+	if !cw.imagesEnabled || !hasInlineImage(msg) || msg.Attachment.LocalPath == "" {
+		return nil
+	}
+
+	path := msg.Attachment.LocalPath
+	if rows, ok := cw.imageRows[path]; ok {
+		return rows
+	}
+
+	var rows []string
+	if img, err := termimg.Load(path); err == nil {
+		rows = termimg.HalfBlocks(img, min(cw.imageOpts.MaxWidth, width), cw.imageOpts.MaxHeight, termimg.Tview)
+	}
+	cw.imageRows[path] = rows
+	return rows
+}
+
 // buildMessageLines builds wrapped lines for chat messages with word wrapping and formatting
 func (cw *ChatWindow) buildMessageLines() {
 	linesBuffer := make([]*LineInfo, 0)
@@ -60,17 +294,23 @@ func (cw *ChatWindow) buildMessageLines() {
 	// Build wrapped lines from oldest to newest
 	for msgIdx, msg := range cw.messages {
 		senderText := msg.Sender + ": "
+		// Group changes and other events aren't said by anyone
+		isSystem := msg.Type == MessageTypeSystem
+		if isSystem {
+			senderText = "• "
+		}
+		dimmed := msg.Pending || isSystem
 		senderWidth := len(senderText)
 
 		// Handle the main message
 		contentWidth := width - senderWidth - 1
-		isSelected := msgIdx == cw.selection && cw.mode == ChatModeReply
+		isSelected := msgIdx == cw.selection && (cw.mode == ChatModeReply || cw.mode == ChatModeReact || len(cw.hits) > 0)
 
 		// Determine color index
 		colorIdx := (hashString(msg.Sender) % 3) + 1
 
 		// Split content into words, then chunk
-		words := strings.Fields(msg.Text)
+		words := strings.Fields(msg.DisplayText())
 		lineBuffer := make([]string, 0)
 		currentWidth := 0
 		firstLine := true
@@ -86,7 +326,7 @@ func (cw *ChatWindow) buildMessageLines() {
 						ColorIdx:    colorIdx,
 						SenderWidth: senderWidth,
 						SenderText:  senderText,
-						IsDimmed:    false,
+						IsDimmed:    dimmed,
 					})
 				} else {
 					linesBuffer = append(linesBuffer, &LineInfo{
@@ -96,7 +336,7 @@ func (cw *ChatWindow) buildMessageLines() {
 						ColorIdx:    colorIdx,
 						SenderWidth: senderWidth,
 						SenderText:  " " + strings.Repeat(" ", senderWidth-1),
-						IsDimmed:    false,
+						IsDimmed:    dimmed,
 					})
 				}
 			}
@@ -121,6 +361,36 @@ func (cw *ChatWindow) buildMessageLines() {
 		// Flush remaining line buffer
 		flushLine()
 
+		for _, row := range cw.photoLines(msg, contentWidth) {
+			linesBuffer = append(linesBuffer, &LineInfo{
+				MessageIdx:  msgIdx,
+				Text:        row,
+				SenderWidth: senderWidth,
+				SenderText:  strings.Repeat(" ", senderWidth),
+			})
+		}
+
+		if len(msg.Reactions) > 0 {
+			linesBuffer = append(linesBuffer, &LineInfo{
+				MessageIdx:  msgIdx,
+				Text:        tview.Escape(ReactionSummary(msg.Reactions)),
+				IsSelected:  isSelected,
+				SenderWidth: senderWidth,
+				SenderText:  strings.Repeat(" ", senderWidth),
+				IsDimmed:    true,
+			})
+		}
+
+		if msg.Pending {
+			linesBuffer = append(linesBuffer, &LineInfo{
+				MessageIdx:  msgIdx,
+				Text:        "⏳ pending, will retry",
+				SenderWidth: senderWidth,
+				SenderText:  strings.Repeat(" ", senderWidth),
+				IsDimmed:    true,
+			})
+		}
+
 		// Add a blank line after each message
 		linesBuffer = append(linesBuffer, &LineInfo{
 			MessageIdx:  msgIdx,
@@ -162,8 +432,8 @@ func (cw *ChatWindow) Update() {
 	// Build the display text
 	var displayText strings.Builder
 
-	// Print from the bottom up
-	for i := len(cw.messagesLines) - 1; i >= 0; i-- {
+	// Lines are oldest first, so the newest message ends up at the bottom
+	for i := range cw.messagesLines {
 		if i < cw.visibleLinesRange[0] || i > cw.visibleLinesRange[1] {
 			continue
 		}
@@ -215,6 +485,7 @@ func (cw *ChatWindow) SetMode(mode ChatMode) {
 	cw.mutex.Lock()
 	defer cw.mutex.Unlock()
 	cw.mode = mode
+	cw.buildMessageLines()
 }
 
 // SetSelection sets the current selection
@@ -224,6 +495,38 @@ func (cw *ChatWindow) SetSelection(selection int) {
 	cw.selection = selection
 }
 
+// MoveSelection moves the selection by delta messages, staying in range
+func (cw *ChatWindow) MoveSelection(delta int) {
+	cw.mutex.Lock()
+	defer cw.mutex.Unlock()
+
+	if len(cw.messages) == 0 {
+		return
+	}
+	cw.selection = max(0, min(cw.selection+delta, len(cw.messages)-1))
+	cw.buildMessageLines()
+}
+
+// SelectLast selects the newest message
+func (cw *ChatWindow) SelectLast() {
+	cw.mutex.Lock()
+	defer cw.mutex.Unlock()
+
+	cw.selection = max(len(cw.messages)-1, 0)
+	cw.buildMessageLines()
+}
+
+// SelectedMessage returns the selected message, or nil if there are none
+func (cw *ChatWindow) SelectedMessage() *Message {
+	cw.mutex.RLock()
+	defer cw.mutex.RUnlock()
+
+	if cw.selection < 0 || cw.selection >= len(cw.messages) {
+		return nil
+	}
+	return cw.messages[cw.selection]
+}
+
 // GetSelection returns the current selection
 func (cw *ChatWindow) GetSelection() int {
 	cw.mutex.RLock()
@@ -2,35 +2,62 @@ package chat
 
 import (
 	"fmt"
+	"io"
+	"net/url"
+	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"strconv"
 
 	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/chatmeta"
 	"github.com/abhi-praj/GoGram/internal/client"
+	"github.com/abhi-praj/GoGram/internal/history"
+	"github.com/abhi-praj/GoGram/internal/media"
+	"github.com/abhi-praj/GoGram/internal/notify"
+	"github.com/abhi-praj/GoGram/internal/outbox"
+	"github.com/abhi-praj/GoGram/internal/schedule"
 )
 
 // DirectMessages handles Instagram direct messaging functionality
 type DirectMessages struct {
 	client          *client.ClientWrapper
 	insta           *goinsta.Instagram
-	internalIDMap   map[string]string
-	nextInternalID  int
+	chatIDs         *chatIDs
 	currentUserID   string
 	notificationMgr *NotificationManager
+	media           *media.Manager
+	schedules       *schedule.Store
+	dispatcher      *schedule.Dispatcher
+	scheduleMutex   sync.Mutex
+	outbox          *outbox.Store
+	outboxWorker    *outbox.Worker
+	outboxMutex     sync.Mutex
+	history         *history.Store
+	meta            *chatmeta.Store
+	// post and syncInbox reach Instagram; tests replace them with stubs
+	post      func(path string, form url.Values) ([]byte, error)
+	syncInbox func() error
 }
 
 // NewDirectMessages creates a new DirectMessages instance
 func NewDirectMessages(client *client.ClientWrapper) *DirectMessages {
 	dm := &DirectMessages{
-		client:         client,
-		insta:          client.GetInstaClient(),
-		internalIDMap:  make(map[string]string),
-		nextInternalID: 100000,
-		currentUserID:  client.GetUserID(),
-	}
+		client:        client,
+		insta:         client.GetInstaClient(),
+		chatIDs:       loadChatIDs(userFile(client.GetUsername(), "chat_ids.json")),
+		currentUserID: client.GetUserID(),
+		media:         newMediaManager(client.GetUsername()),
+		schedules:     newScheduleStore(client.GetUsername()),
+		outbox:        newOutboxStore(client.GetUsername()),
+		history:       newHistoryStore(client.GetUsername()),
+		meta:          chatmeta.NewStore(userFile(client.GetUsername(), "chat_meta.json")),
+		post:          client.PrivatePost,
+	}
+	dm.syncInbox = func() error { return dm.insta.Inbox.Sync() }
 	dm.notificationMgr = NewNotificationManager(dm)
 	return dm
 }
@@ -45,15 +72,41 @@ type Chat struct {
 	LastActivity time.Time
 	UnreadCount  int
 	IsGroup      bool
+	Muted        bool
+	// Pending is set for message requests that haven't been accepted
+	Pending bool
+	// Pinned, MutedLocally, Archived and Labels are kept on this computer
+	// only; Muted is the setting on Instagram
+	Pinned       bool
+	MutedLocally bool
+	Archived     bool
+	Labels       []string
 }
 
 // Message represents a single message in a chat
 type Message struct {
-	ID        string
-	Text      string
-	Sender    string
+	ID     string
+	Text   string
+	Sender string
+	// Username is the sender's Instagram username; Sender is their display name
+	Username  string
 	Timestamp time.Time
-	Type      string // text, media, etc.
+	Type      string // one of the MessageType constants
+	// Attachment is set for photos, videos, voice notes, shares and links
+	Attachment *Attachment
+	// Reactions is only filled in by LoadReactions
+	Reactions []Reaction
+	// ReplyTo is set for replies fetched with GetChatHistoryBefore
+	ReplyTo *ReplyContext
+	// Pending is set for messages still waiting in the outbox
+	Pending bool
+}
+
+// ReplyContext is the message a reply answers
+type ReplyContext struct {
+	ID     string
+	Sender string
+	Text   string
 }
 
 // GetChats fetches the list of recent chats
@@ -68,12 +121,30 @@ func (dm *DirectMessages) GetChatsWithLimit(limit int) ([]*Chat, error) {
 	}
 
 	// Sync inbox to get latest data
-	if err := dm.insta.Inbox.Sync(); err != nil {
+	if err := dm.syncInbox(); err != nil {
 		return nil, fmt.Errorf("failed to sync inbox: %v", err)
 	}
 
+	return dm.buildChats(limit), nil
+}
+
+// CachedChats returns the chats from the last inbox sync without hitting the network,
+// for things like tab completion that run on every keypress
+func (dm *DirectMessages) CachedChats() []*Chat {
+	if dm.insta == nil {
+		return nil
+	}
+	return dm.buildChats(0)
+}
+
+// buildChats converts the synced inbox into chats sorted by last activity
+func (dm *DirectMessages) buildChats(limit int) []*Chat {
+	return dm.chatsFrom(dm.insta.Inbox.Conversations, limit)
+}
+
+// chatsFrom converts conversations into chats sorted by last activity
+func (dm *DirectMessages) chatsFrom(conversations []*goinsta.Conversation, limit int) []*Chat {
 	var chats []*Chat
-	conversations := dm.insta.Inbox.Conversations
 
 	// my attempt to sort by last activity at
 	sortableConvs := make([]*goinsta.Conversation, len(conversations))
@@ -82,28 +153,34 @@ func (dm *DirectMessages) GetChatsWithLimit(limit int) ([]*Chat, error) {
 	sort.Slice(sortableConvs, func(i, j int) bool {
 		return sortableConvs[i].LastActivityAt > sortableConvs[j].LastActivityAt
 	})
+	// Pinned chats go first, so a limit doesn't cut them off
+	meta := dm.chatMeta()
+	sort.SliceStable(sortableConvs, func(i, j int) bool {
+		return meta[sortableConvs[i].ID].Pinned && !meta[sortableConvs[j].ID].Pinned
+	})
 
 	if limit > 0 && limit < len(sortableConvs) {
 		sortableConvs = sortableConvs[:limit]
 	}
 
-	for _, conv := range sortableConvs {
-		// Generate or retrieve internal ID
-		internalID, exists := dm.internalIDMap[conv.ID]
-		if !exists {
-			internalID = fmt.Sprintf("%06d", dm.nextInternalID)
-			dm.internalIDMap[conv.ID] = internalID
-			dm.nextInternalID++
-		}
+	threadIDs := make([]string, len(sortableConvs))
+	for i, conv := range sortableConvs {
+		threadIDs[i] = conv.ID
+	}
+	internalIDs := dm.chatIDs.assign(threadIDs)
 
+	for _, conv := range sortableConvs {
 		chat := &Chat{
 			ID:           conv.ID,
-			InternalID:   internalID,
+			InternalID:   internalIDs[conv.ID],
 			Title:        conv.Title,
 			Users:        conv.Users,
 			IsGroup:      conv.IsGroup,
+			Muted:        conv.Muted,
+			Pending:      conv.Pending,
 			LastActivity: time.Unix(conv.LastActivityAt, 0),
 		}
+		chat.applyMeta(meta[conv.ID])
 
 		// Get last message if available
 		if len(conv.Items) > 0 {
@@ -116,7 +193,7 @@ func (dm *DirectMessages) GetChatsWithLimit(limit int) ([]*Chat, error) {
 		chats = append(chats, chat)
 	}
 
-	return chats, nil
+	return chats
 }
 
 // GetChatByInternalID finds a chat by its internal ID
@@ -163,6 +240,15 @@ func (dm *DirectMessages) GetChatHistory(chatID string, limit int) ([]*Message,
 		return nil, fmt.Errorf("failed to get chat items: %v", err)
 	}
 
+	messages := dm.messagesFrom(conversation, limit)
+	dm.attachLocalMedia(conversation.ID, messages, false)
+	dm.recordHistory(conversation.ID, conversation.Title, messages)
+	return messages, nil
+}
+
+// messagesFrom converts a conversation's loaded items, newest first, naming
+// each sender
+func (dm *DirectMessages) messagesFrom(conversation *goinsta.Conversation, limit int) []*Message {
 	var messages []*Message
 	itemCount := len(conversation.Items)
 	if limit > 0 && limit < itemCount {
@@ -171,41 +257,31 @@ func (dm *DirectMessages) GetChatHistory(chatID string, limit int) ([]*Message,
 
 	for i := 0; i < itemCount; i++ {
 		item := conversation.Items[i]
-		message := &Message{
-			ID:        item.ID,
-			Text:      item.Text,
-			Timestamp: time.Unix(item.Timestamp, 0),
-			Type:      "text", // Default to text, could be enhanced
-		}
+		message := newMessageFromItem(item)
+		message.Sender, message.Username = dm.sender(conversation, item.UserID)
+		messages = append(messages, message)
+	}
+	return messages
+}
 
-		// Determine sender based on user ID comparison
-		currentUserIDInt, _ := strconv.ParseInt(dm.currentUserID, 10, 64)
-		if item.UserID == currentUserIDInt {
-			message.Sender = "You"
-		} else {
-			// Try to find the user in the conversation's users list
-			var senderName string
-			for _, user := range conversation.Users {
-				if user.ID == item.UserID {
-					if user.FullName != "" {
-						senderName = user.FullName
-					} else {
-						senderName = user.Username
-					}
-					break
-				}
-			}
+// sender names who sent an item: "You" or the person's full name, falling
+// back to their username, along with the username
+func (dm *DirectMessages) sender(conversation *goinsta.Conversation, userID int64) (string, string) {
+	currentUserIDInt, _ := strconv.ParseInt(dm.currentUserID, 10, 64)
+	if userID == currentUserIDInt {
+		return "You", dm.client.GetUsername()
+	}
 
-			if senderName == "" {
-				senderName = "Unknown User"
+	// Try to find the user in the conversation's users list
+	for _, user := range conversation.Users {
+		if user.ID == userID {
+			if user.FullName != "" {
+				return user.FullName, user.Username
 			}
-			message.Sender = senderName
+			return user.Username, user.Username
 		}
-
-		messages = append(messages, message)
 	}
-
-	return messages, nil
+	return "Unknown User", ""
 }
 
 // SendMessage sends a message to a specific chat
@@ -214,17 +290,12 @@ func (dm *DirectMessages) SendMessage(chatID, message string) error {
 		return fmt.Errorf("not logged in")
 	}
 
-	chat, err := dm.GetChatByInternalID(chatID)
-	if err == nil {
-		chatID = chat.ID
-	}
-
-	// Find the conversation
-	var conversation *goinsta.Conversation
-	for _, conv := range dm.insta.Inbox.Conversations {
-		if conv.ID == chatID {
-			conversation = conv
-			break
+	// A thread ID from the last sync is used as-is, so sending to many chats
+	// in a row doesn't sync the inbox for each one
+	conversation := dm.conversation(chatID)
+	if conversation == nil {
+		if chat, err := dm.GetChatByInternalID(chatID); err == nil {
+			conversation = dm.conversation(chat.ID)
 		}
 	}
 
@@ -240,46 +311,27 @@ func (dm *DirectMessages) SendMessage(chatID, message string) error {
 	return nil
 }
 
-// SendMessageToUser sends a message to a user by username
+// SendMessageToUser sends a message to a user by username, starting a chat
+// if there isn't one
 func (dm *DirectMessages) SendMessageToUser(username, message string) error {
-	if dm.insta == nil {
-		return fmt.Errorf("not logged in")
-	}
-
-	// Search for the user
-	searchResult, err := dm.insta.Searchbar.SearchUser(username)
-	if err != nil {
-		return fmt.Errorf("failed to search for user: %v", err)
-	}
-
-	if len(searchResult.Users) == 0 {
-		return fmt.Errorf("user not found: %s", username)
-	}
-
-	user := searchResult.Users[0]
-
-	// Create a new conversation or find existing one
-	_, err = dm.insta.Inbox.New(user, message)
-	if err != nil {
-		return fmt.Errorf("failed to send message: %v", err)
-	}
-
-	return nil
+	_, err := dm.StartConversation(username, message)
+	return err
 }
 
-// SendMessageByInternalID sends a message to a chat using its internal ID
+// SendMessageByInternalID sends a message to a chat using its internal ID.
+// It goes through the outbox, so a send that fails is kept and retried; the
+// error is then a *QueuedError.
 func (dm *DirectMessages) SendMessageByInternalID(internalID, message string) error {
-	chat, err := dm.GetChatByInternalID(internalID)
+	chat, err := dm.outboxChat(internalID)
 	if err != nil {
-		return fmt.Errorf("chat with internal ID %s not found: %v", internalID, err)
+		return err
 	}
-
-	return dm.SendMessage(chat.ID, message)
+	return dm.sendThroughOutbox(chat, message)
 }
 
 // SearchChats searches for chats by username or title
 func (dm *DirectMessages) SearchChats(query string) ([]*Chat, error) {
-	chats, err := dm.GetChats()
+	chats, err := dm.GetChatsWithLimit(0)
 	if err != nil {
 		return nil, err
 	}
@@ -306,30 +358,51 @@ func (dm *DirectMessages) SearchChats(query string) ([]*Chat, error) {
 	return results, nil
 }
 
-// MarkAsSeen marks a chat as seen
-func (dm *DirectMessages) MarkAsSeen(chatID string) error {
-	if dm.insta == nil {
-		return fmt.Errorf("not logged in")
-	}
+// WaitForMessage polls a chat until a message from another participant
+// matches pattern, returning the first match. Messages sent before the wait
+// started are ignored.
+func (dm *DirectMessages) WaitForMessage(chatID string, pattern *regexp.Regexp, timeout, interval time.Duration) (*Message, error) {
+	fetch := func() ([]*Message, error) { return dm.GetChatHistory(chatID, 20) }
+	// History timestamps can be a little behind the local clock
+	return waitForMessage(fetch, pattern, time.Now().Truncate(time.Second), timeout, interval)
+}
 
-	// Find the conversation
-	var conversation *goinsta.Conversation
-	for _, conv := range dm.insta.Inbox.Conversations {
-		if conv.ID == chatID {
-			conversation = conv
-			break
+// waitForMessage checks fetch for a match right away and then every
+// interval, never sleeping past the timeout
+func waitForMessage(fetch func() ([]*Message, error), pattern *regexp.Regexp, since time.Time, timeout, interval time.Duration) (*Message, error) {
+	deadline := since.Add(timeout)
+	seen := make(map[string]bool)
+
+	for {
+		messages, err := fetch()
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if conversation == nil {
-		return fmt.Errorf("chat not found")
-	}
+		// History is newest first; check oldest unseen message first
+		for i := len(messages) - 1; i >= 0; i-- {
+			msg := messages[i]
+			if seen[msg.ID] || msg.Timestamp.Before(since) {
+				continue
+			}
+			seen[msg.ID] = true
 
-	// Mark as seen - TODO: Fix this when we understand the MarkAsSeen API better
-	// For now, we'll just return success
-	_ = conversation
+			if msg.Sender != "You" && pattern.MatchString(msg.Text) {
+				return msg, nil
+			}
+		}
 
-	return nil
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if remaining > interval {
+			remaining = interval
+		}
+		time.Sleep(remaining)
+	}
+
+	return nil, fmt.Errorf("timed out after %v waiting for a message matching %q", timeout, pattern.String())
 }
 
 // GetUnreadCount returns the total number of unread messages
@@ -338,7 +411,7 @@ func (dm *DirectMessages) GetUnreadCount() (int, error) {
 		return 0, fmt.Errorf("not logged in")
 	}
 
-	if err := dm.insta.Inbox.Sync(); err != nil {
+	if err := dm.syncInbox(); err != nil {
 		return 0, fmt.Errorf("failed to sync inbox: %v", err)
 	}
 
@@ -366,6 +439,31 @@ func (dm *DirectMessages) StopNotifications() {
 	}
 }
 
+// SetNotificationHandler routes new message notifications to handler, which shows them in the app
+func (dm *DirectMessages) SetNotificationHandler(handler func(*Chat, *Message)) {
+	if dm.notificationMgr == nil {
+		dm.notificationMgr = NewNotificationManager(dm)
+	}
+	dm.notificationMgr.SetHandler(handler)
+}
+
+// SetTerminalOutput changes where the terminal notification sink writes, nil
+// turning it off, and returns the previous writer
+func (dm *DirectMessages) SetTerminalOutput(out io.Writer) io.Writer {
+	if dm.notificationMgr == nil {
+		dm.notificationMgr = NewNotificationManager(dm)
+	}
+	return dm.notificationMgr.SetTerminalOutput(out)
+}
+
+// AddNotifier sends every notification to notifier too, such as a gRPC stream
+func (dm *DirectMessages) AddNotifier(name string, notifier notify.Notifier) {
+	if dm.notificationMgr == nil {
+		dm.notificationMgr = NewNotificationManager(dm)
+	}
+	dm.notificationMgr.AddNotifier(name, notifier)
+}
+
 // IsNotificationRunning returns whether notifications are active
 func (dm *DirectMessages) IsNotificationRunning() bool {
 	return dm.notificationMgr != nil && dm.notificationMgr.IsRunning()
@@ -392,6 +490,14 @@ func (dm *DirectMessages) RefreshNotifications() {
 	}
 }
 
+// NotificationSinkErrors returns the last error of each failing notification sink
+func (dm *DirectMessages) NotificationSinkErrors() map[string]string {
+	if dm.notificationMgr != nil {
+		return dm.notificationMgr.SinkErrors()
+	}
+	return nil
+}
+
 // GetNotificationDebugInfo returns debug information about the notification system
 func (dm *DirectMessages) GetNotificationDebugInfo() map[string]interface{} {
 	if dm.notificationMgr != nil {
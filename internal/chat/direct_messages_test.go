@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWaitForMessage(t *testing.T) {
+	start := time.Now()
+	pattern := regexp.MustCompile(`^pong`)
+
+	// A reply already there on the first check is found without sleeping
+	calls := 0
+	fetch := func() ([]*Message, error) {
+		calls++
+		return []*Message{
+			{ID: "3", Sender: "Bob", Text: "pong", Timestamp: start.Add(time.Millisecond)},
+			{ID: "2", Sender: "You", Text: "pong", Timestamp: start},
+			{ID: "1", Sender: "Bob", Text: "pong from before", Timestamp: start.Add(-time.Minute)},
+		}, nil
+	}
+	msg, err := waitForMessage(fetch, pattern, start, time.Minute, time.Hour)
+	if err != nil || msg.ID != "3" || calls != 1 {
+		t.Fatalf("Expected message 3 on the first check, got %+v, %v after %d checks", msg, err, calls)
+	}
+
+	// The last sleep stops at the deadline rather than a whole interval later
+	calls = 0
+	fetch = func() ([]*Message, error) {
+		calls++
+		return []*Message{{ID: "1", Sender: "Bob", Text: "ping", Timestamp: time.Now()}}, nil
+	}
+	begin := time.Now()
+	if _, err := waitForMessage(fetch, pattern, begin, 50*time.Millisecond, time.Hour); err == nil {
+		t.Fatal("Expected a timeout")
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second || calls != 2 {
+		t.Errorf("Expected two checks within the timeout, got %d in %v", calls, elapsed)
+	}
+}
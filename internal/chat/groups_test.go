@@ -0,0 +1,138 @@
+package chat
+
+import (
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+func TestParseGroupArgs(t *testing.T) {
+	usernames, title := ParseGroupArgs([]string{"@alice", "Weekend", "@bob", "plans", "@"})
+	if !reflect.DeepEqual(usernames, []string{"alice", "bob"}) {
+		t.Errorf("Unexpected usernames %v", usernames)
+	}
+	if title != "Weekend plans @" {
+		t.Errorf("Unexpected title %q", title)
+	}
+
+	if usernames, title := ParseGroupArgs(nil); usernames != nil || title != "" {
+		t.Errorf("Expected nothing from no args, got %v %q", usernames, title)
+	}
+}
+
+func TestUserIDList(t *testing.T) {
+	users := []*goinsta.User{{ID: 1234}, {ID: 5678}}
+	if got := userIDList(users); got != `["1234","5678"]` {
+		t.Errorf("Unexpected user ID list %s", got)
+	}
+}
+
+// stubPost records private API POSTs and answers them with body
+type stubPost struct {
+	paths []string
+	forms []url.Values
+	body  string
+}
+
+func (s *stubPost) post(path string, form url.Values) ([]byte, error) {
+	s.paths = append(s.paths, path)
+	s.forms = append(s.forms, form)
+	return []byte(s.body), nil
+}
+
+// stubInbox returns a logged in DirectMessages whose inbox holds conversations
+// and whose POSTs go to post
+func stubInbox(t *testing.T, post *stubPost, conversations ...*goinsta.Conversation) *DirectMessages {
+	return &DirectMessages{
+		insta:     &goinsta.Instagram{Inbox: &goinsta.Inbox{Conversations: conversations}},
+		chatIDs:   loadChatIDs(filepath.Join(t.TempDir(), "chat_ids.json")),
+		post:      post.post,
+		syncInbox: func() error { return nil },
+	}
+}
+
+func TestCreateGroupThread(t *testing.T) {
+	users := []*goinsta.User{{ID: 1234}, {ID: 5678}}
+	testCases := []struct {
+		body     string
+		threadID string
+	}{
+		{`{"thread_id": "t1", "status": "ok"}`, "t1"},
+		{`{"thread": {"thread_id": "t2"}, "status": "ok"}`, "t2"},
+		{`{"status": "ok"}`, ""},
+	}
+
+	for _, tc := range testCases {
+		post := &stubPost{body: tc.body}
+		dm := stubInbox(t, post)
+
+		threadID, err := dm.createGroupThread(users, "Weekend plans")
+		if tc.threadID == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got thread %s", tc.body, threadID)
+			}
+			continue
+		}
+		if err != nil || threadID != tc.threadID {
+			t.Errorf("%s: expected thread %s, got %s, %v", tc.body, tc.threadID, threadID, err)
+		}
+		if post.paths[0] != "direct_v2/create_group_thread/" {
+			t.Errorf("Unexpected endpoint %s", post.paths[0])
+		}
+		if form := post.forms[0]; form.Get("recipient_users") != `["1234","5678"]` || form.Get("thread_title") != "Weekend plans" {
+			t.Errorf("Unexpected form %v", form)
+		}
+	}
+}
+
+func TestGroupChatRejectsOneOnOne(t *testing.T) {
+	post := &stubPost{}
+	dm := stubInbox(t, post,
+		&goinsta.Conversation{ID: "t1", Title: "alice", Users: []*goinsta.User{{ID: 1, Username: "alice"}}},
+		&goinsta.Conversation{ID: "t2", Title: "Crew", IsGroup: true},
+	)
+
+	if _, err := dm.groupChat("t1"); err == nil || !strings.Contains(err.Error(), "not a group chat") {
+		t.Errorf("Expected t1 to be refused as not a group, got %v", err)
+	}
+	if err := dm.RenameGroup("t1", "New name"); err == nil {
+		t.Errorf("Expected renaming a one-on-one chat to fail")
+	}
+	if chat, err := dm.groupChat("t2"); err != nil || chat.ID != "t2" {
+		t.Errorf("Expected t2 to be found, got %v, %v", chat, err)
+	}
+	if _, err := dm.groupChat("t3"); err == nil {
+		t.Errorf("Expected an unknown chat to fail")
+	}
+	if len(post.paths) != 0 {
+		t.Errorf("Expected nothing to be sent, got %v", post.paths)
+	}
+}
+
+func TestRemoveGroupMember(t *testing.T) {
+	post := &stubPost{body: `{"status": "ok"}`}
+	dm := stubInbox(t, post, &goinsta.Conversation{
+		ID:      "t1",
+		Title:   "Crew",
+		IsGroup: true,
+		Users:   []*goinsta.User{{ID: 1234, Username: "alice"}, {ID: 5678, Username: "bob"}},
+	})
+
+	if err := dm.RemoveGroupMember("t1", "@carol"); err == nil || !strings.Contains(err.Error(), "isn't in Crew") {
+		t.Errorf("Expected removing a non-member to fail, got %v", err)
+	}
+	if len(post.paths) != 0 {
+		t.Fatalf("Expected nothing to be sent for a non-member, got %v", post.paths)
+	}
+
+	if err := dm.RemoveGroupMember("t1", "@Bob"); err != nil {
+		t.Fatalf("Failed to remove bob: %v", err)
+	}
+	if post.paths[0] != "direct_v2/threads/t1/remove_users/" || post.forms[0].Get("user_ids") != `["5678"]` {
+		t.Errorf("Unexpected request %s %v", post.paths[0], post.forms[0])
+	}
+}
@@ -0,0 +1,228 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/history"
+)
+
+// newHistoryStore keeps each account's message history in its own directory
+func newHistoryStore(username string) *history.Store {
+	return history.NewStore(userFile(username, "history"))
+}
+
+// recordHistory adds fetched messages to the local history. It's best effort:
+// a history that can't be written shouldn't stop the messages being shown.
+func (dm *DirectMessages) recordHistory(threadID, title string, messages []*Message) {
+	if dm.history == nil || len(messages) == 0 {
+		return
+	}
+
+	stored := make([]history.Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Pending {
+			continue
+		}
+		stored = append(stored, historyMessage(msg))
+	}
+	dm.history.Add(threadID, title, stored)
+}
+
+func historyMessage(msg *Message) history.Message {
+	stored := history.Message{
+		ID:       msg.ID,
+		Sender:   msg.Sender,
+		Username: msg.Username,
+		Text:     msg.Text,
+		Time:     msg.Timestamp,
+		Kind:     msg.Type,
+		HasMedia: IsMediaType(msg.Type),
+	}
+	if a := msg.Attachment; a != nil {
+		stored.Caption = a.Caption
+		if stored.Caption == "" {
+			stored.Caption = a.Title
+		}
+	}
+	return stored
+}
+
+// SearchMessages searches the locally stored history, newest first. The
+// query's ThreadID may be an internal chat ID.
+func (dm *DirectMessages) SearchMessages(q history.Query) ([]history.Result, error) {
+	if q.ThreadID != "" {
+		if threadID, ok := dm.chatIDs.thread(q.ThreadID); ok {
+			q.ThreadID = threadID
+		}
+	}
+	return dm.history.Search(q)
+}
+
+// StoredHistory returns a chat's locally stored messages, oldest first. It
+// can reach further back than the API returns in one go.
+func (dm *DirectMessages) StoredHistory(chatID string) ([]*Message, error) {
+	threadID := chatID
+	if id, ok := dm.chatIDs.thread(chatID); ok {
+		threadID = id
+	}
+
+	thread, err := dm.history.Thread(threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*Message, 0, len(thread.Messages))
+	for _, stored := range thread.Messages {
+		messages = append(messages, &Message{
+			ID:        stored.ID,
+			Text:      stored.Text,
+			Sender:    stored.Sender,
+			Username:  stored.Username,
+			Timestamp: stored.Time,
+			Type:      stored.Kind,
+		})
+	}
+	return messages, nil
+}
+
+// historyPageSize is how many messages are asked for per request when paging
+const historyPageSize = 20
+
+// threadItem is an inbox item as the thread endpoint returns it, including
+// the reply context goinsta drops
+type threadItem struct {
+	goinsta.InboxItem
+	RepliedTo *struct {
+		ID     string `json:"item_id"`
+		UserID int64  `json:"user_id"`
+		Text   string `json:"text"`
+	} `json:"replied_to_message"`
+}
+
+// GetChatHistoryBefore fetches up to limit messages older than beforeID,
+// newest first, paging back through the chat. An empty beforeID starts at
+// the newest message. hasMore reports whether older messages remain.
+func (dm *DirectMessages) GetChatHistoryBefore(chatID, beforeID string, limit int) ([]*Message, bool, error) {
+	if dm.insta == nil {
+		return nil, false, fmt.Errorf("not logged in")
+	}
+
+	conversation := dm.conversation(chatID)
+	if conversation == nil {
+		chat, err := dm.GetChatByInternalID(chatID)
+		if err != nil {
+			return nil, false, err
+		}
+		conversation = dm.conversation(chat.ID)
+	}
+	if conversation == nil {
+		return nil, false, fmt.Errorf("chat not found")
+	}
+
+	var messages []*Message
+	cursor := beforeID
+	hasMore := true
+	for hasMore && (limit <= 0 || len(messages) < limit) {
+		form := url.Values{}
+		form.Set("visual_message_return_type", "unseen")
+		form.Set("limit", strconv.Itoa(historyPageSize))
+		if cursor != "" {
+			form.Set("cursor", cursor)
+			form.Set("direction", "older")
+		}
+
+		body, err := dm.client.PrivateGet(fmt.Sprintf("direct_v2/threads/%s/", conversation.ID), form)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get chat history: %v", err)
+		}
+
+		var resp struct {
+			Thread struct {
+				Items        []*threadItem `json:"items"`
+				HasOlder     bool          `json:"has_older"`
+				OldestCursor string        `json:"oldest_cursor"`
+			} `json:"thread"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, false, fmt.Errorf("failed to parse chat history: %v", err)
+		}
+
+		for _, item := range resp.Thread.Items {
+			message := newMessageFromItem(&item.InboxItem)
+			message.Sender, message.Username = dm.sender(conversation, item.UserID)
+			if r := item.RepliedTo; r != nil {
+				sender, _ := dm.sender(conversation, r.UserID)
+				message.ReplyTo = &ReplyContext{ID: r.ID, Sender: sender, Text: r.Text}
+			}
+			messages = append(messages, message)
+		}
+
+		hasMore = resp.Thread.HasOlder && resp.Thread.OldestCursor != "" && resp.Thread.OldestCursor != cursor
+		cursor = resp.Thread.OldestCursor
+	}
+
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+		hasMore = true
+	}
+	dm.attachLocalMedia(conversation.ID, messages, false)
+	dm.recordHistory(conversation.ID, conversation.Title, messages)
+	return messages, hasMore, nil
+}
+
+// ChatHistoryRange pages back through a chat and returns its messages sent
+// in [since, until), oldest first. Zero times leave that end open. progress,
+// if set, is called with the number of messages fetched so far. Media that
+// hasn't been downloaded is fetched first when download is set.
+func (dm *DirectMessages) ChatHistoryRange(chatID string, since, until time.Time, download bool, progress func(int)) ([]*Message, error) {
+	var (
+		inRange []*Message
+		fetched int
+		before  string
+	)
+	for {
+		page, hasMore, err := dm.GetChatHistoryBefore(chatID, before, historyPageSize)
+		if err != nil {
+			return nil, err
+		}
+		fetched += len(page)
+		if progress != nil {
+			progress(fetched)
+		}
+
+		reachedStart := false
+		for _, msg := range page {
+			if !since.IsZero() && msg.Timestamp.Before(since) {
+				reachedStart = true
+				continue
+			}
+			if until.IsZero() || msg.Timestamp.Before(until) {
+				inRange = append(inRange, msg)
+			}
+		}
+
+		if len(page) == 0 || !hasMore || reachedStart {
+			break
+		}
+		before = page[len(page)-1].ID
+	}
+
+	// Pages come newest first
+	for i, j := 0, len(inRange)-1; i < j; i, j = i+1, j-1 {
+		inRange[i], inRange[j] = inRange[j], inRange[i]
+	}
+
+	if download && len(inRange) > 0 {
+		threadID, err := dm.threadID(chatID)
+		if err != nil {
+			return nil, err
+		}
+		dm.attachLocalMedia(threadID, inRange, true)
+	}
+	return inRange, nil
+}
@@ -0,0 +1,61 @@
+package chat
+
+import (
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/termimg"
+)
+
+// inlineImageOptions reads the chat.inline_images settings. ok is false when
+// inline images are turned off.
+func inlineImageOptions() (termimg.Options, bool) {
+	cfg := config.GetInstance()
+	if !cfg.GetBool("chat.inline_images", true) {
+		return termimg.Options{}, false
+	}
+
+	protocol, err := termimg.ParseProtocol(cfg.GetString("chat.image_protocol", "auto"))
+	if err != nil || protocol == termimg.ProtocolNone {
+		return termimg.Options{}, false
+	}
+
+	return termimg.Options{
+		Protocol:  protocol,
+		MaxWidth:  cfg.GetInt("chat.image_max_width", 40),
+		MaxHeight: cfg.GetInt("chat.image_max_height", 20),
+	}, true
+}
+
+// hasInlineImage reports whether a message is a photo that can be drawn
+func hasInlineImage(msg *Message) bool {
+	return msg.Type == MessageTypePhoto && msg.Attachment != nil && msg.Attachment.Kind == AttachmentPhoto
+}
+
+// cacheImages downloads photos into the media directory so they can be drawn
+// inline. It does nothing when inline images are turned off.
+func (dm *DirectMessages) cacheImages(threadID string, messages []*Message) {
+	if _, ok := inlineImageOptions(); !ok {
+		return
+	}
+
+	var photos []*Message
+	for _, msg := range messages {
+		if hasInlineImage(msg) && msg.Attachment.LocalPath == "" {
+			photos = append(photos, msg)
+		}
+	}
+	dm.attachLocalMedia(threadID, photos, true)
+}
+
+// renderInlineImage returns the escape sequences that draw a message's photo
+// in the terminal, or an empty string if it has none or can't be decoded
+func renderInlineImage(msg *Message, opts termimg.Options) string {
+	if !hasInlineImage(msg) || msg.Attachment.LocalPath == "" {
+		return ""
+	}
+
+	rendered, err := termimg.RenderFile(msg.Attachment.LocalPath, opts)
+	if err != nil {
+		return ""
+	}
+	return rendered
+}
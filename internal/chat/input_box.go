@@ -63,6 +63,11 @@ func (ib *InputBox) handleDone(key tcell.Key) {
 	}
 }
 
+// SetOnChange calls fn with the field's text whenever it changes
+func (ib *InputBox) SetOnChange(fn func(text string)) {
+	ib.InputField.SetChangedFunc(fn)
+}
+
 // insertRune inserts a rune at the current cursor position
 func (ib *InputBox) insertRune(r rune) {
 	if ib.cursorPos == len(ib.buffer) {
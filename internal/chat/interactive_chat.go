@@ -2,6 +2,7 @@ package chat
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -21,6 +22,7 @@ type InteractiveChat struct {
 	stopChan     chan bool
 	mutex        sync.Mutex
 	lastSentText string
+	lastPresence string
 }
 
 // NewInteractiveChat creates a new interactive chat instance
@@ -89,6 +91,10 @@ func (ic *InteractiveChat) Start() error {
 	if err := ic.displayRecentMessages(10); err != nil {
 		fmt.Printf("Warning: Could not load recent messages: %v\n", err)
 	}
+	if err := ic.dm.MarkAsSeen(ic.chatID); err != nil {
+		fmt.Printf("Warning: Could not mark chat as seen: %v\n", err)
+	}
+	ic.checkPresence()
 
 	fmt.Println("\nChat started! Type your message and press Enter.")
 	fmt.Println("Commands: /quit to exit, /help for help")
@@ -127,16 +133,23 @@ func (ic *InteractiveChat) displayRecentMessages(limit int) error {
 		return err
 	}
 
-	if len(messages) == 0 {
+	pending := ic.dm.PendingMessages(ic.chatID)
+	if len(messages) == 0 && len(pending) == 0 {
 		fmt.Println("No previous messages in this chat.")
 		return nil
 	}
 
+	ic.dm.cacheImages(ic.conversation.ID, messages)
+	ic.dm.LoadReactions(ic.chatID, messages)
+
 	fmt.Printf("\nRecent messages:\n")
 	for i := len(messages) - 1; i >= 0; i-- {
 		msg := messages[i]
 		ic.displayMessage(msg, false)
 	}
+	for _, msg := range pending {
+		ic.displayMessage(msg, false)
+	}
 
 	return nil
 }
@@ -154,10 +167,22 @@ func (ic *InteractiveChat) displayMessage(msg *Message, isNew bool) {
 		fmt.Printf("\n")
 	}
 
-	if msg.Sender == "You" {
-		fmt.Printf("You (%s): %s\n", timeStr, msg.Text)
+	if msg.Type == MessageTypeSystem {
+		fmt.Printf("  • %s (%s)\n", msg.DisplayText(), timeStr)
+	} else if msg.Pending {
+		fmt.Printf("You (%s, ⏳ pending): %s\n", timeStr, msg.DisplayText())
+	} else if msg.Sender == "You" {
+		fmt.Printf("You (%s): %s\n", timeStr, msg.DisplayText())
 	} else {
-		fmt.Printf("%s (%s): %s\n", msg.Sender, timeStr, msg.Text)
+		fmt.Printf("%s (%s): %s\n", msg.Sender, timeStr, msg.DisplayText())
+	}
+
+	if opts, ok := inlineImageOptions(); ok {
+		fmt.Print(renderInlineImage(msg, opts))
+	}
+
+	if len(msg.Reactions) > 0 {
+		fmt.Printf("    %s\n", ReactionSummary(msg.Reactions))
 	}
 }
 
@@ -188,7 +213,12 @@ func (ic *InteractiveChat) inputHandler() error {
 			}
 
 			// Send message
-			if err := ic.sendMessage(input); err != nil {
+			err = ic.sendMessage(input)
+			var queued *QueuedError
+			if errors.As(err, &queued) {
+				ic.displayMessage(pendingMessage(queued.Item), false)
+				fmt.Printf("⏳ %v\n", err)
+			} else if err != nil {
 				fmt.Printf("Failed to send message: %v\n", err)
 			} else {
 				// Track the sent message to avoid duplicate display
@@ -224,6 +254,27 @@ func (ic *InteractiveChat) handleCommand(cmd string) error {
 		} else {
 			fmt.Println("Chat refreshed")
 		}
+	case "/react", "/unreact":
+		return ic.react(command, parts[1:])
+	case "/forward":
+		return ic.forward(parts[1:])
+	case "/schedule":
+		return ic.schedule(strings.TrimSpace(strings.TrimPrefix(cmd, parts[0])))
+	case "/send-photo", "/send-video":
+		path := MediaPathArg(cmd)
+		if path == "" {
+			return fmt.Errorf("usage: %s <path>", command)
+		}
+
+		fmt.Println("Uploading...")
+		send := ic.dm.SendPhoto
+		if command == "/send-video" {
+			send = ic.dm.SendVideo
+		}
+		if err := send(ic.chatID, path); err != nil {
+			return err
+		}
+		fmt.Println("Media sent")
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		ic.showHelp()
@@ -239,9 +290,153 @@ func (ic *InteractiveChat) showHelp() {
 	fmt.Println("  /help         - Show this help")
 	fmt.Println("  /clear        - Clear the screen")
 	fmt.Println("  /refresh      - Refresh recent messages")
+	fmt.Println("  /react [n] [emoji] - React to the nth newest message (default: last received, ❤️)")
+	fmt.Println("  /unreact [n]  - Remove your reaction")
+	fmt.Println("  /forward [n] <chat-id|@username> - Forward the nth newest message (default: last received)")
+	fmt.Println("  /schedule [time|+duration] <text> - Send a message later (e.g. +30m, 18:00)")
+	fmt.Println("  /schedule     - List this chat's scheduled messages")
+	fmt.Println("  /send-photo <path> - Send a photo (JPEG, PNG or GIF)")
+	fmt.Println("  /send-video <path> - Send an MP4 video")
 	fmt.Println("  (type message) - Send a message")
 }
 
+// react handles /react and /unreact. A leading number picks the nth newest
+// message; otherwise the newest message from someone else is used.
+func (ic *InteractiveChat) react(command string, args []string) error {
+	position := 0
+	if len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			if n < 1 {
+				return fmt.Errorf("message number must be 1 or more")
+			}
+			position = n
+			args = args[1:]
+		}
+	}
+
+	msg, err := ic.targetMessage(position)
+	if err != nil {
+		return err
+	}
+
+	if command == "/unreact" {
+		if err := ic.dm.Unreact(ic.chatID, msg.ID); err != nil {
+			return err
+		}
+		fmt.Printf("Removed your reaction from %s: %s\n", msg.Sender, previewText(msg))
+		return nil
+	}
+
+	emoji := strings.Join(args, " ")
+	if emoji == "" {
+		emoji = likeEmoji
+	}
+	if err := ic.dm.React(ic.chatID, msg.ID, emoji); err != nil {
+		return err
+	}
+	fmt.Printf("Reacted %s to %s: %s\n", emoji, msg.Sender, previewText(msg))
+	return nil
+}
+
+// forward handles /forward [n] <target>, picking the message like /react
+func (ic *InteractiveChat) forward(args []string) error {
+	position := 0
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("message number must be 1 or more")
+		}
+		position = n
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("usage: /forward [n] <chat-id|@username>")
+	}
+
+	msg, err := ic.targetMessage(position)
+	if err != nil {
+		return err
+	}
+	if err := ic.dm.ForwardMessage(ic.chatID, msg.ID, args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("Forwarded %s: %s to %s\n", msg.Sender, previewText(msg), args[0])
+	return nil
+}
+
+// schedule queues a message for later, or lists this chat's pending ones
+func (ic *InteractiveChat) schedule(args string) error {
+	if args == "" {
+		messages, err := ic.dm.ListScheduled(false)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for _, msg := range messages {
+			if msg.ChatID == ic.conversation.ID {
+				fmt.Printf("  %s  %s  %-9s %s\n", msg.ID, msg.SendAt.Format("Mon Jan 2 15:04"), msg.Status, previewString(msg.Text))
+				count++
+			}
+		}
+		if count == 0 {
+			fmt.Println("No scheduled messages in this chat")
+		}
+		return nil
+	}
+
+	sendAt, text, err := ParseScheduleArgs(args)
+	if err != nil {
+		return fmt.Errorf("%v (usage: /schedule [time|+duration] <text>)", err)
+	}
+
+	msg, err := ic.dm.ScheduleMessage(ic.chatID, text, sendAt)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Scheduled %s for %s (cancel with: chat schedule cancel %s)\n", msg.ID, sendAt.Format("Mon Jan 2 15:04"), msg.ID)
+	if !ic.dm.IsSchedulerRunning() {
+		fmt.Println("Note: it will be sent by the next GoGram instance that is running at that time")
+	}
+	return nil
+}
+
+// targetMessage returns the nth newest message, or the newest one someone
+// else sent when position is 0
+func (ic *InteractiveChat) targetMessage(position int) (*Message, error) {
+	messages, err := ic.dm.GetChatHistory(ic.chatID, max(position, 20))
+	if err != nil {
+		return nil, err
+	}
+
+	if position > 0 {
+		if position > len(messages) {
+			return nil, fmt.Errorf("chat only has %d messages", len(messages))
+		}
+		return messages[position-1], nil
+	}
+
+	for _, msg := range messages {
+		if msg.Sender != "You" {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("no received messages in this chat")
+}
+
+// previewText shortens a message for confirmations
+func previewText(msg *Message) string {
+	return previewString(msg.DisplayText())
+}
+
+func previewString(s string) string {
+	text := []rune(s)
+	if len(text) > 40 {
+		return string(text[:37]) + "..."
+	}
+	return string(text)
+}
+
 // clearScreen clears the terminal
 func (ic *InteractiveChat) clearScreen() {
 	fmt.Print("\033[H\033[2J")
@@ -257,6 +452,8 @@ func (ic *InteractiveChat) sendMessage(text string) error {
 func (ic *InteractiveChat) messageReceiver() {
 	ticker := time.NewTicker(3 * time.Second)
 	defer ticker.Stop()
+	presenceTicker := time.NewTicker(presenceInterval)
+	defer presenceTicker.Stop()
 
 	for {
 		select {
@@ -264,14 +461,35 @@ func (ic *InteractiveChat) messageReceiver() {
 			return
 		case <-ticker.C:
 			ic.checkForNewMessages()
+		case <-presenceTicker.C:
+			ic.checkPresence()
 		}
 	}
 }
 
+// checkPresence prints "Seen by X" when it changes
+func (ic *InteractiveChat) checkPresence() {
+	presence, err := ic.dm.GetPresence(ic.chatID)
+	if err != nil {
+		return
+	}
+
+	text := presence.String()
+	ic.mutex.Lock()
+	defer ic.mutex.Unlock()
+	if text == ic.lastPresence {
+		return
+	}
+	ic.lastPresence = text
+	if text != "" {
+		fmt.Printf("    %s\n", text)
+	}
+}
+
 // checkForNewMessages checks if there are new messages and displays them
 func (ic *InteractiveChat) checkForNewMessages() {
 	// Sync inbox to get latest messages
-	if err := ic.dm.insta.Inbox.Sync(); err != nil {
+	if err := ic.dm.syncInbox(); err != nil {
 		return // Silently fail, will retry next tick
 	}
 
@@ -303,12 +521,7 @@ func (ic *InteractiveChat) checkForNewMessages() {
 		if latestTime.After(time.Now().Add(-10*time.Second)) &&
 			latestItem.UserID != currentUserIDInt {
 
-			msg := &Message{
-				ID:        latestItem.ID,
-				Text:      latestItem.Text,
-				Timestamp: latestTime,
-				Type:      "text",
-			}
+			msg := newMessageFromItem(latestItem)
 
 			// todo make sure the sender logic is correct
 			var senderName string
@@ -327,7 +540,10 @@ func (ic *InteractiveChat) checkForNewMessages() {
 			}
 			msg.Sender = senderName
 
+			ic.dm.attachLocalMedia(conversation.ID, []*Message{msg}, ic.dm.shouldAutoDownload(conversation.ID))
+			ic.dm.cacheImages(conversation.ID, []*Message{msg})
 			ic.displayMessage(msg, true)
+			ic.dm.MarkAsSeen(ic.chatID)
 		}
 
 		// Handle sent messages (messages from current user that were just sent)
@@ -336,13 +552,8 @@ func (ic *InteractiveChat) checkForNewMessages() {
 			latestItem.Text == ic.lastSentText &&
 			ic.lastSentText != "" {
 
-			msg := &Message{
-				ID:        latestItem.ID,
-				Text:      latestItem.Text,
-				Timestamp: latestTime,
-				Type:      "text",
-				Sender:    "You",
-			}
+			msg := newMessageFromItem(latestItem)
+			msg.Sender = "You"
 
 			ic.displayMessage(msg, false)
 			ic.lastSentText = ""
@@ -0,0 +1,458 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/client"
+)
+
+// Message types, stored in Message.Type
+const (
+	MessageTypeText       = "text"
+	MessageTypePhoto      = "photo"
+	MessageTypeVideo      = "video"
+	MessageTypeVoice      = "voice"
+	MessageTypeReelShare  = "reel_share"
+	MessageTypePostShare  = "post_share"
+	MessageTypeStoryReply = "story_reply"
+	MessageTypeLink       = "link"
+	MessageTypeLike       = "like"
+	MessageTypeAnimated   = "animated"
+	MessageTypeSystem     = "system"
+)
+
+// Attachment kinds
+const (
+	AttachmentPhoto = "photo"
+	AttachmentVideo = "video"
+	AttachmentAudio = "audio"
+	AttachmentLink  = "link"
+)
+
+// Attachment describes the media carried by a message
+type Attachment struct {
+	Kind       string
+	URL        string
+	PreviewURL string
+	Width      int
+	Height     int
+	Duration   time.Duration
+	MediaID    string
+	Code       string // shortcode of a shared post or reel
+	Owner      string // username of a shared post, reel or story
+	Caption    string
+	Title      string // link preview title
+	LocalPath  string // set once the media has been downloaded
+}
+
+// IsMediaType reports whether messages of this type carry media rather than text
+func IsMediaType(messageType string) bool {
+	switch messageType {
+	case MessageTypePhoto, MessageTypeVideo, MessageTypeVoice, MessageTypeReelShare,
+		MessageTypePostShare, MessageTypeStoryReply, MessageTypeAnimated:
+		return true
+	}
+	return false
+}
+
+// DisplayText is the message text, or a short placeholder for media messages
+// so they don't show up as empty lines
+func (m *Message) DisplayText() string {
+	label := attachmentLabel(m)
+	if m.Attachment != nil && m.Attachment.LocalPath != "" {
+		label = strings.TrimSpace(label + " " + m.Attachment.LocalPath)
+	}
+	switch {
+	case label == "":
+		return m.Text
+	case m.Text == "":
+		return label
+	default:
+		return label + " " + m.Text
+	}
+}
+
+// AttachmentLabel is the bracketed placeholder DisplayText shows for media,
+// such as "[photo 1080x1350]", or "" for plain text messages
+func (m *Message) AttachmentLabel() string {
+	return attachmentLabel(m)
+}
+
+func attachmentLabel(m *Message) string {
+	a := m.Attachment
+
+	switch m.Type {
+	case MessageTypePhoto:
+		return "[photo" + dimensions(a) + "]"
+	case MessageTypeVideo:
+		return "[video" + dimensions(a) + duration(a) + "]"
+	case MessageTypeVoice:
+		return "[voice message" + duration(a) + "]"
+	case MessageTypeAnimated:
+		return "[GIF]"
+	case MessageTypeReelShare:
+		return "[reel" + owner(a) + "]"
+	case MessageTypePostShare:
+		return "[post" + owner(a) + "]"
+	case MessageTypeStoryReply:
+		return "[story reply" + owner(a) + "]"
+	case MessageTypeLink:
+		if a != nil && a.Title != "" && !strings.Contains(m.Text, a.Title) {
+			return "[link: " + a.Title + "]"
+		}
+	}
+	return ""
+}
+
+func dimensions(a *Attachment) string {
+	if a == nil || a.Width == 0 || a.Height == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" %dx%d", a.Width, a.Height)
+}
+
+func duration(a *Attachment) string {
+	if a == nil || a.Duration == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" %s", a.Duration.Round(time.Second))
+}
+
+func owner(a *Attachment) string {
+	if a == nil || a.Owner == "" {
+		return ""
+	}
+	return " by @" + a.Owner
+}
+
+// newMessageFromItem converts an inbox item into a Message without sender info
+func newMessageFromItem(item *goinsta.InboxItem) *Message {
+	messageType, text, attachment := classifyItem(item)
+	return &Message{
+		ID:         item.ID,
+		Text:       text,
+		Timestamp:  itemTime(item.Timestamp),
+		Type:       messageType,
+		Attachment: attachment,
+	}
+}
+
+// itemTime converts an item timestamp. Instagram sends microseconds, but
+// seconds and milliseconds turn up too, e.g. in items built locally.
+func itemTime(ts int64) time.Time {
+	switch {
+	case ts > 1e14:
+		return time.UnixMicro(ts)
+	case ts > 1e11:
+		return time.UnixMilli(ts)
+	}
+	return time.Unix(ts, 0)
+}
+
+// classifyItem works out what kind of message an inbox item is
+func classifyItem(item *goinsta.InboxItem) (string, string, *Attachment) {
+	switch item.Type {
+	case "like":
+		text := item.Like
+		if text == "" {
+			text = "❤️"
+		}
+		return MessageTypeLike, text, nil
+
+	case "media":
+		if item.Media != nil {
+			messageType, attachment := mediaAttachment(item.Media)
+			return messageType, item.Text, attachment
+		}
+
+	case "raven_media":
+		if item.VisualMedia != nil && item.VisualMedia.Media != nil {
+			messageType, attachment := mediaAttachment(item.VisualMedia.Media)
+			return messageType, item.Text, attachment
+		}
+
+	case "voice_media":
+		if item.VoiceMedia != nil {
+			audio := item.VoiceMedia.Media.Audio
+			return MessageTypeVoice, "", &Attachment{
+				Kind:     AttachmentAudio,
+				URL:      audio.AudioSrc,
+				MediaID:  item.VoiceMedia.Media.ID,
+				Duration: time.Duration(audio.Duration) * time.Millisecond,
+			}
+		}
+
+	case "media_share":
+		if item.MediaShare != nil {
+			_, attachment := mediaAttachment(item.MediaShare)
+			return MessageTypePostShare, item.Text, attachment
+		}
+
+	case "clip":
+		if item.Clip != nil {
+			_, attachment := mediaAttachment(&item.Clip.Media)
+			return MessageTypeReelShare, item.Text, attachment
+		}
+
+	case "reel_share":
+		if item.Reel != nil {
+			_, attachment := mediaAttachment(&item.Reel.Media)
+			text := item.Reel.Text
+			if text == "" {
+				text = item.Text
+			}
+			return MessageTypeStoryReply, text, attachment
+		}
+
+	case "link":
+		text := item.Link.Text
+		if text == "" {
+			text = item.Text
+		}
+		return MessageTypeLink, text, &Attachment{
+			Kind:       AttachmentLink,
+			URL:        item.Link.Context.URL,
+			PreviewURL: item.Link.Context.ImageURL,
+			Title:      item.Link.Context.Title,
+			Caption:    item.Link.Context.Summary,
+		}
+
+	case "animated_media":
+		if item.AnimatedMedia != nil {
+			image := item.AnimatedMedia.Images.FixedHeight
+			return MessageTypeAnimated, "", &Attachment{
+				Kind:    AttachmentVideo,
+				URL:     firstNonEmpty(image.Mp4, image.URL),
+				MediaID: item.AnimatedMedia.ID,
+			}
+		}
+
+	case "action_log":
+		if item.ActionLog != nil {
+			return MessageTypeSystem, item.ActionLog.Description, nil
+		}
+
+	case "text", "":
+		return MessageTypeText, item.Text, nil
+	}
+
+	if item.Text != "" {
+		return MessageTypeText, item.Text, nil
+	}
+	return MessageTypeText, fmt.Sprintf("[unsupported message: %s]", item.Type), nil
+}
+
+// mediaAttachment describes a goinsta media item, using the first slide of carousels
+func mediaAttachment(media *goinsta.Item) (string, *Attachment) {
+	if len(media.CarouselMedia) > 0 && len(media.Images.Versions) == 0 && len(media.Videos) == 0 {
+		messageType, attachment := mediaAttachment(&media.CarouselMedia[0])
+		attachment.Code = media.Code
+		attachment.Owner = media.User.Username
+		attachment.Caption = media.Caption.Text
+		return messageType, attachment
+	}
+
+	attachment := &Attachment{
+		PreviewURL: goinsta.GetBest(media.Images.Versions),
+		Width:      media.OriginalWidth,
+		Height:     media.OriginalHeight,
+		MediaID:    mediaID(media),
+		Code:       media.Code,
+		Owner:      media.User.Username,
+		Caption:    media.Caption.Text,
+	}
+
+	if media.MediaType == 2 || len(media.Videos) > 0 {
+		attachment.Kind = AttachmentVideo
+		attachment.URL = goinsta.GetBest(media.Videos)
+		attachment.Duration = time.Duration(media.VideoDuration * float64(time.Second))
+		return MessageTypeVideo, attachment
+	}
+
+	attachment.Kind = AttachmentPhoto
+	attachment.URL = attachment.PreviewURL
+	attachment.PreviewURL = ""
+	return MessageTypePhoto, attachment
+}
+
+// mediaID formats goinsta's ID, which is usually a string but sometimes a number
+func mediaID(media *goinsta.Item) string {
+	switch id := media.ID.(type) {
+	case nil:
+		return ""
+	case string:
+		return id
+	case float64:
+		return fmt.Sprintf("%.0f", id)
+	default:
+		return fmt.Sprintf("%v", id)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// DetectMediaKind reports whether the file at path is a photo or a video
+func DetectMediaKind(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, _ := file.Read(head)
+	contentType := http.DetectContentType(head[:n])
+
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return AttachmentPhoto, nil
+	case strings.HasPrefix(contentType, "video/"):
+		return AttachmentVideo, nil
+	}
+
+	// MOV files and some MP4 brands aren't sniffed, fall back to the extension
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg", ".png", ".gif":
+		return AttachmentPhoto, nil
+	case ".mp4", ".mov", ".m4v":
+		return AttachmentVideo, nil
+	}
+
+	return "", fmt.Errorf("%s is not a photo or video (%s)", filepath.Base(path), contentType)
+}
+
+// MediaPathArg extracts the path argument from a "/send-photo <path>" style
+// command, allowing spaces and surrounding quotes and expanding ~
+func MediaPathArg(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) < 2 {
+		return ""
+	}
+
+	path := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(command), fields[0]))
+	path = strings.Trim(path, `"'`)
+
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// SendMedia sends a photo or video file to a chat by internal ID
+func (dm *DirectMessages) SendMedia(internalID, path string) error {
+	kind, err := DetectMediaKind(path)
+	if err != nil {
+		return err
+	}
+
+	if kind == AttachmentVideo {
+		return dm.SendVideo(internalID, path)
+	}
+	return dm.SendPhoto(internalID, path)
+}
+
+// SendPhoto uploads an image and sends it to a chat by internal ID
+func (dm *DirectMessages) SendPhoto(internalID, path string) error {
+	chat, err := dm.GetChatByInternalID(internalID)
+	if err != nil {
+		return fmt.Errorf("chat with internal ID %s not found: %v", internalID, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	photo, err := client.PreparePhoto(data)
+	if err != nil {
+		return err
+	}
+
+	uploadID, err := dm.client.UploadPhoto(photo)
+	if err != nil {
+		return err
+	}
+
+	return dm.configureMedia(chat.ID, "configure_photo", url.Values{
+		"upload_id":               {uploadID},
+		"allow_full_aspect_ratio": {"true"},
+	})
+}
+
+// SendVideo uploads an MP4 video and sends it to a chat by internal ID
+func (dm *DirectMessages) SendVideo(internalID, path string) error {
+	chat, err := dm.GetChatByInternalID(internalID)
+	if err != nil {
+		return fmt.Errorf("chat with internal ID %s not found: %v", internalID, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	info, err := client.ProbeVideo(data)
+	if err != nil {
+		return fmt.Errorf("failed to read video %s: %v", filepath.Base(path), err)
+	}
+
+	uploadID, err := dm.client.UploadVideo(data, info)
+	if err != nil {
+		return err
+	}
+
+	return dm.configureMedia(chat.ID, "configure_video", url.Values{
+		"upload_id":    {uploadID},
+		"video_result": {""},
+		"sampled":      {"true"},
+	})
+}
+
+// configureMedia attaches an uploaded photo or video to a thread
+func (dm *DirectMessages) configureMedia(threadID, method string, form url.Values) error {
+	if err := dm.broadcast(threadID, method, form); err != nil {
+		return fmt.Errorf("failed to send media: %v", err)
+	}
+	return nil
+}
+
+// broadcast posts an item to a thread through direct_v2/threads/broadcast/<method>/.
+// action defaults to send_item.
+func (dm *DirectMessages) broadcast(threadID, method string, form url.Values) error {
+	threadIDs, _ := json.Marshal([]string{threadID})
+	token := client.NewClientContext()
+
+	if form.Get("action") == "" {
+		form.Set("action", "send_item")
+	}
+	form.Set("is_shh_mode", "0")
+	form.Set("send_attribution", "direct_thread")
+	form.Set("thread_ids", string(threadIDs))
+	// A caller may pass its own client context as an idempotency key
+	if form.Get("client_context") != "" {
+		token = form.Get("client_context")
+	}
+	form.Set("client_context", token)
+	form.Set("mutation_token", token)
+	form.Set("offline_threading_id", token)
+
+	_, err := dm.post("direct_v2/threads/broadcast/"+method+"/", form)
+	return err
+}
@@ -0,0 +1,205 @@
+package chat
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/media"
+)
+
+// newMediaManager creates the downloader for the logged in account under advanced.media_dir
+func newMediaManager(username string) *media.Manager {
+	cfg := config.GetInstance()
+	mediaDir := cfg.GetString("advanced.media_dir", filepath.Join(cfg.GetConfigDir(), "media"))
+	if username == "" {
+		username = "default"
+	}
+
+	return media.NewManager(
+		filepath.Join(mediaDir, username),
+		cfg.GetInt("media.max_concurrent_downloads", 3),
+		cfg.GetBool("media.auto_download", false),
+	)
+}
+
+// Media returns the account's media downloader
+func (dm *DirectMessages) Media() *media.Manager {
+	return dm.media
+}
+
+// shouldAutoDownload reports whether new media in a thread is saved as it arrives
+func (dm *DirectMessages) shouldAutoDownload(threadID string) bool {
+	return dm.media != nil && dm.media.ShouldAutoDownload(threadID)
+}
+
+// downloadable reports whether a message carries a file that can be saved
+func downloadable(msg *Message) bool {
+	a := msg.Attachment
+	if a == nil || a.URL == "" {
+		return false
+	}
+	return a.Kind == AttachmentPhoto || a.Kind == AttachmentVideo || a.Kind == AttachmentAudio
+}
+
+// attachLocalMedia fills in LocalPath for messages whose media is already on
+// disk, downloading the rest first when download is set
+func (dm *DirectMessages) attachLocalMedia(threadID string, messages []*Message, download bool) {
+	if dm.media == nil {
+		return
+	}
+
+	var pending []*Message
+	for _, msg := range messages {
+		if !downloadable(msg) {
+			continue
+		}
+		if entry := dm.media.Lookup(threadID, msg.ID); entry != nil {
+			msg.Attachment.LocalPath = entry.Path
+		} else if download {
+			pending = append(pending, msg)
+		}
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	reqs := make([]media.Request, len(pending))
+	for i, msg := range pending {
+		reqs[i] = mediaRequest(threadID, msg)
+	}
+	for i, result := range dm.media.DownloadAll(reqs) {
+		if result.Err == nil {
+			pending[i].Attachment.LocalPath = result.Entry.Path
+		}
+	}
+}
+
+func mediaRequest(threadID string, msg *Message) media.Request {
+	return media.Request{
+		ChatID:    threadID,
+		MessageID: msg.ID,
+		URL:       msg.Attachment.URL,
+		Kind:      msg.Attachment.Kind,
+	}
+}
+
+// DownloadChatMedia downloads the attachments among a chat's last limit messages
+func (dm *DirectMessages) DownloadChatMedia(chatID string, limit int) ([]media.Result, error) {
+	if dm.media == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := dm.GetChatHistory(chatID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqs []media.Request
+	for _, msg := range messages {
+		if downloadable(msg) {
+			reqs = append(reqs, mediaRequest(threadID, msg))
+		}
+	}
+	return dm.media.DownloadAll(reqs), nil
+}
+
+// SetAutoDownload sets a chat's auto-download policy (on, off or default)
+func (dm *DirectMessages) SetAutoDownload(chatID, policy string) error {
+	if dm.media == nil {
+		return fmt.Errorf("not logged in")
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return err
+	}
+	return dm.media.SetPolicy(threadID, policy)
+}
+
+// AutoDownloadPolicy describes a chat's auto-download setting, including the
+// global default it falls back to
+func (dm *DirectMessages) AutoDownloadPolicy(chatID string) (string, error) {
+	if dm.media == nil {
+		return "", fmt.Errorf("not logged in")
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return "", err
+	}
+
+	policy := dm.media.Policy(threadID)
+	if policy == media.PolicyDefault {
+		state := media.PolicyNever
+		if dm.media.ShouldAutoDownload(threadID) {
+			state = media.PolicyAlways
+		}
+		policy = fmt.Sprintf("%s (%s)", policy, state)
+	}
+	return policy, nil
+}
+
+// MediaEntries lists downloaded media for a chat, or for every chat when chatID is empty
+func (dm *DirectMessages) MediaEntries(chatID string) ([]*media.Entry, error) {
+	if dm.media == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+	if chatID == "" {
+		return dm.media.Entries(""), nil
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return dm.media.Entries(threadID), nil
+}
+
+// PruneMedia deletes downloads older than maxAge, optionally only for one chat
+func (dm *DirectMessages) PruneMedia(maxAge time.Duration, chatID string) (int, int64, error) {
+	if dm.media == nil {
+		return 0, 0, fmt.Errorf("not logged in")
+	}
+
+	threadID := ""
+	if chatID != "" {
+		var err error
+		if threadID, err = dm.threadID(chatID); err != nil {
+			return 0, 0, err
+		}
+	}
+	return dm.media.Prune(maxAge, threadID)
+}
+
+// InternalIDForThread returns the internal chat ID for an Instagram thread ID,
+// or the thread ID itself if the chat isn't in the inbox
+func (dm *DirectMessages) InternalIDForThread(threadID string) string {
+	if internalID, ok := dm.chatIDs.get(threadID); ok {
+		return internalID
+	}
+	return threadID
+}
+
+// threadID resolves an internal chat ID to the Instagram thread ID, accepting
+// thread IDs as-is like GetChatHistory does
+func (dm *DirectMessages) threadID(chatID string) (string, error) {
+	chat, err := dm.GetChatByInternalID(chatID)
+	if err == nil {
+		return chat.ID, nil
+	}
+
+	for _, conv := range dm.insta.Inbox.Conversations {
+		if conv.ID == chatID {
+			return conv.ID, nil
+		}
+	}
+	return "", err
+}
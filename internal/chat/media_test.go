@@ -0,0 +1,124 @@
+package chat
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+func inboxItem(t *testing.T, raw string) *goinsta.InboxItem {
+	t.Helper()
+	var item goinsta.InboxItem
+	if err := json.Unmarshal([]byte(raw), &item); err != nil {
+		t.Fatalf("Failed to parse item: %v", err)
+	}
+	return &item
+}
+
+func TestClassifyItem(t *testing.T) {
+	testCases := []struct {
+		name         string
+		raw          string
+		expectedType string
+		expectedText string
+		expectedURL  string
+	}{
+		{"text", `{"item_type":"text","text":"hi"}`, MessageTypeText, "hi", ""},
+		{"like", `{"item_type":"like","like":"❤️"}`, MessageTypeLike, "❤️", ""},
+		{"photo", `{"item_type":"media","media":{"media_type":1,"original_width":1080,"original_height":1350,
+			"image_versions2":{"candidates":[{"width":320,"height":400,"url":"small"},{"width":1080,"height":1350,"url":"big"}]}}}`,
+			MessageTypePhoto, "", "big"},
+		{"video", `{"item_type":"media","media":{"media_type":2,"video_duration":12.4,
+			"video_versions":[{"width":720,"height":1280,"url":"vid"}]}}`, MessageTypeVideo, "", "vid"},
+		{"disappearing photo", `{"item_type":"raven_media","visual_media":{"media":{"media_type":1,
+			"image_versions2":{"candidates":[{"width":640,"height":640,"url":"raven"}]}}}}`, MessageTypePhoto, "", "raven"},
+		{"voice", `{"item_type":"voice_media","voice_media":{"media":{"id":"v1","audio":{"audio_src":"audio","duration":4200}}}}`,
+			MessageTypeVoice, "", "audio"},
+		{"post share", `{"item_type":"media_share","media_share":{"media_type":1,"code":"abc","user":{"username":"nasa"},
+			"image_versions2":{"candidates":[{"width":100,"height":100,"url":"post"}]}}}`, MessageTypePostShare, "", "post"},
+		{"reel share", `{"item_type":"clip","clip":{"clip":{"media_type":2,"video_versions":[{"width":10,"height":10,"url":"reel"}]}}}`,
+			MessageTypeReelShare, "", "reel"},
+		{"story reply", `{"item_type":"reel_share","reel_share":{"text":"lol","type":"reply","media":{"media_type":1,
+			"image_versions2":{"candidates":[{"width":10,"height":10,"url":"story"}]}}}}`, MessageTypeStoryReply, "lol", "story"},
+		{"link", `{"item_type":"link","link":{"text":"look https://go.dev","link_context":{"link_url":"https://go.dev","link_title":"Go"}}}`,
+			MessageTypeLink, "look https://go.dev", "https://go.dev"},
+		{"action log", `{"item_type":"action_log","action_log":{"description":"Alice named the group"}}`,
+			MessageTypeSystem, "Alice named the group", ""},
+		{"unknown", `{"item_type":"xma_something"}`, MessageTypeText, "[unsupported message: xma_something]", ""},
+	}
+
+	for _, tc := range testCases {
+		msg := newMessageFromItem(inboxItem(t, tc.raw))
+		if msg.Type != tc.expectedType {
+			t.Errorf("%s: expected type %s, got %s", tc.name, tc.expectedType, msg.Type)
+		}
+		if msg.Text != tc.expectedText {
+			t.Errorf("%s: expected text %q, got %q", tc.name, tc.expectedText, msg.Text)
+		}
+
+		url := ""
+		if msg.Attachment != nil {
+			url = msg.Attachment.URL
+		}
+		if url != tc.expectedURL {
+			t.Errorf("%s: expected attachment URL %q, got %q", tc.name, tc.expectedURL, url)
+		}
+	}
+}
+
+func TestDisplayText(t *testing.T) {
+	testCases := []struct {
+		msg      *Message
+		expected string
+	}{
+		{&Message{Type: MessageTypeText, Text: "hello"}, "hello"},
+		{&Message{Type: MessageTypePhoto, Attachment: &Attachment{Width: 1080, Height: 1350}}, "[photo 1080x1350]"},
+		{&Message{Type: MessageTypeVoice, Attachment: &Attachment{Duration: 4200 * time.Millisecond}}, "[voice message 4s]"},
+		{&Message{Type: MessageTypePostShare, Text: "look", Attachment: &Attachment{Owner: "nasa"}}, "[post by @nasa] look"},
+		{&Message{Type: MessageTypePhoto, Attachment: &Attachment{LocalPath: "/tmp/m1.jpg"}}, "[photo] /tmp/m1.jpg"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.msg.DisplayText(); got != tc.expected {
+			t.Errorf("DisplayText() = %q, expected %q", got, tc.expected)
+		}
+	}
+}
+
+func TestDetectMediaKind(t *testing.T) {
+	dir := t.TempDir()
+
+	jpegPath := filepath.Join(dir, "photo.bin")
+	os.WriteFile(jpegPath, []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), 0600)
+	if kind, err := DetectMediaKind(jpegPath); err != nil || kind != AttachmentPhoto {
+		t.Errorf("Expected photo, got %q (%v)", kind, err)
+	}
+
+	movPath := filepath.Join(dir, "clip.mov")
+	os.WriteFile(movPath, []byte("\x00\x00\x00\x14ftypqt  "), 0600)
+	if kind, err := DetectMediaKind(movPath); err != nil || kind != AttachmentVideo {
+		t.Errorf("Expected video, got %q (%v)", kind, err)
+	}
+
+	textPath := filepath.Join(dir, "notes.txt")
+	os.WriteFile(textPath, []byte("just text"), 0600)
+	if _, err := DetectMediaKind(textPath); err == nil {
+		t.Error("Expected an error for a text file")
+	}
+}
+
+func TestMediaPathArg(t *testing.T) {
+	if got := MediaPathArg("/send-photo  /tmp/my photo.jpg "); got != "/tmp/my photo.jpg" {
+		t.Errorf("Expected path with spaces, got %q", got)
+	}
+	if got := MediaPathArg(`send-video "/tmp/clip.mp4"`); got != "/tmp/clip.mp4" {
+		t.Errorf("Expected quotes to be stripped, got %q", got)
+	}
+	if got := MediaPathArg("/send-photo"); got != "" {
+		t.Errorf("Expected empty path, got %q", got)
+	}
+}
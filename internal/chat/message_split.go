@@ -0,0 +1,86 @@
+package chat
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+)
+
+// MaxMessageLength is the longest text message Instagram accepts, in characters
+const MaxMessageLength = 1000
+
+// SplitMessage breaks text into messages of at most limit characters,
+// preferring to break at whitespace. Words longer than limit are split hard.
+func SplitMessage(text string, limit int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if limit <= 0 {
+		limit = MaxMessageLength
+	}
+
+	var parts []string
+	runes := []rune(text)
+
+	for len(runes) > limit {
+		cut := limit
+		for i := limit; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+
+		part := strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+		if part != "" {
+			parts = append(parts, part)
+		}
+		runes = []rune(strings.TrimLeftFunc(string(runes[cut:]), unicode.IsSpace))
+	}
+
+	if len(runes) > 0 {
+		parts = append(parts, string(runes))
+	}
+
+	return parts
+}
+
+// ScanBlocks is a bufio.SplitFunc that yields blocks separated by NUL bytes
+// or blank lines, so multi-line messages can be piped in one at a time
+func ScanBlocks(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	nul := bytes.IndexByte(data, 0)
+	blank, width := blankLine(data)
+
+	switch {
+	case blank >= 0 && (nul < 0 || blank < nul):
+		return blank + width, data[:blank], nil
+	case nul >= 0:
+		return nul + 1, data[:nul], nil
+	case atEOF:
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// blankLine finds the first blank line, returning where the separator starts
+// and how many bytes it spans, or -1
+func blankLine(data []byte) (int, int) {
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\n' {
+			continue
+		}
+		j := i + 1
+		if j < len(data) && data[j] == '\r' {
+			j++
+		}
+		if j < len(data) && data[j] == '\n' {
+			return i, j + 1 - i
+		}
+	}
+	return -1, 0
+}
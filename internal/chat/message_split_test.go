@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessage(t *testing.T) {
+	testCases := []struct {
+		input    string
+		limit    int
+		expected []string
+	}{
+		{"", 10, nil},
+		{"short", 10, []string{"short"}},
+		{"hello world again", 11, []string{"hello world", "again"}},
+		{"hello world again", 8, []string{"hello", "world", "again"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"  padded   text  ", 6, []string{"padded", "text"}},
+		{"héllo wörld", 6, []string{"héllo", "wörld"}},
+	}
+
+	for _, tc := range testCases {
+		result := SplitMessage(tc.input, tc.limit)
+		if strings.Join(result, "|") != strings.Join(tc.expected, "|") || len(result) != len(tc.expected) {
+			t.Errorf("SplitMessage(%q, %d) = %q, expected %q", tc.input, tc.limit, result, tc.expected)
+		}
+	}
+}
+
+func TestSplitMessageRespectsLimit(t *testing.T) {
+	text := strings.Repeat("log line with some words ", 200)
+	for _, part := range SplitMessage(text, MaxMessageLength) {
+		if n := utf8.RuneCountInString(part); n > MaxMessageLength {
+			t.Errorf("Expected parts of at most %d characters, got %d", MaxMessageLength, n)
+		}
+	}
+}
+
+func TestScanBlocks(t *testing.T) {
+	input := "first line\nstill first\n\nsecond\r\n\r\nthird\x00fourth\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(ScanBlocks)
+
+	var blocks []string
+	for scanner.Scan() {
+		blocks = append(blocks, strings.TrimSpace(scanner.Text()))
+	}
+
+	expected := []string{"first line\nstill first", "second", "third", "fourth"}
+	if strings.Join(blocks, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected blocks %q, got %q", expected, blocks)
+	}
+}
@@ -0,0 +1,110 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+// maxUserResults caps the users SearchUsers returns
+const maxUserResults = 10
+
+// participantsResponse is the part of direct_v2/threads/get_by_participants/
+// we need. Thread is missing when you have never talked.
+type participantsResponse struct {
+	Thread *struct {
+		ThreadID string `json:"thread_id"`
+	} `json:"thread"`
+}
+
+// SearchUsers finds Instagram users to start a chat with
+func (dm *DirectMessages) SearchUsers(query string) ([]*goinsta.User, error) {
+	if dm.insta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	query = strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if query == "" {
+		return nil, nil
+	}
+
+	result, err := dm.insta.Searchbar.SearchUser(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for users: %v", err)
+	}
+
+	users := make([]*goinsta.User, 0, maxUserResults)
+	for _, user := range result.Users {
+		if len(users) == maxUserResults {
+			break
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// StartConversation opens the chat with a user, sending text first if given.
+// Without text the chat has to exist already, since Instagram only creates a
+// thread along with its first message. The chat comes back with its internal
+// ID, which it keeps from then on.
+func (dm *DirectMessages) StartConversation(username, text string) (*Chat, error) {
+	if dm.insta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	users, err := dm.lookupUsers([]string{username})
+	if err != nil {
+		return nil, err
+	}
+	user := users[0]
+
+	threadID, err := dm.threadWith(user)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(text) == "" {
+		if threadID == "" {
+			return nil, fmt.Errorf("you haven't talked with %s yet; send a first message to start the chat", user.Username)
+		}
+		return dm.findChat(threadID)
+	}
+
+	if threadID != "" {
+		chat, err := dm.findChat(threadID)
+		if err != nil {
+			return nil, err
+		}
+		return chat, dm.sendThroughOutbox(chat, text)
+	}
+
+	conv, err := dm.insta.Inbox.New(user, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start chat with %s: %v", user.Username, err)
+	}
+	return dm.findChat(conv.ID)
+}
+
+// threadWith returns the ID of your one-to-one thread with user, or "" if
+// there isn't one
+func (dm *DirectMessages) threadWith(user *goinsta.User) (string, error) {
+	query := url.Values{}
+	query.Set("recipient_users", userIDList([]*goinsta.User{user}))
+
+	body, err := dm.client.PrivateGet("direct_v2/threads/get_by_participants/", query)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up chat with %s: %v", user.Username, err)
+	}
+
+	var resp participantsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse chat with %s: %v", user.Username, err)
+	}
+	if resp.Thread == nil {
+		return "", nil
+	}
+	return resp.Thread.ThreadID, nil
+}
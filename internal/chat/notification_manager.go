@@ -2,36 +2,50 @@ package chat
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/notify"
 )
 
 // NotificationManager handles background message notifications
 type NotificationManager struct {
-	dm             *DirectMessages
-	lastMessageIDs map[string]string
-	lastCheckTimes map[string]time.Time
-	mutex          sync.Mutex
-	stopChan       chan bool
-	isRunning      bool
-	checkInterval  time.Duration
-	isPaused       bool
-	pauseMutex     sync.RWMutex
+	dm            *DirectMessages
+	threads       map[string]threadState // by thread ID
+	since         time.Time              // messages before this never notify
+	lastSync      time.Time
+	mutex         sync.Mutex
+	stopChan      chan bool
+	isRunning     bool
+	checkInterval time.Duration
+	isPaused      bool
+	pauseMutex    sync.RWMutex
+	handler       func(*Chat, *Message)
+	rules         *notify.Engine
+	digest        notify.Digest
+	sinks         map[string]notify.Notifier // configured sinks by name
+	notifiers     map[string]notify.Notifier // added with AddNotifier, always sent to
+	sinkErrors    map[string]string          // last failure of each sink
+	sinkMutex     sync.Mutex
+	terminal      *notify.SwitchWriter // where the terminal sink writes
 }
 
 // NewNotificationManager creates a new notification manager
 func NewNotificationManager(dm *DirectMessages) *NotificationManager {
 	return &NotificationManager{
-		dm:             dm,
-		lastMessageIDs: make(map[string]string),
-		lastCheckTimes: make(map[string]time.Time),
-		stopChan:       make(chan bool),
-		checkInterval:  5 * time.Second,
-		isPaused:       false,
+		dm:            dm,
+		threads:       make(map[string]threadState),
+		notifiers:     make(map[string]notify.Notifier),
+		sinkErrors:    make(map[string]string),
+		terminal:      notify.NewSwitchWriter(os.Stdout),
+		stopChan:      make(chan bool),
+		checkInterval: 5 * time.Second,
+		isPaused:      false,
 	}
 }
 
@@ -48,9 +62,14 @@ func (nm *NotificationManager) Start() error {
 		return fmt.Errorf("not logged in")
 	}
 
+	rules, sinks, err := nm.loadConfig()
+	if err != nil {
+		return err
+	}
+	nm.rules, nm.sinks = rules, sinks
+
 	nm.isRunning = true
-	// Initialize last check times
-	nm.initializeLastCheckTimes()
+	nm.snapshot()
 
 	// Start background monitoring
 	go nm.backgroundMonitor()
@@ -89,35 +108,53 @@ func (nm *NotificationManager) IsPaused() bool {
 	return nm.isPaused
 }
 
-// initializeLastCheckTimes sets up initial state for all chats
-func (nm *NotificationManager) initializeLastCheckTimes() {
-	chats, err := nm.dm.GetChats()
-	if err != nil {
+// threadState is what the last inbox sync showed of a thread
+type threadState struct {
+	lastActivity int64     // the thread's LastActivityAt
+	lastItemID   string    // newest item
+	lastItemTime time.Time // and its time
+}
+
+// snapshot records the state of every thread in one inbox sync, so only
+// messages after it notify
+func (nm *NotificationManager) snapshot() {
+	nm.threads = make(map[string]threadState)
+	nm.since = time.Now()
+	if err := nm.dm.syncInbox(); err != nil {
 		return
 	}
+	nm.lastSync = time.Now()
+	nm.newItems(nm.dm.insta.Inbox.Conversations)
+}
 
-	now := time.Now()
-	for _, chat := range chats {
-		nm.lastCheckTimes[chat.InternalID] = now
-		// Get the last message ID if available
-		messages, err := nm.dm.GetChatHistory(chat.InternalID, 1)
-		if err == nil && len(messages) > 0 {
-			nm.lastMessageIDs[chat.InternalID] = messages[0].ID
-		}
+// loadConfig reads the notification rules and sinks from the config file
+func (nm *NotificationManager) loadConfig() (*notify.Engine, map[string]notify.Notifier, error) {
+	cfg, err := notify.LoadConfig()
+	if err != nil {
+		return nil, nil, err
 	}
+	rules, err := notify.NewEngine(cfg, nm.dm.client.GetUsername())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid notifications config: %v", err)
+	}
+	sinks, err := notify.NewNotifiers(cfg, rules.SinkNames(), nm.terminal)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid notifications config: %v", err)
+	}
+	return rules, sinks, nil
 }
 
-// Refresh initializes the notification system with current chat state
+// Refresh initializes the notification system with current chat state and
+// reloads the notification rules. The old rules stay if the new ones are invalid.
 func (nm *NotificationManager) Refresh() {
 	nm.mutex.Lock()
 	defer nm.mutex.Unlock()
 
-	// Clear existing state
-	nm.lastMessageIDs = make(map[string]string)
-	nm.lastCheckTimes = make(map[string]time.Time)
+	if rules, sinks, err := nm.loadConfig(); err == nil {
+		nm.rules, nm.sinks = rules, sinks
+	}
 
-	// Re-initialize
-	nm.initializeLastCheckTimes()
+	nm.snapshot()
 }
 
 // backgroundMonitor runs in the background and checks for new messages
@@ -135,7 +172,9 @@ func (nm *NotificationManager) backgroundMonitor() {
 	}
 }
 
-// checkForNewMessages checks all chats for new messages
+// checkForNewMessages syncs the inbox once and notifies for the messages
+// that arrived in any thread since the last sync. A thread with new messages
+// moves to the top of the inbox, so the first page covers every chat.
 func (nm *NotificationManager) checkForNewMessages() {
 	nm.mutex.Lock()
 	defer nm.mutex.Unlock()
@@ -144,108 +183,237 @@ func (nm *NotificationManager) checkForNewMessages() {
 		return
 	}
 
-	// Check if notifications are paused
-	if nm.IsPaused() {
+	if err := nm.dm.syncInbox(); err != nil {
 		return
 	}
+	nm.lastSync = time.Now()
+	conversations := nm.dm.insta.Inbox.Conversations
 
-	chats, err := nm.dm.GetChats()
-	if err != nil {
+	// Keep track of threads while paused, so their messages don't notify
+	// on resume
+	fresh := nm.newItems(conversations)
+	if nm.IsPaused() {
+		return
+	}
+	if len(fresh) == 0 && nm.digest.Len() == 0 {
 		return
 	}
 
+	chats := nm.dm.chatsFrom(conversations, 0)
 	for _, chat := range chats {
-		nm.checkChatForNewMessages(chat)
+		if items, ok := fresh[chat.ID]; ok {
+			nm.notifyItems(chat, items)
+		}
 	}
+	nm.flushDigest(chats)
 }
 
-// checkChatForNewMessages checks a specific chat for new messages
-func (nm *NotificationManager) checkChatForNewMessages(chat *Chat) {
-	// Sync inbox to get latest data
-	if err := nm.dm.insta.Inbox.Sync(); err != nil {
-		return
+// newItems updates the thread states from conversations and returns the
+// items other people sent since the last sync, oldest first, by thread ID.
+// Threads whose last activity hasn't changed are skipped without looking at
+// their items.
+func (nm *NotificationManager) newItems(conversations []*goinsta.Conversation) map[string][]*goinsta.InboxItem {
+	currentUserID, _ := strconv.ParseInt(nm.dm.currentUserID, 10, 64)
+	fresh := make(map[string][]*goinsta.InboxItem)
+
+	for _, conv := range conversations {
+		state, known := nm.threads[conv.ID]
+		if known && conv.LastActivityAt == state.lastActivity {
+			continue
+		}
+
+		since := nm.since
+		if known && !state.lastItemTime.IsZero() {
+			since = state.lastItemTime
+		}
+
+		// Items are newest first
+		var items []*goinsta.InboxItem
+		for _, item := range conv.Items {
+			if item.ID == state.lastItemID || !itemTime(item.Timestamp).After(since) {
+				break
+			}
+			if item.UserID != currentUserID {
+				items = append(items, item)
+			}
+		}
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+		if len(items) > 0 {
+			fresh[conv.ID] = items
+		}
+
+		state.lastActivity = conv.LastActivityAt
+		if len(conv.Items) > 0 {
+			state.lastItemID = conv.Items[0].ID
+			state.lastItemTime = itemTime(conv.Items[0].Timestamp)
+		}
+		nm.threads[conv.ID] = state
 	}
+	return fresh
+}
 
-	// Find the conversation
-	var conversation *goinsta.Conversation
-	for _, conv := range nm.dm.insta.Inbox.Conversations {
-		if conv.ID == chat.ID {
-			conversation = conv
-			break
+// notifyItems turns new inbox items into messages and notifies for each
+func (nm *NotificationManager) notifyItems(chat *Chat, items []*goinsta.InboxItem) {
+	messages := make([]*Message, len(items))
+	for i, item := range items {
+		msg := newMessageFromItem(item)
+		msg.Sender = "Unknown User"
+		for _, user := range chat.Users {
+			if user.ID == item.UserID {
+				msg.Sender, msg.Username = user.FullName, user.Username
+				if msg.Sender == "" {
+					msg.Sender = user.Username
+				}
+				break
+			}
 		}
+		messages[i] = msg
 	}
 
-	if conversation == nil {
-		return
+	nm.dm.attachLocalMedia(chat.ID, messages, nm.dm.shouldAutoDownload(chat.ID))
+	for _, msg := range messages {
+		nm.notify(chat, msg)
 	}
+}
 
-	// Get latest items
-	if err := conversation.GetItems(); err != nil {
+// SetHandler sets where the app shows notifications, such as above the shell
+// prompt. Without a handler they only go to sinks.
+func (nm *NotificationManager) SetHandler(handler func(*Chat, *Message)) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+	nm.handler = handler
+}
+
+// notify runs a new message through the notification rules
+func (nm *NotificationManager) notify(chat *Chat, msg *Message) {
+	if chat.MutedLocally {
+		return
+	}
+	if nm.rules == nil {
+		nm.displayNotification(chat, msg, notify.Decision{Action: notify.Notify})
 		return
 	}
 
-	lastMessageID := nm.lastMessageIDs[chat.InternalID]
-	lastCheckTime := nm.lastCheckTimes[chat.InternalID]
+	candidate := notify.Message{
+		ChatID:    chat.InternalID,
+		ThreadID:  chat.ID,
+		ChatTitle: chat.Title,
+		Sender:    msg.Sender,
+		Username:  msg.Username,
+		Text:      msg.Text,
+	}
+	decision := nm.rules.Evaluate(candidate, time.Now())
+	switch decision.Action {
+	case notify.Notify:
+		nm.displayNotification(chat, msg, decision)
+	case notify.Defer:
+		if candidate.Text == "" {
+			candidate.Text = msg.AttachmentLabel()
+		}
+		nm.digest.Add(candidate)
+	}
+}
 
-	// Check for new messages (messages newer than our last check)
-	if len(conversation.Items) > 0 {
-		latestItem := conversation.Items[0]
-		latestTime := time.Unix(latestItem.Timestamp, 0)
+// flushDigest sends one notification per chat for the messages held back
+// during quiet hours, once they're over
+func (nm *NotificationManager) flushDigest(chats []*Chat) {
+	if nm.rules == nil || nm.rules.Quiet(time.Now()) || nm.digest.Len() == 0 {
+		return
+	}
 
-		// Skip if this is the same message we've already seen
-		if latestItem.ID == lastMessageID {
-			return
-		}
+	byID := make(map[string]*Chat, len(chats))
+	for _, chat := range chats {
+		byID[chat.InternalID] = chat
+	}
 
-		// Skip messages sent by current user
-		currentUserIDInt, _ := strconv.ParseInt(nm.dm.currentUserID, 10, 64)
-		if latestItem.UserID == currentUserIDInt {
-			return
+	for _, entry := range nm.digest.Take() {
+		latest := entry.Latest
+		chat, ok := byID[latest.ChatID]
+		if !ok {
+			chat = &Chat{ID: latest.ThreadID, InternalID: latest.ChatID, Title: latest.ChatTitle}
 		}
 
-		// Only show messages that are genuinely new (within last 30 seconds of last check)
-		// This prevents showing old messages that might be loaded from cache
-		timeSinceLastCheck := time.Since(lastCheckTime)
-		if latestTime.After(lastCheckTime) && timeSinceLastCheck < 30*time.Second {
-			// Create message object
-			msg := &Message{
-				ID:        latestItem.ID,
-				Text:      latestItem.Text,
-				Timestamp: latestTime,
-				Type:      "text",
-			}
-
-			// Determine sender name from conversation users
-			var senderName string
-			for _, user := range conversation.Users {
-				if user.ID == latestItem.UserID {
-					if user.FullName != "" {
-						senderName = user.FullName
-					} else {
-						senderName = user.Username
-					}
-					break
-				}
-			}
+		text := fmt.Sprintf("Latest from %s: %s", latest.Sender, latest.Text)
+		if entry.Count > 1 {
+			text = fmt.Sprintf("%d messages during quiet hours. %s", entry.Count, text)
+		} else {
+			text = "During quiet hours. " + text
+		}
+		nm.displayNotification(chat, &Message{
+			Text:      text,
+			Sender:    latest.Sender,
+			Username:  latest.Username,
+			Timestamp: time.Now(),
+			Type:      MessageTypeSystem,
+		}, notify.Decision{Action: notify.Notify, Sinks: nm.rules.DefaultSinks()})
+	}
+}
 
-			if senderName == "" {
-				senderName = "Unknown User"
-			}
-			msg.Sender = senderName
+// displayNotification shows a notification for a new message in the app,
+// through the handler, and sends it to the decision's sinks and every added
+// notifier in the background
+func (nm *NotificationManager) displayNotification(chat *Chat, msg *Message, decision notify.Decision) {
+	if nm.handler != nil {
+		nm.handler(chat, msg)
+	}
 
-			nm.displayNotification(chat, msg)
+	targets := make(map[string]notify.Notifier, len(decision.Sinks)+len(nm.notifiers))
+	for _, name := range decision.Sinks {
+		if sink, ok := nm.sinks[name]; ok {
+			targets[name] = sink
 		}
 	}
+	for name, notifier := range nm.notifiers {
+		targets[name] = notifier
+	}
+	if len(targets) == 0 {
+		return
+	}
 
-	// Update tracking info
-	if len(conversation.Items) > 0 {
-		nm.lastMessageIDs[chat.InternalID] = conversation.Items[0].ID
+	n := notify.Notification{
+		ChatID:      chat.InternalID,
+		ThreadID:    chat.ID,
+		ChatTitle:   chat.Title,
+		Sender:      msg.Sender,
+		Username:    msg.Username,
+		Text:        msg.DisplayText(),
+		Time:        msg.Timestamp,
+		UnreadCount: chat.UnreadCount,
+		Rule:        decision.Rule,
 	}
-	nm.lastCheckTimes[chat.InternalID] = time.Now()
+	go func() {
+		errs := notify.Deliver(targets, n)
+
+		nm.sinkMutex.Lock()
+		defer nm.sinkMutex.Unlock()
+		for name := range targets {
+			if err, ok := errs[name]; ok {
+				nm.sinkErrors[name] = err.Error()
+			} else {
+				delete(nm.sinkErrors, name)
+			}
+		}
+	}()
 }
 
-// displayNotification shows a notification for a new message
-func (nm *NotificationManager) displayNotification(chat *Chat, msg *Message) {
+// SetTerminalOutput changes where the terminal sink writes its bell and
+// title, nil turning it off, and returns the previous writer
+func (nm *NotificationManager) SetTerminalOutput(out io.Writer) io.Writer {
+	return nm.terminal.Set(out)
+}
+
+// AddNotifier sends every notification to notifier as well, whatever the
+// rules say. Adding a notifier under the same name replaces it.
+func (nm *NotificationManager) AddNotifier(name string, notifier notify.Notifier) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+	nm.notifiers[name] = notifier
+}
+
+// FormatNotification renders the notification banner for a new message
+func FormatNotification(chat *Chat, msg *Message) string {
 	// Get sender display name
 	senderDisplay := msg.Sender
 	if senderDisplay == "Unknown User" {
@@ -259,20 +427,21 @@ func (nm *NotificationManager) displayNotification(chat *Chat, msg *Message) {
 	}
 
 	// Truncate message for preview
-	preview := msg.Text
+	preview := msg.DisplayText()
 	if len(preview) > 50 {
 		preview = preview[:47] + "..."
 	}
 
 	// Display notification with timestamp - make it stand out
+	var b strings.Builder
 	timeStr := msg.Timestamp.Format("15:04")
-	fmt.Printf("\n" + strings.Repeat("─", 60) + "\n")
-	fmt.Printf("🔔 [%s] New message from %s in %s\n",
+	b.WriteString("\n" + strings.Repeat("─", 60) + "\n")
+	fmt.Fprintf(&b, "🔔 [%s] New message from %s in %s\n",
 		timeStr, senderDisplay, chat.Title)
-	fmt.Printf("💬 %s\n", preview)
-	fmt.Printf("💬 Use 'chat %s' to open this conversation\n", chat.InternalID)
-	fmt.Printf(strings.Repeat("─", 60) + "\n")
-	fmt.Print("ig-cli> ")
+	fmt.Fprintf(&b, "💬 %s\n", preview)
+	fmt.Fprintf(&b, "💬 Use 'chat %s' to open this conversation\n", chat.InternalID)
+	b.WriteString(strings.Repeat("─", 60) + "\n")
+	return b.String()
 }
 
 // GetDebugInfo returns debug information about the notification system
@@ -284,12 +453,26 @@ func (nm *NotificationManager) GetDebugInfo() map[string]interface{} {
 	info["isRunning"] = nm.isRunning
 	info["isPaused"] = nm.IsPaused()
 	info["checkInterval"] = nm.checkInterval
-	info["lastMessageIDs"] = nm.lastMessageIDs
-	info["lastCheckTimes"] = nm.lastCheckTimes
+	info["trackedThreads"] = len(nm.threads)
+	info["lastSync"] = nm.lastSync
+	info["digestPending"] = nm.digest.Len()
+	info["sinkErrors"] = nm.SinkErrors()
 
 	return info
 }
 
+// SinkErrors returns the last error of each sink that failed the last time
+func (nm *NotificationManager) SinkErrors() map[string]string {
+	nm.sinkMutex.Lock()
+	defer nm.sinkMutex.Unlock()
+
+	errs := make(map[string]string, len(nm.sinkErrors))
+	for name, err := range nm.sinkErrors {
+		errs[name] = err
+	}
+	return errs
+}
+
 // IsRunning returns whether the notification manager is active
 func (nm *NotificationManager) IsRunning() bool {
 	nm.mutex.Lock()
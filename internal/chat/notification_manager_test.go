@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+func itemAt(id string, userID int64, t time.Time) *goinsta.InboxItem {
+	return &goinsta.InboxItem{ID: id, UserID: userID, Timestamp: t.UnixMicro()}
+}
+
+func itemIDs(items []*goinsta.InboxItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestNewItems(t *testing.T) {
+	start := time.Now()
+	nm := NewNotificationManager(&DirectMessages{currentUserID: "1"})
+	nm.since = start
+
+	// Messages from before the start never notify
+	old := &goinsta.Conversation{ID: "t1", LastActivityAt: 100, Items: []*goinsta.InboxItem{itemAt("a", 2, start.Add(-time.Minute))}}
+	quiet := &goinsta.Conversation{ID: "t2", LastActivityAt: 50, Items: []*goinsta.InboxItem{itemAt("x", 3, start.Add(-time.Hour))}}
+	if fresh := nm.newItems([]*goinsta.Conversation{old, quiet}); len(fresh) != 0 {
+		t.Fatalf("Expected nothing new at first, got %v", fresh)
+	}
+
+	// New items in t1, oldest first and without our own; nothing in t2
+	old.LastActivityAt = 200
+	old.Items = append([]*goinsta.InboxItem{
+		itemAt("d", 2, start.Add(3*time.Second)),
+		itemAt("c", 1, start.Add(2*time.Second)),
+		itemAt("b", 2, start.Add(time.Second)),
+	}, old.Items...)
+	// A thread beyond the first few, seen for the first time
+	added := &goinsta.Conversation{ID: "t9", LastActivityAt: 300, Items: []*goinsta.InboxItem{itemAt("z", 4, start.Add(time.Second))}}
+
+	fresh := nm.newItems([]*goinsta.Conversation{old, quiet, added})
+	if len(fresh) != 2 || !reflect.DeepEqual(itemIDs(fresh["t1"]), []string{"b", "d"}) || !reflect.DeepEqual(itemIDs(fresh["t9"]), []string{"z"}) {
+		t.Errorf("Unexpected new items %v / %v", itemIDs(fresh["t1"]), itemIDs(fresh["t9"]))
+	}
+
+	// Nothing changed since
+	if fresh := nm.newItems([]*goinsta.Conversation{old, quiet, added}); len(fresh) != 0 {
+		t.Errorf("Expected no repeats, got %v", fresh)
+	}
+
+	// Activity without new items, like a reaction
+	old.LastActivityAt = 250
+	if fresh := nm.newItems([]*goinsta.Conversation{old}); len(fresh) != 0 {
+		t.Errorf("Expected nothing new for a reaction, got %v", fresh)
+	}
+}
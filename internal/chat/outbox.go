@@ -0,0 +1,237 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/client"
+	"github.com/abhi-praj/GoGram/internal/outbox"
+)
+
+// QueuedError is returned when a message couldn't be sent right away and was
+// left in the outbox
+type QueuedError struct {
+	Item *outbox.Item
+	Err  error
+}
+
+func (e *QueuedError) Error() string {
+	if e.Retrying() {
+		return fmt.Sprintf("not sent yet, queued as %s and will retry: %v", e.Item.ID, e.Err)
+	}
+	return fmt.Sprintf("failed to send message, kept in the outbox as %s: %v", e.Item.ID, e.Err)
+}
+
+func (e *QueuedError) Unwrap() error {
+	return e.Err
+}
+
+// Retrying reports whether the outbox will keep trying on its own
+func (e *QueuedError) Retrying() bool {
+	return e.Item.Status == outbox.StatusPending
+}
+
+// errWaitingBehind is why a message was queued without trying to send it
+var errWaitingBehind = errors.New("earlier messages to this chat are still waiting")
+
+// newOutboxStore opens the account's outbox under advanced.users_dir
+func newOutboxStore(username string) *outbox.Store {
+	return outbox.NewStore(userFile(username, "outbox.json"))
+}
+
+// sendThroughOutbox records a message in the outbox and makes the first
+// attempt at sending it. If older messages to the chat are still waiting, it
+// is queued behind them instead, so the chat gets them in order.
+func (dm *DirectMessages) sendThroughOutbox(chat *Chat, text string) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+
+	if waiting, err := dm.outbox.Waiting(chat.ID); err == nil && waiting > 0 {
+		if item, err := dm.outbox.Queue(client.NewClientContext(), chat.ID, chat.Title, text); err == nil {
+			return &QueuedError{Item: item, Err: errWaitingBehind}
+		}
+	}
+
+	item, err := dm.outbox.Add(client.NewClientContext(), chat.ID, chat.Title, text)
+	if err != nil {
+		// Without the outbox there's nothing to retry from, but the
+		// message can still go out
+		return dm.SendMessage(chat.ID, text)
+	}
+
+	sendErr := dm.sendQueued(item)
+	finished, err := dm.outbox.Finish(item.ID, sendErr, client.IsTemporary(sendErr))
+	if err != nil {
+		if sendErr != nil {
+			return fmt.Errorf("failed to send message: %v", sendErr)
+		}
+		return nil
+	}
+	if sendErr != nil {
+		return &QueuedError{Item: finished, Err: sendErr}
+	}
+	return nil
+}
+
+// sendQueued sends an outbox item, using its key as the client context so
+// Instagram ignores repeats of an attempt that actually got through
+func (dm *DirectMessages) sendQueued(item *outbox.Item) error {
+	return dm.sendText(item.ChatID, item.Text, item.Key)
+}
+
+// sendText sends text to a thread with key as its client context
+func (dm *DirectMessages) sendText(threadID, text, key string) error {
+	form := url.Values{}
+	form.Set("text", text)
+	form.Set("client_context", key)
+	return dm.broadcast(threadID, "text", form)
+}
+
+// PendingMessages returns the messages to a chat still waiting in the outbox,
+// oldest first. chatID may be an internal ID or a thread ID.
+func (dm *DirectMessages) PendingMessages(chatID string) []*Message {
+	threadID := chatID
+	if chat, err := dm.cachedChat(chatID); err == nil {
+		threadID = chat.ID
+	}
+
+	items, err := dm.outbox.List()
+	if err != nil {
+		return nil
+	}
+
+	var messages []*Message
+	for _, item := range items {
+		if item.ChatID != threadID || item.Status == outbox.StatusSent {
+			continue
+		}
+		messages = append(messages, pendingMessage(item))
+	}
+	return messages
+}
+
+// pendingMessage shows an outbox item as one of your messages
+func pendingMessage(item *outbox.Item) *Message {
+	return &Message{
+		ID:        item.ID,
+		Text:      item.Text,
+		Sender:    "You",
+		Timestamp: item.CreatedAt,
+		Type:      MessageTypeText,
+		Pending:   true,
+	}
+}
+
+// ListOutbox returns queued messages, oldest first. Delivered ones are only
+// included when all is true.
+func (dm *DirectMessages) ListOutbox(all bool) ([]*outbox.Item, error) {
+	items, err := dm.outbox.List()
+	if err != nil || all {
+		return items, err
+	}
+
+	var open []*outbox.Item
+	for _, item := range items {
+		if item.Status != outbox.StatusSent {
+			open = append(open, item)
+		}
+	}
+	return open, nil
+}
+
+// RetryOutbox makes a queued message due for sending now
+func (dm *DirectMessages) RetryOutbox(id string) (*outbox.Item, error) {
+	return dm.outbox.Retry(id)
+}
+
+// RetryAllOutbox makes every queued message due for sending now
+func (dm *DirectMessages) RetryAllOutbox() ([]*outbox.Item, error) {
+	return dm.outbox.RetryAll()
+}
+
+// DropOutbox removes a queued message so it is never sent
+func (dm *DirectMessages) DropOutbox(id string) (*outbox.Item, error) {
+	return dm.outbox.Drop(id)
+}
+
+// FlushOutbox attempts every queued message that is due, in this process
+func (dm *DirectMessages) FlushOutbox(report func(*outbox.Item)) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+	return dm.newOutboxWorker(report).RunOnce(time.Now())
+}
+
+// StartOutbox retries queued messages in the background. report hears about
+// each one that is finally delivered or given up on.
+func (dm *DirectMessages) StartOutbox(report func(*outbox.Item)) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+
+	dm.outboxMutex.Lock()
+	defer dm.outboxMutex.Unlock()
+
+	if dm.outboxWorker == nil {
+		dm.outboxWorker = dm.newOutboxWorker(report)
+	}
+	dm.outboxWorker.Start()
+	return nil
+}
+
+// StopOutbox stops retrying queued messages from this process
+func (dm *DirectMessages) StopOutbox() {
+	dm.outboxMutex.Lock()
+	defer dm.outboxMutex.Unlock()
+
+	if dm.outboxWorker != nil {
+		dm.outboxWorker.Stop()
+	}
+}
+
+// IsOutboxRunning reports whether this process is retrying queued messages
+func (dm *DirectMessages) IsOutboxRunning() bool {
+	dm.outboxMutex.Lock()
+	defer dm.outboxMutex.Unlock()
+	return dm.outboxWorker != nil && dm.outboxWorker.IsRunning()
+}
+
+func (dm *DirectMessages) newOutboxWorker(report func(*outbox.Item)) *outbox.Worker {
+	return outbox.NewWorker(dm.outbox, dm.sendQueued, client.IsTemporary, report)
+}
+
+// QueueMessageByInternalID adds a message to the outbox without trying to
+// send it, so it goes out after the ones already waiting for that chat
+func (dm *DirectMessages) QueueMessageByInternalID(internalID, message string) (*outbox.Item, error) {
+	chat, err := dm.outboxChat(internalID)
+	if err != nil {
+		return nil, err
+	}
+	return dm.outbox.Queue(client.NewClientContext(), chat.ID, chat.Title, message)
+}
+
+// outboxChat looks a chat up for sending, falling back to the last inbox
+// sync while offline since that is enough to queue a message
+func (dm *DirectMessages) outboxChat(internalID string) (*Chat, error) {
+	chat, err := dm.GetChatByInternalID(internalID)
+	if err != nil && client.IsTemporary(err) {
+		chat, err = dm.cachedChat(internalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chat with internal ID %s not found: %v", internalID, err)
+	}
+	return chat, nil
+}
+
+// cachedChat finds a chat from the last inbox sync by internal ID
+func (dm *DirectMessages) cachedChat(internalID string) (*Chat, error) {
+	for _, chat := range dm.CachedChats() {
+		if chat.InternalID == internalID {
+			return chat, nil
+		}
+	}
+	return nil, fmt.Errorf("chat with internal ID %s not found", internalID)
+}
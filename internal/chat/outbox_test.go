@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/abhi-praj/GoGram/internal/outbox"
+)
+
+func TestSendQueuesBehindWaitingMessages(t *testing.T) {
+	post := &stubPost{body: `{"status": "ok"}`}
+	dm := stubInbox(t, post)
+	dm.outbox = outbox.NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+	target := &Chat{ID: "t1", Title: "Alice"}
+
+	if err := dm.sendThroughOutbox(target, "first"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if len(post.forms) != 1 {
+		t.Fatalf("Expected the first message to be sent straight away, got %d sends", len(post.forms))
+	}
+
+	// An older message is still waiting, so the next one goes behind it
+	if _, err := dm.outbox.Queue("key-1", "t1", "Alice", "queued"); err != nil {
+		t.Fatal(err)
+	}
+	err := dm.sendThroughOutbox(target, "second")
+	var queued *QueuedError
+	if !errors.As(err, &queued) || !queued.Retrying() || queued.Item.Text != "second" {
+		t.Fatalf("Expected the message to be queued, got %v", err)
+	}
+	if len(post.forms) != 1 {
+		t.Errorf("Expected nothing else to be sent yet, got %d sends", len(post.forms))
+	}
+
+	if err := dm.FlushOutbox(nil); err != nil {
+		t.Fatalf("FlushOutbox failed: %v", err)
+	}
+	if len(post.forms) != 3 || post.forms[1].Get("text") != "queued" || post.forms[2].Get("text") != "second" {
+		t.Errorf("Expected the queued messages to go out in order, got %v", post.forms)
+	}
+}
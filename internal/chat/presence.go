@@ -0,0 +1,268 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/client"
+)
+
+const (
+	// typingResend is how often "still typing" is repeated; Instagram drops
+	// the indicator after a few seconds without one
+	typingResend = 5 * time.Second
+	// presenceInterval is how often open chats poll for seen receipts
+	presenceInterval = 10 * time.Second
+)
+
+// Presence is what the other people in a chat have done with your messages.
+// Typing isn't included: Instagram only reports it over its realtime
+// channel, which GoGram doesn't use, so typing indicators are only sent.
+type Presence struct {
+	SeenBy []string // names of people who have seen your latest message
+}
+
+// String renders presence for status lines, e.g. "Seen by Alice, Bob"
+func (p Presence) String() string {
+	if len(p.SeenBy) > 0 {
+		return "Seen by " + strings.Join(p.SeenBy, ", ")
+	}
+	return ""
+}
+
+// microTimestamp is a microsecond timestamp that Instagram sends as either a
+// number or a string
+type microTimestamp int64
+
+func (t *microTimestamp) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*t = microTimestamp(value)
+	return nil
+}
+
+// threadSeenResponse is the part of direct_v2/threads/<id>/ that says who has
+// read what
+type threadSeenResponse struct {
+	Thread struct {
+		Items []struct {
+			ID        string         `json:"item_id"`
+			UserID    int64          `json:"user_id"`
+			Timestamp microTimestamp `json:"timestamp"`
+		} `json:"items"`
+		LastSeenAt map[string]struct {
+			ItemID    string         `json:"item_id"`
+			Timestamp microTimestamp `json:"timestamp"`
+		} `json:"last_seen_at"`
+	} `json:"thread"`
+}
+
+// parseSeenBy returns the IDs of the other users who have seen the newest
+// message, sorted. Like Instagram, it only reports this when the newest
+// message is yours.
+func parseSeenBy(body []byte, currentUserID string) ([]string, error) {
+	var resp threadSeenResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse thread: %v", err)
+	}
+
+	if len(resp.Thread.Items) == 0 {
+		return nil, nil
+	}
+	newest := resp.Thread.Items[0]
+	if strconv.FormatInt(newest.UserID, 10) != currentUserID {
+		return nil, nil
+	}
+
+	var seenBy []string
+	for userID, seen := range resp.Thread.LastSeenAt {
+		if userID == currentUserID {
+			continue
+		}
+		if seen.ItemID == newest.ID || seen.Timestamp >= newest.Timestamp {
+			seenBy = append(seenBy, userID)
+		}
+	}
+	sort.Strings(seenBy)
+	return seenBy, nil
+}
+
+// conversation returns the inbox conversation for a thread ID
+func (dm *DirectMessages) conversation(threadID string) *goinsta.Conversation {
+	for _, conv := range dm.insta.Inbox.Conversations {
+		if conv.ID == threadID {
+			return conv
+		}
+	}
+	return nil
+}
+
+// MarkAsSeen marks the newest message in a chat as seen. It does nothing in
+// invisible mode.
+func (dm *DirectMessages) MarkAsSeen(chatID string) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+	if client.InvisibleMode() {
+		return nil
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return err
+	}
+
+	conversation := dm.conversation(threadID)
+	if conversation == nil {
+		return fmt.Errorf("chat not found")
+	}
+
+	itemID := conversation.LastPermanentItem.ID
+	if len(conversation.Items) > 0 {
+		itemID = conversation.Items[0].ID
+	}
+	if itemID == "" {
+		return nil
+	}
+
+	token := client.NewClientContext()
+	_, err = dm.post(fmt.Sprintf("direct_v2/threads/%s/items/%s/seen/", threadID, itemID), url.Values{
+		"thread_id":            {threadID},
+		"action":               {"mark_seen"},
+		"client_context":       {token},
+		"offline_threading_id": {token},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark chat as seen: %v", err)
+	}
+	return nil
+}
+
+// SendTyping tells the chat you've started (or stopped) typing. It does
+// nothing in invisible mode.
+func (dm *DirectMessages) SendTyping(chatID string, active bool) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+	if client.InvisibleMode() {
+		return nil
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return err
+	}
+
+	status := "0"
+	if active {
+		status = "1"
+	}
+	form := url.Values{
+		"action":          {"indicate_activity"},
+		"activity_status": {status},
+	}
+	if err := dm.broadcast(threadID, "indicate_activity", form); err != nil {
+		return fmt.Errorf("failed to send typing indicator: %v", err)
+	}
+	return nil
+}
+
+// GetPresence fetches who has seen your latest message in a chat
+func (dm *DirectMessages) GetPresence(chatID string) (Presence, error) {
+	if dm.insta == nil {
+		return Presence{}, fmt.Errorf("not logged in")
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return Presence{}, err
+	}
+
+	body, err := dm.client.PrivateGet("direct_v2/threads/"+threadID+"/", url.Values{
+		"limit":                      {"1"},
+		"visual_message_return_type": {"unseen"},
+	})
+	if err != nil {
+		return Presence{}, fmt.Errorf("failed to load seen state: %v", err)
+	}
+
+	seenBy, err := parseSeenBy(body, dm.currentUserID)
+	if err != nil {
+		return Presence{}, err
+	}
+
+	names := dm.userNames(threadID)
+	var presence Presence
+	for _, userID := range seenBy {
+		presence.SeenBy = append(presence.SeenBy, displayName(names, userID))
+	}
+	return presence, nil
+}
+
+func displayName(names map[string]string, userID string) string {
+	if name := names[userID]; name != "" {
+		return name
+	}
+	return "Unknown User"
+}
+
+// TypingIndicator turns input box changes into throttled typing indicators
+type TypingIndicator struct {
+	send     func(chatID string, active bool) error
+	chatID   string
+	active   bool
+	lastSent time.Time
+	mutex    sync.Mutex
+}
+
+// NewTypingIndicator creates an indicator that reports through send
+func NewTypingIndicator(send func(chatID string, active bool) error) *TypingIndicator {
+	return &TypingIndicator{send: send}
+}
+
+// Update is called whenever the input changes. It sends "typing" when text
+// appears, repeats it every typingResend while there is text, and sends
+// "stopped" when the text is cleared or the chat changes.
+func (ti *TypingIndicator) Update(chatID string, hasText bool) {
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	var pending []typingEvent
+	if ti.active && ti.chatID != chatID {
+		pending = append(pending, typingEvent{ti.chatID, false})
+		ti.active = false
+	}
+	ti.chatID = chatID
+
+	switch {
+	case hasText && (!ti.active || time.Since(ti.lastSent) >= typingResend):
+		ti.active = true
+		ti.lastSent = time.Now()
+		pending = append(pending, typingEvent{chatID, true})
+	case !hasText && ti.active:
+		ti.active = false
+		pending = append(pending, typingEvent{chatID, false})
+	}
+
+	if len(pending) > 0 {
+		go func() {
+			for _, e := range pending {
+				ti.send(e.chatID, e.active)
+			}
+		}()
+	}
+}
+
+type typingEvent struct {
+	chatID string
+	active bool
+}
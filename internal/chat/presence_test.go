@@ -0,0 +1,98 @@
+package chat
+
+import (
+	"testing"
+	"time"
+)
+
+const threadWithSeen = `{
+  "thread": {
+    "items": [{"item_id": "m2", "user_id": 1, "timestamp": 1700000005000000}],
+    "last_seen_at": {
+      "1": {"item_id": "m2", "timestamp": "1700000005000000"},
+      "2": {"item_id": "m2", "timestamp": "1700000006000000"},
+      "3": {"item_id": "m1", "timestamp": "1700000007000000"},
+      "4": {"item_id": "m1", "timestamp": "1700000001000000"}
+    }
+  },
+  "status": "ok"
+}`
+
+func TestParseSeenBy(t *testing.T) {
+	seenBy, err := parseSeenBy([]byte(threadWithSeen), "1")
+	if err != nil {
+		t.Fatalf("parseSeenBy failed: %v", err)
+	}
+	if len(seenBy) != 2 || seenBy[0] != "2" || seenBy[1] != "3" {
+		t.Errorf("Expected users 2 and 3 (by item and by timestamp), got %v", seenBy)
+	}
+
+	seenBy, err = parseSeenBy([]byte(threadWithSeen), "2")
+	if err != nil || len(seenBy) != 0 {
+		t.Errorf("Expected no seen receipts when the newest message isn't yours, got %v (%v)", seenBy, err)
+	}
+
+	if _, err := parseSeenBy([]byte("not json"), "1"); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestPresenceString(t *testing.T) {
+	testCases := []struct {
+		presence Presence
+		expected string
+	}{
+		{Presence{}, ""},
+		{Presence{SeenBy: []string{"Alice", "Bob"}}, "Seen by Alice, Bob"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.presence.String(); got != tc.expected {
+			t.Errorf("%+v.String() = %q, expected %q", tc.presence, got, tc.expected)
+		}
+	}
+}
+
+func TestTypingIndicator(t *testing.T) {
+	events := make(chan typingEvent, 10)
+	ti := NewTypingIndicator(func(chatID string, active bool) error {
+		events <- typingEvent{chatID, active}
+		return nil
+	})
+
+	expect := func(chatID string, active bool) {
+		t.Helper()
+		select {
+		case e := <-events:
+			if e.chatID != chatID || e.active != active {
+				t.Errorf("Expected %s/%v, got %+v", chatID, active, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %s/%v to be sent", chatID, active)
+		}
+	}
+
+	ti.Update("a", true)
+	expect("a", true)
+
+	// Further keystrokes within typingResend aren't sent again
+	ti.Update("a", true)
+	ti.Update("a", true)
+
+	ti.Update("a", false)
+	expect("a", false)
+	ti.Update("a", false)
+
+	// Switching chats stops typing in the old one
+	ti.Update("a", true)
+	expect("a", true)
+	ti.Update("b", true)
+	expect("a", false)
+	expect("b", true)
+
+	select {
+	case e := <-events:
+		t.Errorf("Unexpected extra indicator %+v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,248 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+// likeEmoji is how the classic double-tap "like" reaction is shown
+const likeEmoji = "❤️"
+
+// Reaction is one person's emoji reaction to a message
+type Reaction struct {
+	Emoji     string
+	SenderID  string
+	Sender    string // "You" for the logged in user
+	Timestamp time.Time
+}
+
+// threadItemsResponse is the part of direct_v2/threads/<id>/ that goinsta's
+// InboxItem drops
+type threadItemsResponse struct {
+	Thread struct {
+		Items []struct {
+			ID        string `json:"item_id"`
+			Reactions *struct {
+				Likes []struct {
+					SenderID  int64 `json:"sender_id"`
+					Timestamp int64 `json:"timestamp"`
+				} `json:"likes"`
+				Emojis []struct {
+					SenderID  int64  `json:"sender_id"`
+					Timestamp int64  `json:"timestamp"`
+					Emoji     string `json:"emoji"`
+				} `json:"emojis"`
+			} `json:"reactions"`
+		} `json:"items"`
+	} `json:"thread"`
+}
+
+// parseReactions maps item IDs to their reactions. Sender is left empty.
+func parseReactions(body []byte) (map[string][]Reaction, error) {
+	var resp threadItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse thread: %v", err)
+	}
+
+	reactions := make(map[string][]Reaction)
+	for _, item := range resp.Thread.Items {
+		if item.Reactions == nil {
+			continue
+		}
+
+		for _, emoji := range item.Reactions.Emojis {
+			reactions[item.ID] = append(reactions[item.ID], Reaction{
+				Emoji:     emoji.Emoji,
+				SenderID:  strconv.FormatInt(emoji.SenderID, 10),
+				Timestamp: time.UnixMicro(emoji.Timestamp),
+			})
+		}
+
+		// Older clients send plain likes; skip senders who also have an emoji
+		for _, like := range item.Reactions.Likes {
+			senderID := strconv.FormatInt(like.SenderID, 10)
+			if !hasReactionFrom(reactions[item.ID], senderID) {
+				reactions[item.ID] = append(reactions[item.ID], Reaction{
+					Emoji:     likeEmoji,
+					SenderID:  senderID,
+					Timestamp: time.UnixMicro(like.Timestamp),
+				})
+			}
+		}
+	}
+	return reactions, nil
+}
+
+func hasReactionFrom(reactions []Reaction, senderID string) bool {
+	for _, r := range reactions {
+		if r.SenderID == senderID {
+			return true
+		}
+	}
+	return false
+}
+
+// ReactionSummary groups reactions by emoji in the order they first appear,
+// e.g. "❤️ 2  😂 1"
+func ReactionSummary(reactions []Reaction) string {
+	var order []string
+	counts := make(map[string]int)
+	for _, r := range reactions {
+		if counts[r.Emoji] == 0 {
+			order = append(order, r.Emoji)
+		}
+		counts[r.Emoji]++
+	}
+
+	parts := make([]string, len(order))
+	for i, emoji := range order {
+		parts[i] = fmt.Sprintf("%s %d", emoji, counts[emoji])
+	}
+	return strings.Join(parts, "  ")
+}
+
+// LoadReactions fills in Reactions for messages from a chat. goinsta doesn't
+// parse reactions, so this fetches the thread's raw items separately.
+func (dm *DirectMessages) LoadReactions(chatID string, messages []*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return err
+	}
+
+	body, err := dm.client.PrivateGet("direct_v2/threads/"+threadID+"/", url.Values{
+		"limit":                      {strconv.Itoa(len(messages))},
+		"visual_message_return_type": {"unseen"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load reactions: %v", err)
+	}
+
+	reactions, err := parseReactions(body)
+	if err != nil {
+		return err
+	}
+
+	names := dm.userNames(threadID)
+	for _, msg := range messages {
+		msg.Reactions = reactions[msg.ID]
+		for i := range msg.Reactions {
+			msg.Reactions[i].Sender = displayName(names, msg.Reactions[i].SenderID)
+		}
+	}
+	return nil
+}
+
+// userNames maps the user IDs in a thread to display names
+func (dm *DirectMessages) userNames(threadID string) map[string]string {
+	names := map[string]string{dm.currentUserID: "You"}
+
+	var conversation *goinsta.Conversation
+	for _, conv := range dm.insta.Inbox.Conversations {
+		if conv.ID == threadID {
+			conversation = conv
+			break
+		}
+	}
+	if conversation == nil {
+		return names
+	}
+
+	for _, user := range conversation.Users {
+		name := user.FullName
+		if name == "" {
+			name = user.Username
+		}
+		names[strconv.FormatInt(user.ID, 10)] = name
+	}
+	return names
+}
+
+// React sends an emoji reaction to a message, replacing any earlier reaction of yours
+func (dm *DirectMessages) React(chatID, messageID, emoji string) error {
+	if emoji == "" {
+		emoji = likeEmoji
+	}
+	return dm.sendReaction(chatID, messageID, emoji, "created")
+}
+
+// Unreact removes your reaction from a message
+func (dm *DirectMessages) Unreact(chatID, messageID string) error {
+	return dm.sendReaction(chatID, messageID, "", "deleted")
+}
+
+func (dm *DirectMessages) sendReaction(chatID, messageID, emoji, status string) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+
+	threadID, err := dm.threadID(chatID)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"item_type":       {"reaction"},
+		"reaction_type":   {"like"},
+		"node_type":       {"item"},
+		"item_id":         {messageID},
+		"reaction_status": {status},
+	}
+	if emoji != "" {
+		form.Set("emoji", emoji)
+	}
+
+	if err := dm.broadcast(threadID, "reaction", form); err != nil {
+		if status == "deleted" {
+			return fmt.Errorf("failed to remove reaction: %v", err)
+		}
+		return fmt.Errorf("failed to react: %v", err)
+	}
+	return nil
+}
+
+// ReactionTracker notices when the reactions on a chat's messages change
+type ReactionTracker struct {
+	seen map[string]string
+}
+
+// NewReactionTracker creates a tracker with no known messages
+func NewReactionTracker() *ReactionTracker {
+	return &ReactionTracker{seen: make(map[string]string)}
+}
+
+// Changed records the messages' reactions and returns the ones whose
+// reactions differ from the last call. Messages seen for the first time
+// aren't reported.
+func (rt *ReactionTracker) Changed(messages []*Message) []*Message {
+	var changed []*Message
+	for _, msg := range messages {
+		key := reactionKey(msg.Reactions)
+		previous, known := rt.seen[msg.ID]
+		if known && previous != key {
+			changed = append(changed, msg)
+		}
+		rt.seen[msg.ID] = key
+	}
+	return changed
+}
+
+// reactionKey identifies a set of reactions, ignoring order
+func reactionKey(reactions []Reaction) string {
+	keys := make([]string, len(reactions))
+	for i, r := range reactions {
+		keys[i] = r.SenderID + ":" + r.Emoji
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,82 @@
+package chat
+
+import "testing"
+
+const threadWithReactions = `{
+  "thread": {
+    "items": [
+      {
+        "item_id": "m2",
+        "reactions": {
+          "emojis": [
+            {"sender_id": 1, "timestamp": 1700000000000000, "emoji": "😂"},
+            {"sender_id": 2, "timestamp": 1700000001000000, "emoji": "😂"}
+          ],
+          "likes": [
+            {"sender_id": 2, "timestamp": 1700000001000000},
+            {"sender_id": 3, "timestamp": 1700000002000000}
+          ]
+        }
+      },
+      {"item_id": "m1"}
+    ]
+  },
+  "status": "ok"
+}`
+
+func TestParseReactions(t *testing.T) {
+	reactions, err := parseReactions([]byte(threadWithReactions))
+	if err != nil {
+		t.Fatalf("parseReactions failed: %v", err)
+	}
+
+	got := reactions["m2"]
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 reactions (a like from an emoji sender is dropped), got %+v", got)
+	}
+	if got[0].Emoji != "😂" || got[0].SenderID != "1" || got[0].Timestamp.Unix() != 1700000000 {
+		t.Errorf("Unexpected first reaction %+v", got[0])
+	}
+	if got[2].Emoji != likeEmoji || got[2].SenderID != "3" {
+		t.Errorf("Expected a plain like to show as a heart, got %+v", got[2])
+	}
+	if len(reactions["m1"]) != 0 {
+		t.Errorf("Expected no reactions on m1, got %+v", reactions["m1"])
+	}
+
+	if _, err := parseReactions([]byte("not json")); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestReactionSummary(t *testing.T) {
+	reactions := []Reaction{{Emoji: "😂"}, {Emoji: likeEmoji}, {Emoji: "😂"}}
+	if got := ReactionSummary(reactions); got != "😂 2  ❤️ 1" {
+		t.Errorf("ReactionSummary() = %q", got)
+	}
+	if got := ReactionSummary(nil); got != "" {
+		t.Errorf("Expected an empty summary, got %q", got)
+	}
+}
+
+func TestReactionTracker(t *testing.T) {
+	tracker := NewReactionTracker()
+	msg := &Message{ID: "m1"}
+
+	if changed := tracker.Changed([]*Message{msg}); len(changed) != 0 {
+		t.Error("Expected messages seen for the first time not to be reported")
+	}
+
+	msg.Reactions = []Reaction{{Emoji: "🔥", SenderID: "2"}}
+	if changed := tracker.Changed([]*Message{msg}); len(changed) != 1 {
+		t.Error("Expected an added reaction to be reported")
+	}
+	if changed := tracker.Changed([]*Message{msg}); len(changed) != 0 {
+		t.Error("Expected unchanged reactions not to be reported again")
+	}
+
+	msg.Reactions = nil
+	if changed := tracker.Changed([]*Message{msg}); len(changed) != 1 {
+		t.Error("Expected a removed reaction to be reported")
+	}
+}
@@ -0,0 +1,117 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// Record converts the chat into its output record
+func (c *Chat) Record() output.ChatRecord {
+	record := output.ChatRecord{
+		ID:           c.ID,
+		InternalID:   c.InternalID,
+		Title:        c.Title,
+		Users:        make([]output.UserRecord, 0, len(c.Users)),
+		LastMessage:  c.LastMessage,
+		LastActivity: output.TimePtr(c.LastActivity),
+		UnreadCount:  c.UnreadCount,
+		IsGroup:      c.IsGroup,
+		Muted:        c.Muted,
+		Pending:      c.Pending,
+		Pinned:       c.Pinned,
+		MutedLocally: c.MutedLocally,
+		Archived:     c.Archived,
+		Labels:       c.Labels,
+	}
+
+	for _, user := range c.Users {
+		record.Users = append(record.Users, output.UserRecord{
+			ID:            fmt.Sprintf("%d", user.ID),
+			Username:      user.Username,
+			FullName:      user.FullName,
+			ProfilePicURL: user.ProfilePicURL,
+			IsVerified:    user.IsVerified,
+		})
+	}
+
+	return record
+}
+
+// NotificationRecord converts a new message in the chat into its output record
+func (c *Chat) NotificationRecord(m *Message) output.NotificationRecord {
+	return output.NotificationRecord{
+		ChatID:         c.InternalID,
+		ChatTitle:      c.Title,
+		Sender:         m.Sender,
+		MessagePreview: m.DisplayText(),
+		Timestamp:      output.TimePtr(m.Timestamp),
+		UnreadCount:    c.UnreadCount,
+	}
+}
+
+// Record converts the message into its output record
+func (m *Message) Record(chatID string) output.MessageRecord {
+	record := output.MessageRecord{
+		ID:        m.ID,
+		Text:      m.Text,
+		Sender:    m.Sender,
+		Timestamp: output.TimePtr(m.Timestamp),
+		Type:      MessageTypeName(m.Type),
+		ChatID:    chatID,
+		Kind:      m.Type,
+	}
+
+	if a := m.Attachment; a != nil {
+		record.Attachment = &output.AttachmentRecord{
+			Kind:       a.Kind,
+			URL:        a.URL,
+			PreviewURL: a.PreviewURL,
+			Width:      a.Width,
+			Height:     a.Height,
+			DurationMs: a.Duration.Milliseconds(),
+			MediaID:    a.MediaID,
+			Code:       a.Code,
+			Owner:      a.Owner,
+			Caption:    a.Caption,
+			Title:      a.Title,
+			LocalPath:  a.LocalPath,
+		}
+	}
+
+	for _, r := range m.Reactions {
+		record.Reactions = append(record.Reactions, output.ReactionRecord{
+			Emoji:     r.Emoji,
+			SenderID:  r.SenderID,
+			Sender:    r.Sender,
+			Timestamp: output.TimePtr(r.Timestamp),
+		})
+	}
+
+	return record
+}
+
+// Record converts the import result into its output record
+func (t ImportedThread) Record() output.ImportRecord {
+	return output.ImportRecord{
+		ChatID:   t.ChatID,
+		Title:    t.Title,
+		Matched:  t.Matched,
+		Messages: t.Messages,
+		Added:    t.Added,
+	}
+}
+
+// MessageTypeName maps internal message types onto the proto MessageType names
+func MessageTypeName(messageType string) string {
+	messageType = strings.ToLower(messageType)
+	switch {
+	case messageType == "media" || IsMediaType(messageType):
+		return "MEDIA"
+	case messageType == MessageTypeSystem:
+		return "SYSTEM"
+	default:
+		return "TEXT"
+	}
+}
@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+func TestChatRecordJSONMatchesProtoFields(t *testing.T) {
+	record := (&Chat{
+		ID:           "340282366841710300949128",
+		InternalID:   "100000",
+		Title:        "Team",
+		Users:        []*goinsta.User{{ID: 1234, Username: "alice"}},
+		LastActivity: time.Unix(1700000000, 0),
+		IsGroup:      true,
+	}).Record()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	for _, key := range []string{"id", "internal_id", "title", "users", "last_message", "last_activity", "unread_count", "is_group"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("Expected JSON field %q in %s", key, data)
+		}
+	}
+	if record.Users[0].ID != "1234" {
+		t.Errorf("Expected user ID 1234, got %q", record.Users[0].ID)
+	}
+}
+
+func TestMessageTypeName(t *testing.T) {
+	cases := map[string]string{"text": "TEXT", "media": "MEDIA", "photo": "MEDIA", "voice": "MEDIA", "link": "TEXT", "system": "SYSTEM", "": "TEXT"}
+	for in, expected := range cases {
+		if got := MessageTypeName(in); got != expected {
+			t.Errorf("MessageTypeName(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+// Message requests are threads from people you don't follow. Instagram keeps
+// them in a separate pending inbox until they are accepted.
+
+// GetMessageRequests fetches pending message requests, newest first
+func (dm *DirectMessages) GetMessageRequests() ([]*Chat, error) {
+	if dm.insta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	if err := dm.insta.Inbox.SyncPending(); err != nil {
+		return nil, fmt.Errorf("failed to sync message requests: %v", err)
+	}
+
+	chats := dm.chatsFrom(dm.insta.Inbox.Pending, 0)
+	for _, chat := range chats {
+		chat.Pending = true
+	}
+	return chats, nil
+}
+
+// PreviewMessageRequest returns a request's messages, newest first, without
+// marking them seen, so the sender can't tell you've looked
+func (dm *DirectMessages) PreviewMessageRequest(chatID string, limit int) ([]*Message, error) {
+	conv, err := dm.pendingConversation(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := dm.messagesFrom(conv, limit)
+	dm.attachLocalMedia(conv.ID, messages, false)
+	return messages, nil
+}
+
+// AcceptMessageRequest moves a request into the inbox and returns it as a
+// normal chat
+func (dm *DirectMessages) AcceptMessageRequest(chatID string) (*Chat, error) {
+	conv, err := dm.pendingConversation(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dm.post(fmt.Sprintf("direct_v2/threads/%s/approve/", conv.ID), url.Values{}); err != nil {
+		return nil, fmt.Errorf("failed to accept message request: %v", err)
+	}
+	dm.dropPending(conv.ID)
+	return dm.findChat(conv.ID)
+}
+
+// DeclineMessageRequest deletes a request without telling the sender
+func (dm *DirectMessages) DeclineMessageRequest(chatID string) error {
+	conv, err := dm.pendingConversation(chatID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dm.post(fmt.Sprintf("direct_v2/threads/%s/decline/", conv.ID), url.Values{}); err != nil {
+		return fmt.Errorf("failed to decline message request: %v", err)
+	}
+	dm.dropPending(conv.ID)
+	return nil
+}
+
+// BlockMessageRequest blocks everyone who sent a request and declines it
+func (dm *DirectMessages) BlockMessageRequest(chatID string) error {
+	conv, err := dm.pendingConversation(chatID)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range conv.Users {
+		userID := strconv.FormatInt(user.ID, 10)
+		form := url.Values{}
+		form.Set("user_id", userID)
+		form.Set("surface", "direct_thread")
+		if _, err := dm.post(fmt.Sprintf("friendships/block/%s/", userID), form); err != nil {
+			return fmt.Errorf("failed to block %s: %v", user.Username, err)
+		}
+	}
+
+	return dm.DeclineMessageRequest(chatID)
+}
+
+// pendingConversation finds a request by internal or thread ID, syncing the
+// pending inbox if it hasn't been loaded yet
+func (dm *DirectMessages) pendingConversation(chatID string) (*goinsta.Conversation, error) {
+	if dm.insta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	if conv := dm.findPending(chatID); conv != nil {
+		return conv, nil
+	}
+	if _, err := dm.GetMessageRequests(); err != nil {
+		return nil, err
+	}
+	if conv := dm.findPending(chatID); conv != nil {
+		return conv, nil
+	}
+	return nil, fmt.Errorf("message request %s not found", chatID)
+}
+
+func (dm *DirectMessages) findPending(chatID string) *goinsta.Conversation {
+	for _, conv := range dm.insta.Inbox.Pending {
+		if conv.ID == chatID || dm.InternalIDForThread(conv.ID) == chatID {
+			return conv
+		}
+	}
+	return nil
+}
+
+// dropPending forgets a request that has been dealt with
+func (dm *DirectMessages) dropPending(threadID string) {
+	pending := dm.insta.Inbox.Pending[:0]
+	for _, conv := range dm.insta.Inbox.Pending {
+		if conv.ID != threadID {
+			pending = append(pending, conv)
+		}
+	}
+	dm.insta.Inbox.Pending = pending
+}
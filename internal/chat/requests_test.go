@@ -0,0 +1,94 @@
+package chat
+
+import (
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+func TestFindAndDropPending(t *testing.T) {
+	chatIDs := loadChatIDs(filepath.Join(t.TempDir(), "chat_ids.json"))
+	ids := chatIDs.assign([]string{"t1", "t2"})
+
+	dm := &DirectMessages{
+		insta: &goinsta.Instagram{Inbox: &goinsta.Inbox{
+			Pending: []*goinsta.Conversation{{ID: "t1"}, {ID: "t2"}},
+		}},
+		chatIDs: chatIDs,
+	}
+
+	if conv := dm.findPending(ids["t2"]); conv == nil || conv.ID != "t2" {
+		t.Errorf("Expected internal ID %s to find t2, got %v", ids["t2"], conv)
+	}
+	if conv := dm.findPending("t1"); conv == nil || conv.ID != "t1" {
+		t.Errorf("Expected thread ID to find t1, got %v", conv)
+	}
+	if conv := dm.findPending("t3"); conv != nil {
+		t.Errorf("Expected no request for t3, got %v", conv)
+	}
+
+	dm.dropPending("t1")
+	if len(dm.insta.Inbox.Pending) != 1 || dm.insta.Inbox.Pending[0].ID != "t2" {
+		t.Errorf("Expected only t2 left, got %v", dm.insta.Inbox.Pending)
+	}
+}
+
+func TestAcceptMessageRequest(t *testing.T) {
+	post := &stubPost{body: `{"status": "ok"}`}
+	dm := stubInbox(t, post)
+	dm.insta.Inbox.Pending = []*goinsta.Conversation{{ID: "t1", Title: "stranger", Pending: true}, {ID: "t2"}}
+	// Once accepted, the thread shows up in the inbox
+	dm.syncInbox = func() error {
+		dm.insta.Inbox.Conversations = []*goinsta.Conversation{{ID: "t1", Title: "stranger"}}
+		return nil
+	}
+
+	chat, err := dm.AcceptMessageRequest("t1")
+	if err != nil {
+		t.Fatalf("Failed to accept request: %v", err)
+	}
+	if chat.ID != "t1" || chat.Pending || chat.InternalID == "" {
+		t.Errorf("Expected t1 as a normal chat, got %+v", chat)
+	}
+	if len(post.paths) != 1 || post.paths[0] != "direct_v2/threads/t1/approve/" {
+		t.Errorf("Unexpected requests %v", post.paths)
+	}
+	if len(dm.insta.Inbox.Pending) != 1 || dm.insta.Inbox.Pending[0].ID != "t2" {
+		t.Errorf("Expected only t2 to stay pending, got %v", dm.insta.Inbox.Pending)
+	}
+}
+
+func TestDeclineMessageRequest(t *testing.T) {
+	post := &stubPost{body: `{"status": "ok"}`}
+	dm := stubInbox(t, post)
+	dm.insta.Inbox.Pending = []*goinsta.Conversation{{ID: "t1"}, {ID: "t2"}}
+	ids := dm.chatIDs.assign([]string{"t1", "t2"})
+
+	if err := dm.DeclineMessageRequest(ids["t2"]); err != nil {
+		t.Fatalf("Failed to decline request: %v", err)
+	}
+	if len(post.paths) != 1 || post.paths[0] != "direct_v2/threads/t2/decline/" {
+		t.Errorf("Unexpected requests %v", post.paths)
+	}
+	if len(dm.insta.Inbox.Pending) != 1 || dm.insta.Inbox.Pending[0].ID != "t1" {
+		t.Errorf("Expected only t1 to stay pending, got %v", dm.insta.Inbox.Pending)
+	}
+}
+
+func TestDeclineMessageRequestFailure(t *testing.T) {
+	dm := stubInbox(t, &stubPost{})
+	dm.insta.Inbox.Pending = []*goinsta.Conversation{{ID: "t1"}}
+	dm.post = func(string, url.Values) ([]byte, error) {
+		return nil, errors.New("status code 500")
+	}
+
+	if err := dm.DeclineMessageRequest("t1"); err == nil {
+		t.Fatal("Expected the decline to fail")
+	}
+	if len(dm.insta.Inbox.Pending) != 1 {
+		t.Errorf("Expected t1 to stay pending after a failed decline, got %v", dm.insta.Inbox.Pending)
+	}
+}
@@ -0,0 +1,124 @@
+package chat
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/schedule"
+)
+
+// newScheduleStore opens the account's scheduled messages under advanced.users_dir
+func newScheduleStore(username string) *schedule.Store {
+	return schedule.NewStore(userFile(username, "scheduled.json"))
+}
+
+// ParseScheduleArgs splits "<time|+duration> <text>", falling back to
+// scheduling.default_schedule_duration when no time is given
+func ParseScheduleArgs(args string) (time.Time, string, error) {
+	return schedule.ParseArgs(args, time.Now(), defaultScheduleDelay())
+}
+
+// ScheduleTime parses a time for ScheduleMessage; an empty string means
+// scheduling.default_schedule_duration from now
+func ScheduleTime(when string) (time.Time, error) {
+	if when == "" {
+		return time.Now().Add(defaultScheduleDelay()), nil
+	}
+	return schedule.ParseTime(when, time.Now())
+}
+
+func defaultScheduleDelay() time.Duration {
+	delay, err := schedule.ParseDelay(config.GetInstance().GetString("scheduling.default_schedule_duration", "01:00"))
+	if err != nil {
+		return time.Hour
+	}
+	return delay
+}
+
+// ScheduleMessage queues text to be sent to a chat at sendAt
+func (dm *DirectMessages) ScheduleMessage(chatID, text string, sendAt time.Time) (*schedule.Message, error) {
+	if dm.insta == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+
+	chat, err := dm.findChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return dm.schedules.Add(chat.ID, chat.Title, text, sendAt)
+}
+
+// ListScheduled returns scheduled messages, soonest first. Sent and cancelled
+// ones are only included when all is true.
+func (dm *DirectMessages) ListScheduled(all bool) ([]*schedule.Message, error) {
+	messages, err := dm.schedules.List()
+	if err != nil || all {
+		return messages, err
+	}
+
+	var open []*schedule.Message
+	for _, msg := range messages {
+		if !msg.Done() {
+			open = append(open, msg)
+		}
+	}
+	return open, nil
+}
+
+// CancelScheduled cancels a pending scheduled message, or dismisses a missed one
+func (dm *DirectMessages) CancelScheduled(id string) (*schedule.Message, error) {
+	return dm.schedules.Cancel(id)
+}
+
+// StartScheduler sends scheduled messages as they come due, in this process.
+// report hears about each one that is sent, fails or was missed while
+// nothing was running.
+func (dm *DirectMessages) StartScheduler(report func(*schedule.Message)) error {
+	if dm.insta == nil {
+		return fmt.Errorf("not logged in")
+	}
+
+	dm.scheduleMutex.Lock()
+	defer dm.scheduleMutex.Unlock()
+
+	if dm.dispatcher == nil {
+		dm.dispatcher = schedule.NewDispatcher(dm.schedules, func(msg *schedule.Message) error {
+			return dm.SendMessage(msg.ChatID, msg.Text)
+		}, report)
+	}
+	dm.dispatcher.Start()
+	return nil
+}
+
+// StopScheduler stops sending scheduled messages from this process
+func (dm *DirectMessages) StopScheduler() {
+	dm.scheduleMutex.Lock()
+	defer dm.scheduleMutex.Unlock()
+
+	if dm.dispatcher != nil {
+		dm.dispatcher.Stop()
+	}
+}
+
+// IsSchedulerRunning reports whether this process is sending scheduled messages
+func (dm *DirectMessages) IsSchedulerRunning() bool {
+	dm.scheduleMutex.Lock()
+	defer dm.scheduleMutex.Unlock()
+	return dm.dispatcher != nil && dm.dispatcher.IsRunning()
+}
+
+// findChat looks a chat up by internal ID or thread ID
+func (dm *DirectMessages) findChat(chatID string) (*Chat, error) {
+	chats, err := dm.GetChatsWithLimit(0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chat := range chats {
+		if chat.InternalID == chatID || chat.ID == chatID {
+			return chat, nil
+		}
+	}
+	return nil, fmt.Errorf("chat %s not found", chatID)
+}
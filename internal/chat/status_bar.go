@@ -3,6 +3,7 @@ package chat
 import (
 	"sync"
 
+	"github.com/abhi-praj/GoGram/internal/client"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -13,6 +14,7 @@ type StatusBar struct {
 	mode       ChatMode
 	message    string
 	defaultMsg string
+	presence   string
 	mutex      sync.RWMutex
 	app        *tview.Application
 }
@@ -48,8 +50,35 @@ func (sb *StatusBar) Update(message string) {
 		sb.message = sb.defaultMsg
 	}
 
+	sb.redraw()
+}
+
+// SetPresence shows what the people in the current chat are doing, e.g.
+// "Seen by Alice", after the status message. An empty string clears it.
+func (sb *StatusBar) SetPresence(presence string) {
+	sb.mutex.Lock()
+	defer sb.mutex.Unlock()
+
+	if presence == sb.presence {
+		return
+	}
+	sb.presence = presence
+	sb.redraw()
+}
+
+// redraw shows the message and presence, flagging invisible mode. Callers
+// hold the mutex.
+func (sb *StatusBar) redraw() {
+	text := sb.message
+	if client.InvisibleMode() {
+		text = "[::b]INVISIBLE[::-]  •  " + text
+	}
+	if sb.presence != "" {
+		text += "  •  " + sb.presence
+	}
+
 	sb.app.QueueUpdateDraw(func() {
-		sb.SetText(sb.message)
+		sb.SetText(text)
 	})
 }
 
@@ -76,6 +105,8 @@ func (sb *StatusBar) SetMode(mode ChatMode) {
 		modeText = "REPLY MODE - Select message to reply to"
 	case ChatModeUnsend:
 		modeText = "UNSEND MODE - Select message to unsend"
+	case ChatModeReact:
+		modeText = "REACT MODE - Up/Down to select, type an emoji (or - to remove) and press Enter"
 	}
 
 	sb.defaultMsg = modeText
@@ -83,9 +114,7 @@ func (sb *StatusBar) SetMode(mode ChatMode) {
 		sb.message = modeText
 	}
 
-	sb.app.QueueUpdateDraw(func() {
-		sb.SetText(sb.message)
-	})
+	sb.redraw()
 }
 
 // GetMode returns the current chat mode
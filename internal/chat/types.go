@@ -8,6 +8,7 @@ const (
 	ChatModeCommand
 	ChatModeReply
 	ChatModeUnsend
+	ChatModeReact
 )
 
 // Signal represents continue or quit chat
@@ -26,6 +27,28 @@ const (
 	ChatMenuModeDefault ChatMenuMode = iota
 	ChatMenuModeSearchUsername
 	ChatMenuModeSearchTitle
+	ChatMenuModeNewChat
+)
+
+// ChatMenuAction is something done to the chat selected in the chat menu
+type ChatMenuAction int
+
+const (
+	ChatMenuActionNewChat ChatMenuAction = iota
+	ChatMenuActionNewGroup
+	ChatMenuActionRename
+	ChatMenuActionAddMember
+	ChatMenuActionRemoveMember
+	ChatMenuActionLeave
+	ChatMenuActionToggleMute
+	ChatMenuActionToggleRequests
+	ChatMenuActionAccept
+	ChatMenuActionDecline
+	ChatMenuActionBlock
+	ChatMenuActionTogglePin
+	ChatMenuActionToggleLocalMute
+	ChatMenuActionToggleArchive
+	ChatMenuActionLabel
 )
 
 // LineInfo stores line information for chat messages
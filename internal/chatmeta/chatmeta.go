@@ -0,0 +1,158 @@
+// Package chatmeta keeps per-chat settings that only exist on this computer:
+// pins, GoGram-only mutes, archiving and labels
+package chatmeta
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+// Meta is what's stored for one chat
+type Meta struct {
+	Pinned bool `json:"pinned,omitempty"`
+	// Muted silences GoGram's notifications for the chat. It's separate from
+	// muting on Instagram, which other devices follow too.
+	Muted    bool     `json:"muted,omitempty"`
+	Archived bool     `json:"archived,omitempty"`
+	Labels   []string `json:"labels,omitempty"`
+}
+
+// HasLabel reports whether the chat carries label
+func (m Meta) HasLabel(label string) bool {
+	label = NormalizeLabel(label)
+	for _, l := range m.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Meta) empty() bool {
+	return !m.Pinned && !m.Muted && !m.Archived && len(m.Labels) == 0
+}
+
+// NormalizeLabel lowercases a label and drops a leading # or @, so "#Work",
+// "@work" and "work" are the same label
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimLeft(strings.TrimSpace(label), "#@"))
+}
+
+// ValidateLabel checks a label is usable: not empty, with no spaces or commas,
+// which separate labels on the command line
+func ValidateLabel(label string) error {
+	if NormalizeLabel(label) == "" {
+		return fmt.Errorf("label is empty")
+	}
+	if strings.ContainsFunc(label, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return fmt.Errorf("label %q can't contain spaces or commas", label)
+	}
+	return nil
+}
+
+// Store keeps every chat's Meta in one JSON file keyed by thread ID. Changes
+// reload the file under a lock file, so several GoGram processes can share it.
+type Store struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewStore creates a store backed by path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// All returns the settings of every chat that has any
+func (s *Store) All() (map[string]Meta, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.load()
+}
+
+// Get returns a chat's settings, the zero Meta if it has none
+func (s *Store) Get(threadID string) (Meta, error) {
+	all, err := s.All()
+	if err != nil {
+		return Meta{}, err
+	}
+	return all[threadID], nil
+}
+
+// Update changes a chat's settings with fn and saves them
+func (s *Store) Update(threadID string, fn func(*Meta)) (Meta, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := storage.Lock(s.path)
+	if err != nil {
+		return Meta{}, fmt.Errorf("failed to lock chat settings: %v", err)
+	}
+	defer unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return Meta{}, err
+	}
+
+	meta := all[threadID]
+	fn(&meta)
+	meta.Labels = cleanLabels(meta.Labels)
+	if meta.empty() {
+		delete(all, threadID)
+	} else {
+		all[threadID] = meta
+	}
+
+	if err := storage.WriteJSON(s.path, all); err != nil {
+		return Meta{}, fmt.Errorf("failed to save chat settings: %v", err)
+	}
+	return meta, nil
+}
+
+// Labels counts how many chats carry each label
+func (s *Store) Labels() (map[string]int, error) {
+	all, err := s.All()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, meta := range all {
+		for _, label := range meta.Labels {
+			counts[label]++
+		}
+	}
+	return counts, nil
+}
+
+func (s *Store) load() (map[string]Meta, error) {
+	all := make(map[string]Meta)
+	if err := storage.ReadJSON(s.path, &all); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load chat settings: %v", err)
+	}
+	return all, nil
+}
+
+// cleanLabels normalizes labels, dropping empty ones and duplicates, and
+// sorts them
+func cleanLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	var cleaned []string
+	for _, label := range labels {
+		label = NormalizeLabel(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		cleaned = append(cleaned, label)
+	}
+	sort.Strings(cleaned)
+	return cleaned
+}
@@ -0,0 +1,55 @@
+package chatmeta
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStoreUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat_meta.json")
+	store := NewStore(path)
+
+	meta, err := store.Update("t1", func(m *Meta) {
+		m.Pinned = true
+		m.Labels = append(m.Labels, "#Work", "vip", "work", " ")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !meta.Pinned || !reflect.DeepEqual(meta.Labels, []string{"vip", "work"}) {
+		t.Errorf("Unexpected meta %+v", meta)
+	}
+
+	// Another process sees the change
+	meta, err = NewStore(path).Get("t1")
+	if err != nil || !meta.Pinned || !meta.HasLabel("@WORK") {
+		t.Errorf("Expected the saved meta to be read back, got %+v, %v", meta, err)
+	}
+
+	store.Update("t2", func(m *Meta) { m.Labels = []string{"work"} })
+	counts, err := store.Labels()
+	if err != nil || counts["work"] != 2 || counts["vip"] != 1 {
+		t.Errorf("Unexpected label counts %v, %v", counts, err)
+	}
+
+	// Chats with nothing set are dropped from the file
+	store.Update("t2", func(m *Meta) { m.Labels = nil })
+	all, _ := store.All()
+	if _, ok := all["t2"]; ok || len(all) != 1 {
+		t.Errorf("Expected only t1 to be stored, got %v", all)
+	}
+}
+
+func TestValidateLabel(t *testing.T) {
+	for _, label := range []string{"work", "#vip", "clients-2025"} {
+		if err := ValidateLabel(label); err != nil {
+			t.Errorf("ValidateLabel(%q) = %v", label, err)
+		}
+	}
+	for _, label := range []string{"", "#", "two words", "a,b"} {
+		if err := ValidateLabel(label); err == nil {
+			t.Errorf("Expected ValidateLabel(%q) to fail", label)
+		}
+	}
+}
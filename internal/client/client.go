@@ -14,6 +14,8 @@ type ClientWrapper struct {
 	instaClient *goinsta.Instagram
 	username    string
 	config      *config.Config
+	sessions    *SessionStore
+	api         *privateAPI
 }
 
 // NewClientWrapper creates a new client wrapper
@@ -34,6 +36,7 @@ func (c *ClientWrapper) Login(username, password string, verificationCode string
 	if err := c.instaClient.Login(); err != nil {
 		return fmt.Errorf("login failed: %v", err)
 	}
+	c.api = newPrivateAPI(c.instaClient)
 
 	// Update username and save session
 	c.username = username
@@ -61,11 +64,25 @@ func (c *ClientWrapper) LoginBySession() error {
 		return fmt.Errorf("no session file found for user %s", c.username)
 	}
 
-	var err error
-	c.instaClient, err = goinsta.Import(sessionPath)
+	store, err := c.getSessionStore()
+	if err != nil {
+		return err
+	}
+
+	data, encrypted, err := store.Load(sessionPath)
+	if err != nil {
+		return fmt.Errorf("failed to read session: %v", err)
+	}
+
+	c.instaClient, err = goinsta.ImportFromBytes(data)
 	if err != nil {
 		return fmt.Errorf("failed to import session: %v", err)
 	}
+	c.api = newPrivateAPI(c.instaClient)
+
+	if !encrypted && c.config.GetBool("security.encrypt_sessions", true) {
+		fmt.Fprintf(os.Stderr, "Warning: session for @%s is stored unencrypted, run 'auth migrate-sessions' to encrypt it\n", c.username)
+	}
 
 	return nil
 }
@@ -86,6 +103,7 @@ func (c *ClientWrapper) Logout() error {
 
 	c.config.Set("login.current_username", nil)
 	c.instaClient = nil
+	c.api = nil
 
 	return nil
 }
@@ -96,19 +114,52 @@ func (c *ClientWrapper) saveSession() error {
 		return fmt.Errorf("no active Instagram client")
 	}
 
-	sessionPath := c.getSessionPath()
-
-	if err := os.MkdirAll(filepath.Dir(sessionPath), 0755); err != nil {
-		return fmt.Errorf("failed to create session directory: %v", err)
+	store, err := c.getSessionStore()
+	if err != nil {
+		return err
 	}
 
-	if err := c.instaClient.Export(sessionPath); err != nil {
+	data, err := c.instaClient.ExportAsBytes()
+	if err != nil {
 		return fmt.Errorf("failed to export session: %v", err)
 	}
 
+	if err := store.Save(c.getSessionPath(), data); err != nil {
+		return fmt.Errorf("failed to save session: %v", err)
+	}
+
 	return nil
 }
 
+// getSessionStore lazily builds the session store from the security config
+func (c *ClientWrapper) getSessionStore() (*SessionStore, error) {
+	if c.sessions != nil {
+		return c.sessions, nil
+	}
+
+	store, err := NewSessionStoreFromConfig(c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.sessions = store
+	return store, nil
+}
+
+// NewSessionStoreFromConfig creates a session store using the security.* settings
+func NewSessionStoreFromConfig(cfg *config.Config) (*SessionStore, error) {
+	if !cfg.GetBool("security.encrypt_sessions", true) {
+		return NewSessionStore(nil, false), nil
+	}
+
+	provider, err := NewSecretProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up session encryption: %v", err)
+	}
+
+	return NewSessionStore(provider, true), nil
+}
+
 // getSessionPath returns the path to the session file
 func (c *ClientWrapper) getSessionPath() string {
 	usersDir := c.config.Get("advanced.users_dir", "").(string)
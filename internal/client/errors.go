@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// APIError is a private API call that Instagram answered with an error
+type APIError struct {
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return e.Path + " failed: " + e.Message
+}
+
+// temporaryMessages appear in errors from throttling, Instagram outages and
+// network trouble, including ones goinsta has flattened into plain text
+var temporaryMessages = []string{
+	"please wait a few minutes",
+	"rate limit",
+	"too many requests",
+	"try again later",
+	"status code 429",
+	"status code 5",
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"network is unreachable",
+	"i/o timeout",
+}
+
+// IsTemporary reports whether err is worth retrying later: the network is
+// down, the request timed out, Instagram is rate limiting or having trouble.
+// Errors are matched by text as well, since goinsta doesn't keep their types.
+func IsTemporary(err error) bool {
+	if err == nil || errors.Is(err, ErrInvisible) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500 {
+			return true
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	text := strings.ToLower(err.Error())
+	for _, fragment := range temporaryMessages {
+		if strings.Contains(text, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+)
+
+func TestIsTemporary(t *testing.T) {
+	testCases := []struct {
+		err       error
+		temporary bool
+	}{
+		{nil, false},
+		{&APIError{Path: "/send/", StatusCode: 429, Message: "Too Many Requests"}, true},
+		{&APIError{Path: "/send/", StatusCode: 503, Message: "Service Unavailable"}, true},
+		{&APIError{Path: "/send/", StatusCode: 400, Message: "Please wait a few minutes before you try again."}, true},
+		{&APIError{Path: "/send/", StatusCode: 400, Message: "This user isn't accepting messages"}, false},
+		{fmt.Errorf("failed to sync inbox: %w", &net.DNSError{Err: "no such host", Name: "i.instagram.com"}), true},
+		{errors.New("Invalid status code 500: Internal Server Error"), true},
+		{&url.Error{Op: "Post", URL: "https://i.instagram.com/", Err: ErrInvisible}, false},
+	}
+
+	for _, tc := range testCases {
+		if got := IsTemporary(tc.err); got != tc.temporary {
+			t.Errorf("IsTemporary(%v) = %v, expected %v", tc.err, got, tc.temporary)
+		}
+	}
+}
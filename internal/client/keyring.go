@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	keyringService = "gogram"
+	keyringAccount = "session-key"
+)
+
+// KeyringProvider keeps the session key in the OS keyring, using secret-tool
+// on Linux and the security CLI on macOS
+type KeyringProvider struct {
+	run  func(stdin string, name string, args ...string) (string, error)
+	goos string
+}
+
+// NewKeyringProvider creates a keyring provider backed by the platform CLI
+func NewKeyringProvider() *KeyringProvider {
+	return &KeyringProvider{run: runKeyringCommand, goos: runtime.GOOS}
+}
+
+// Name returns the provider name
+func (p *KeyringProvider) Name() string {
+	return "keyring"
+}
+
+// errNoKeyringEntry is a lookup that worked but found no key
+var errNoKeyringEntry = errors.New("no key in keyring")
+
+// Secret loads the key from the keyring, storing a fresh one on first use.
+// Only a lookup that positively finds no entry creates a key; a locked
+// keyring or a missing CLI is an error, since replacing the key would make
+// every saved session unreadable.
+func (p *KeyringProvider) Secret() ([]byte, error) {
+	encoded, err := p.lookup()
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyring entry is corrupt: %v", err)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, errNoKeyringEntry) {
+		return nil, fmt.Errorf("failed to read key from keyring: %v", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+
+	if err := p.store(base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// lookup returns the stored key, or errNoKeyringEntry if there isn't one
+func (p *KeyringProvider) lookup() (string, error) {
+	var out string
+	var err error
+	switch p.goos {
+	case "linux", "freebsd", "openbsd":
+		out, err = p.run("", "secret-tool", "lookup", "service", keyringService, "account", keyringAccount)
+		// secret-tool exits with 1 and says nothing when there's no match;
+		// D-Bus and unlock failures come with a message
+		var cmdErr *keyringCommandError
+		if errors.As(err, &cmdErr) && cmdErr.exitCode == 1 && cmdErr.stderr == "" {
+			return "", errNoKeyringEntry
+		}
+	case "darwin":
+		out, err = p.run("", "security", "find-generic-password", "-s", keyringService, "-a", keyringAccount, "-w")
+		// errSecItemNotFound
+		var cmdErr *keyringCommandError
+		if errors.As(err, &cmdErr) && cmdErr.exitCode == 44 {
+			return "", errNoKeyringEntry
+		}
+	default:
+		return "", fmt.Errorf("keyring not supported on %s", p.goos)
+	}
+	if err != nil {
+		return "", err
+	}
+	if out == "" {
+		return "", errNoKeyringEntry
+	}
+	return out, nil
+}
+
+func (p *KeyringProvider) store(secret string) error {
+	var err error
+	switch p.goos {
+	case "linux", "freebsd", "openbsd":
+		_, err = p.run(secret, "secret-tool", "store", "--label=GoGram session key", "service", keyringService, "account", keyringAccount)
+	case "darwin":
+		// A bare -w makes security prompt for the secret, twice, so it never
+		// shows up in the process list
+		_, err = p.run(secret+"\n"+secret+"\n", "security", "add-generic-password", "-U", "-s", keyringService, "-a", keyringAccount, "-w")
+	default:
+		err = fmt.Errorf("keyring not supported on %s", p.goos)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store key in keyring: %v", err)
+	}
+	return nil
+}
+
+// keyringCommandError is a keyring CLI that failed to run or exited non-zero
+type keyringCommandError struct {
+	name     string
+	err      error
+	exitCode int // -1 if the command didn't run
+	stderr   string
+}
+
+func (e *keyringCommandError) Error() string {
+	if e.stderr != "" {
+		return fmt.Sprintf("%s: %v (%s)", e.name, e.err, e.stderr)
+	}
+	return fmt.Sprintf("%s: %v", e.name, e.err)
+}
+
+// runKeyringCommand runs a keyring CLI and returns its trimmed stdout
+func runKeyringCommand(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		cmdErr := &keyringCommandError{name: name, err: err, exitCode: -1, stderr: strings.TrimSpace(stderr.String())}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			cmdErr.exitCode = exitErr.ExitCode()
+		}
+		return "", cmdErr
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestKeyringStoreKeepsSecretOffCommandLine(t *testing.T) {
+	for _, goos := range []string{"linux", "darwin"} {
+		var stdin string
+		var args []string
+		p := &KeyringProvider{goos: goos, run: func(in string, name string, a ...string) (string, error) {
+			stdin, args = in, a
+			return "", nil
+		}}
+
+		if err := p.store("s3cret"); err != nil {
+			t.Fatalf("%s: %v", goos, err)
+		}
+		for _, arg := range args {
+			if strings.Contains(arg, "s3cret") {
+				t.Errorf("%s: secret passed as argument %q", goos, arg)
+			}
+		}
+		if !strings.HasPrefix(stdin, "s3cret") {
+			t.Errorf("%s: expected the secret on stdin, got %q", goos, stdin)
+		}
+	}
+}
+
+func TestKeyringSecretOnlyCreatesKeyWhenNoneExists(t *testing.T) {
+	stored := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	testCases := []struct {
+		name    string
+		goos    string
+		lookup  error
+		out     string
+		creates bool
+		fails   bool
+	}{
+		{"found", "linux", nil, stored, false, false},
+		{"not found", "linux", &keyringCommandError{name: "secret-tool", err: errors.New("exit status 1"), exitCode: 1}, "", true, false},
+		{"locked", "linux", &keyringCommandError{name: "secret-tool", err: errors.New("exit status 1"), exitCode: 1, stderr: "Cannot unlock collection"}, "", false, true},
+		{"no binary", "linux", &keyringCommandError{name: "secret-tool", err: errors.New("executable file not found in $PATH"), exitCode: -1}, "", false, true},
+		{"not found", "darwin", &keyringCommandError{name: "security", err: errors.New("exit status 44"), exitCode: 44}, "", true, false},
+		{"denied", "darwin", &keyringCommandError{name: "security", err: errors.New("exit status 51"), exitCode: 51}, "", false, true},
+	}
+
+	for _, tc := range testCases {
+		created := false
+		p := &KeyringProvider{goos: tc.goos, run: func(stdin string, name string, args ...string) (string, error) {
+			if stdin != "" {
+				created = true
+				return "", nil
+			}
+			return tc.out, tc.lookup
+		}}
+
+		key, err := p.Secret()
+		if tc.fails != (err != nil) {
+			t.Errorf("%s/%s: unexpected error %v", tc.goos, tc.name, err)
+		}
+		if created != tc.creates {
+			t.Errorf("%s/%s: expected creating a key to be %v", tc.goos, tc.name, tc.creates)
+		}
+		if !tc.fails && len(key) != 32 {
+			t.Errorf("%s/%s: expected a 32 byte key, got %d bytes", tc.goos, tc.name, len(key))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"time"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// VideoInfo is the metadata Instagram wants alongside a video upload
+type VideoInfo struct {
+	Duration time.Duration
+	Width    int
+	Height   int
+}
+
+// ProbeVideo reads duration and dimensions from an MP4/MOV file's moov box
+func ProbeVideo(data []byte) (VideoInfo, error) {
+	var info VideoInfo
+	if err := walkBoxes(data, &info); err != nil {
+		return info, err
+	}
+
+	if info.Duration == 0 {
+		return info, fmt.Errorf("not an MP4 video (no movie header found)")
+	}
+	if info.Width == 0 || info.Height == 0 {
+		return info, fmt.Errorf("no video track found")
+	}
+	return info, nil
+}
+
+// walkBoxes scans a sequence of ISO BMFF boxes, descending into containers
+func walkBoxes(data []byte, info *VideoInfo) error {
+	for len(data) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(data[0:4]))
+		boxType := string(data[4:8])
+		header := uint64(8)
+
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return fmt.Errorf("truncated %s box", boxType)
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			header = 16
+		}
+
+		if size < header || size > uint64(len(data)) {
+			return fmt.Errorf("invalid %s box size", boxType)
+		}
+		body := data[header:size]
+
+		switch boxType {
+		case "moov", "trak", "mdia", "minf", "stbl", "edts":
+			if err := walkBoxes(body, info); err != nil {
+				return err
+			}
+		case "mvhd":
+			parseMovieHeader(body, info)
+		case "tkhd":
+			parseTrackHeader(body, info)
+		}
+
+		data = data[size:]
+	}
+	return nil
+}
+
+// parseMovieHeader reads the overall duration from an mvhd box
+func parseMovieHeader(body []byte, info *VideoInfo) {
+	if len(body) < 4 {
+		return
+	}
+
+	var timescale, duration uint64
+	switch body[0] {
+	case 0:
+		if len(body) < 20 {
+			return
+		}
+		timescale = uint64(binary.BigEndian.Uint32(body[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(body[16:20]))
+	case 1:
+		if len(body) < 32 {
+			return
+		}
+		timescale = uint64(binary.BigEndian.Uint32(body[20:24]))
+		duration = binary.BigEndian.Uint64(body[24:32])
+	}
+
+	if timescale > 0 {
+		info.Duration = time.Duration(duration * uint64(time.Second) / timescale)
+	}
+}
+
+// parseTrackHeader takes the dimensions of the first visual track
+func parseTrackHeader(body []byte, info *VideoInfo) {
+	if info.Width > 0 || len(body) < 4 {
+		return
+	}
+
+	// Width and height are the last two 16.16 fixed point fields
+	offset := 76
+	if body[0] == 1 {
+		offset = 88
+	}
+	if len(body) < offset+8 {
+		return
+	}
+
+	width := int(binary.BigEndian.Uint32(body[offset:offset+4]) >> 16)
+	height := int(binary.BigEndian.Uint32(body[offset+4:offset+8]) >> 16)
+	if width > 0 && height > 0 {
+		info.Width = width
+		info.Height = height
+	}
+}
+
+// PreparePhoto returns data as a JPEG, re-encoding PNG and GIF images since
+// direct photo uploads only accept JPEG
+func PreparePhoto(data []byte) ([]byte, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %v", err)
+	}
+	if format == "jpeg" {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("failed to convert %s to JPEG: %v", format, err)
+	}
+	return buf.Bytes(), nil
+}
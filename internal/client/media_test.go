@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func box(boxType string, body ...[]byte) []byte {
+	payload := bytes.Join(body, nil)
+	out := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(out[0:4], uint32(8+len(payload)))
+	copy(out[4:8], boxType)
+	return append(out, payload...)
+}
+
+func movieHeader(timescale, duration uint32) []byte {
+	body := make([]byte, 100)
+	binary.BigEndian.PutUint32(body[12:16], timescale)
+	binary.BigEndian.PutUint32(body[16:20], duration)
+	return box("mvhd", body)
+}
+
+func trackHeader(width, height uint32) []byte {
+	body := make([]byte, 84)
+	binary.BigEndian.PutUint32(body[76:80], width<<16)
+	binary.BigEndian.PutUint32(body[80:84], height<<16)
+	return box("tkhd", body)
+}
+
+func TestProbeVideo(t *testing.T) {
+	data := bytes.Join([][]byte{
+		box("ftyp", []byte("isom")),
+		box("moov",
+			movieHeader(1000, 5500),
+			box("trak", trackHeader(0, 0)),
+			box("trak", trackHeader(1280, 720)),
+		),
+		box("mdat", make([]byte, 32)),
+	}, nil)
+
+	info, err := ProbeVideo(data)
+	if err != nil {
+		t.Fatalf("ProbeVideo failed: %v", err)
+	}
+	if info.Duration != 5500*time.Millisecond {
+		t.Errorf("Expected duration 5.5s, got %v", info.Duration)
+	}
+	if info.Width != 1280 || info.Height != 720 {
+		t.Errorf("Expected 1280x720, got %dx%d", info.Width, info.Height)
+	}
+}
+
+func TestProbeVideoRejectsNonVideo(t *testing.T) {
+	if _, err := ProbeVideo([]byte("definitely not a video")); err == nil {
+		t.Error("Expected an error for non-MP4 data")
+	}
+}
+
+func TestPreparePhotoConvertsPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatalf("png.Encode failed: %v", err)
+	}
+
+	out, err := PreparePhoto(buf.Bytes())
+	if err != nil {
+		t.Fatalf("PreparePhoto failed: %v", err)
+	}
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("Expected JPEG output: %v", err)
+	}
+	if cfg.Width != 4 || cfg.Height != 3 {
+		t.Errorf("Expected 4x3, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
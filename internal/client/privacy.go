@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+)
+
+// ErrInvisible is returned for requests that would reveal activity while
+// privacy.invisible_mode is on
+var ErrInvisible = errors.New("blocked by privacy.invisible_mode")
+
+// presenceEndpoints are path fragments of calls that tell other people you
+// are around: seen receipts, typing indicators and presence pings
+var presenceEndpoints = []string{
+	"/seen/",
+	"_seen/",
+	"/write_seen_state/",
+	"/indicate_activity/",
+	"/set_presence",
+	"/presence_ping",
+}
+
+// InvisibleMode reports whether privacy.invisible_mode is on
+func InvisibleMode() bool {
+	return config.GetInstance().GetBool("privacy.invisible_mode", false)
+}
+
+// isPresenceRequest reports whether req would reveal activity
+func isPresenceRequest(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	for _, fragment := range presenceEndpoints {
+		if strings.Contains(req.URL.Path, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+// fakeBackend stands in for Instagram and records the paths it is asked for
+type fakeBackend struct {
+	mutex sync.Mutex
+	paths []string
+}
+
+func (b *fakeBackend) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.mutex.Lock()
+	b.paths = append(b.paths, req.URL.Path)
+	b.mutex.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"status": "ok"}`)),
+		Request:    req,
+	}, nil
+}
+
+func (b *fakeBackend) seenCalls() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	count := 0
+	for _, path := range b.paths {
+		if strings.Contains(path, "seen") {
+			count++
+		}
+	}
+	return count
+}
+
+// newTestClient returns a client whose requests go to backend
+func newTestClient(backend *fakeBackend, invisible bool) (*ClientWrapper, *goinsta.Instagram) {
+	insta := goinsta.New("user", "password")
+	insta.Account = &goinsta.Account{}
+	api := newPrivateAPI(insta)
+	api.recorder.next = backend
+	api.recorder.invisible = func() bool { return invisible }
+	return &ClientWrapper{instaClient: insta, api: api}, insta
+}
+
+func TestInvisibleModeBlocksSeenCalls(t *testing.T) {
+	backend := &fakeBackend{}
+	c, insta := newTestClient(backend, true)
+
+	if _, err := c.PrivatePost("direct_v2/threads/1/items/2/seen/", nil); !errors.Is(err, ErrInvisible) {
+		t.Errorf("Expected the thread seen call to be blocked, got %v", err)
+	}
+	if _, err := c.PrivatePost("direct_v2/threads/broadcast/indicate_activity/", nil); !errors.Is(err, ErrInvisible) {
+		t.Errorf("Expected the typing indicator to be blocked, got %v", err)
+	}
+
+	// Calls goinsta makes itself go through the same transport
+	if err := insta.Activity.MarkAsSeen(); err == nil {
+		t.Error("Expected goinsta's activity seen call to be blocked")
+	}
+
+	if n := backend.seenCalls(); n != 0 {
+		t.Errorf("Expected no seen calls to reach the backend, got %d: %v", n, backend.paths)
+	}
+
+	// Reads still work
+	if _, err := c.PrivateGet("direct_v2/threads/1/", nil); err != nil {
+		t.Errorf("Expected reads to go through, got %v", err)
+	}
+	if len(backend.paths) != 1 {
+		t.Errorf("Expected exactly one request to reach the backend, got %v", backend.paths)
+	}
+}
+
+func TestSeenCallsAllowedWhenVisible(t *testing.T) {
+	backend := &fakeBackend{}
+	c, _ := newTestClient(backend, false)
+
+	if _, err := c.PrivatePost("direct_v2/threads/1/items/2/seen/", nil); err != nil {
+		t.Fatalf("Expected the seen call to go through, got %v", err)
+	}
+	if n := backend.seenCalls(); n != 1 {
+		t.Errorf("Expected 1 seen call, got %d", n)
+	}
+}
+
+func TestIsPresenceRequest(t *testing.T) {
+	testCases := []struct {
+		method, path string
+		expected     bool
+	}{
+		{http.MethodPost, "/api/v1/direct_v2/threads/1/items/2/seen/", true},
+		{http.MethodPost, "/api/v1/news/inbox_seen/", true},
+		{http.MethodPost, "/api/v1/media/seen/", true},
+		{http.MethodPost, "/api/v1/direct_v2/visual_threads/1/item_seen/", true},
+		{http.MethodPost, "/api/v1/direct_v2/threads/broadcast/indicate_activity/", true},
+		{http.MethodPost, "/api/v1/direct_v2/threads/broadcast/text/", false},
+		{http.MethodGet, "/api/v1/direct_v2/inbox/", false},
+	}
+
+	for _, tc := range testCases {
+		req, _ := http.NewRequest(tc.method, "https://i.instagram.com"+tc.path, nil)
+		if got := isPresenceRequest(req); got != tc.expected {
+			t.Errorf("isPresenceRequest(%s %s) = %v, expected %v", tc.method, tc.path, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+)
+
+const (
+	apiBaseURL     = "https://i.instagram.com/api/v1/"
+	ruploadURL     = "https://i.instagram.com/"
+	requestTimeout = 2 * time.Minute
+)
+
+// headerRecorder sits under goinsta's HTTP client and remembers the headers of
+// the last authenticated request. goinsta has no exported way to call
+// endpoints it doesn't wrap, so privateAPI replays those headers instead.
+// Since every request passes through it, it also drops presence calls while
+// invisible returns true.
+type headerRecorder struct {
+	next      http.RoundTripper
+	invisible func() bool
+	mutex     sync.RWMutex
+	headers   http.Header
+}
+
+func (r *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.invisible != nil && r.invisible() && isPresenceRequest(req) {
+		return nil, ErrInvisible
+	}
+	if strings.HasSuffix(req.URL.Host, "instagram.com") && req.Header.Get("Authorization") != "" {
+		r.mutex.Lock()
+		r.headers = req.Header.Clone()
+		r.mutex.Unlock()
+	}
+	return r.next.RoundTrip(req)
+}
+
+func (r *headerRecorder) last() http.Header {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.headers == nil {
+		return nil
+	}
+	return r.headers.Clone()
+}
+
+// privateAPI makes Instagram private API calls that goinsta doesn't cover
+type privateAPI struct {
+	insta    *goinsta.Instagram
+	recorder *headerRecorder
+	http     *http.Client
+}
+
+// newPrivateAPI hooks the recorder into insta's transport
+func newPrivateAPI(insta *goinsta.Instagram) *privateAPI {
+	recorder := &headerRecorder{
+		next:      &http.Transport{Proxy: http.ProxyFromEnvironment},
+		invisible: InvisibleMode,
+	}
+	insta.SetHTTPTransport(recorder)
+
+	return &privateAPI{
+		insta:    insta,
+		recorder: recorder,
+		http:     &http.Client{Transport: recorder, Timeout: requestTimeout},
+	}
+}
+
+// apiResponse is the envelope every private API response shares
+type apiResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// headers returns the headers for a new request, falling back to the
+// exported session when goinsta hasn't made a request yet
+func (api *privateAPI) headers() http.Header {
+	headers := api.recorder.last()
+	if headers == nil {
+		headers = http.Header{}
+		for key, value := range api.insta.ExportConfig().HeaderOptions {
+			headers.Set(key, value)
+		}
+	}
+
+	// Let net/http negotiate and decode compression itself
+	headers.Del("Accept-Encoding")
+	headers.Del("Content-Encoding")
+	headers.Del("Content-Length")
+	headers.Set("X-Pigeon-Rawclienttime", fmt.Sprintf("%.3f", float64(time.Now().UnixMilli())/1000))
+	return headers
+}
+
+// do sends a request and checks the response envelope
+func (api *privateAPI) do(req *http.Request) ([]byte, error) {
+	resp, err := api.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	var envelope apiResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, &APIError{Path: req.URL.Path, StatusCode: resp.StatusCode, Message: fmt.Sprintf("unexpected response (HTTP %d)", resp.StatusCode)}
+	}
+	if resp.StatusCode != http.StatusOK || envelope.Status != "ok" {
+		message := envelope.Message
+		if message == "" {
+			message = resp.Status
+		}
+		return nil, &APIError{Path: req.URL.Path, StatusCode: resp.StatusCode, Message: message}
+	}
+
+	return body, nil
+}
+
+// post sends a form to an /api/v1/ endpoint, adding the device UUID
+func (api *privateAPI) post(endpoint string, form url.Values) ([]byte, error) {
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("_uuid", api.insta.ExportConfig().UUID)
+
+	req, err := http.NewRequest(http.MethodPost, apiBaseURL+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = api.headers()
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8")
+
+	return api.do(req)
+}
+
+// get calls an /api/v1/ endpoint with query parameters
+func (api *privateAPI) get(endpoint string, query url.Values) ([]byte, error) {
+	u := apiBaseURL + endpoint
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = api.headers()
+
+	return api.do(req)
+}
+
+// rupload sends raw media bytes to the resumable upload endpoint
+func (api *privateAPI) rupload(kind, entityType string, data []byte, params map[string]string) (string, error) {
+	uploadID := params["upload_id"]
+	name := fmt.Sprintf("%s_0_%d", uploadID, 1000000000+rand.Int63n(9000000000))
+
+	ruploadParams, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ruploadURL+kind+"/"+name, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header = api.headers()
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Instagram-Rupload-Params", string(ruploadParams))
+	req.Header.Set("X_FB_PHOTO_WATERFALL_ID", newUploadToken())
+	req.Header.Set("X-Entity-Type", entityType)
+	req.Header.Set("X-Entity-Name", name)
+	req.Header.Set("X-Entity-Length", strconv.Itoa(len(data)))
+	req.Header.Set("Offset", "0")
+
+	if _, err := api.do(req); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+const retryContext = `{"num_step_auto_retry":0,"num_reupload":0,"num_step_manual_retry":0}`
+
+// newUploadID returns a millisecond timestamp, which is what the app uses
+func newUploadID() string {
+	return strconv.FormatInt(time.Now().UnixMilli(), 10)
+}
+
+// newUploadToken returns a random 19 digit token for client contexts
+func newUploadToken() string {
+	return "68" + strconv.FormatInt(10000000000000000+rand.Int63n(90000000000000000), 10)
+}
+
+// PrivatePost calls a private API endpoint goinsta doesn't wrap, e.g.
+// "direct_v2/threads/broadcast/configure_photo/"
+func (c *ClientWrapper) PrivatePost(endpoint string, form url.Values) ([]byte, error) {
+	if c.api == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+	return c.api.post(endpoint, form)
+}
+
+// PrivateGet is the GET counterpart of PrivatePost
+func (c *ClientWrapper) PrivateGet(endpoint string, query url.Values) ([]byte, error) {
+	if c.api == nil {
+		return nil, fmt.Errorf("not logged in")
+	}
+	return c.api.get(endpoint, query)
+}
+
+// NewClientContext returns a token to use as client_context/mutation_token
+func NewClientContext() string {
+	return newUploadToken()
+}
+
+// UploadPhoto uploads a JPEG and returns its upload ID
+func (c *ClientWrapper) UploadPhoto(jpeg []byte) (string, error) {
+	if c.api == nil {
+		return "", fmt.Errorf("not logged in")
+	}
+
+	params := map[string]string{
+		"retry_context":     retryContext,
+		"media_type":        "1",
+		"upload_id":         newUploadID(),
+		"xsharing_user_ids": "[]",
+		"image_compression": `{"lib_name":"moz","lib_version":"3.1.m","quality":"80"}`,
+	}
+
+	uploadID, err := c.api.rupload("rupload_igphoto", "image/jpeg", jpeg, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload photo: %v", err)
+	}
+	return uploadID, nil
+}
+
+// UploadVideo uploads an MP4 and returns its upload ID
+func (c *ClientWrapper) UploadVideo(mp4 []byte, info VideoInfo) (string, error) {
+	if c.api == nil {
+		return "", fmt.Errorf("not logged in")
+	}
+
+	params := map[string]string{
+		"retry_context":            retryContext,
+		"media_type":               "2",
+		"upload_id":                newUploadID(),
+		"xsharing_user_ids":        "[]",
+		"direct_v2":                "1",
+		"upload_media_duration_ms": strconv.FormatInt(info.Duration.Milliseconds(), 10),
+		"upload_media_width":       strconv.Itoa(info.Width),
+		"upload_media_height":      strconv.Itoa(info.Height),
+	}
+
+	uploadID, err := c.api.rupload("rupload_igvideo", "video/mp4", mp4, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload video: %v", err)
+	}
+	return uploadID, nil
+}
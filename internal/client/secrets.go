@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/storage"
+	"golang.org/x/term"
+)
+
+// PassphraseEnv lets scripts supply the session passphrase without a prompt
+const PassphraseEnv = "GOGRAM_SESSION_PASSPHRASE"
+
+// SecretProvider supplies the secret that session encryption keys are derived from
+type SecretProvider interface {
+	// Name identifies the provider in encrypted session files
+	Name() string
+	// Secret returns the secret material, creating it on first use where that makes sense
+	Secret() ([]byte, error)
+}
+
+// NewSecretProvider builds the provider named by security.secret_provider
+func NewSecretProvider(cfg *config.Config) (SecretProvider, error) {
+	name := strings.ToLower(cfg.GetString("security.secret_provider", "keyfile"))
+
+	switch name {
+	case "keyfile", "":
+		return &KeyfileProvider{Path: cfg.GetString("security.key_file", "")}, nil
+	case "passphrase":
+		return &PassphraseProvider{}, nil
+	case "keyring":
+		return NewKeyringProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown secret provider: %s", name)
+	}
+}
+
+// KeyfileProvider stores a random key in a local file readable only by the owner
+type KeyfileProvider struct {
+	Path string
+}
+
+// Name returns the provider name
+func (p *KeyfileProvider) Name() string {
+	return "keyfile"
+}
+
+// Secret reads the keyfile, generating it if it doesn't exist yet
+func (p *KeyfileProvider) Secret() ([]byte, error) {
+	if p.Path == "" {
+		return nil, fmt.Errorf("no key file configured")
+	}
+
+	data, err := os.ReadFile(p.Path)
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("key file %s is corrupt: %v", p.Path, err)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read key file: %v", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+
+	encoded := []byte(base64.StdEncoding.EncodeToString(key) + "\n")
+	if err := storage.WriteFileAtomic(p.Path, encoded, storage.PrivateFileMode); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %v", err)
+	}
+
+	return key, nil
+}
+
+// PassphraseProvider asks for a passphrase, or reads it from GOGRAM_SESSION_PASSPHRASE
+type PassphraseProvider struct {
+	cached []byte
+}
+
+// Name returns the provider name
+func (p *PassphraseProvider) Name() string {
+	return "passphrase"
+}
+
+// Secret returns the passphrase, prompting once per process
+func (p *PassphraseProvider) Secret() ([]byte, error) {
+	if p.cached != nil {
+		return p.cached, nil
+	}
+
+	if env := os.Getenv(PassphraseEnv); env != "" {
+		p.cached = []byte(env)
+		return p.cached, nil
+	}
+
+	passphrase, err := readPassphrase("Session passphrase: ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %v", err)
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("empty passphrase")
+	}
+
+	p.cached = []byte(passphrase)
+	return p.cached, nil
+}
+
+// readPassphrase prompts on stderr, so it stays out of piped output, and
+// reads the answer without echo from the terminal stderr is on. Without a
+// terminal it reads a line from stdin.
+func readPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		if tty, err := os.Open("/dev/tty"); err == nil {
+			defer tty.Close()
+			passphrase, err := term.ReadPassword(int(tty.Fd()))
+			fmt.Fprintln(os.Stderr)
+			return string(passphrase), err
+		}
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		passphrase, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(passphrase), err
+	}
+
+	passphrase, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !(err == io.EOF && passphrase != "") {
+		return "", err
+	}
+	return strings.TrimRight(passphrase, "\r\n"), nil
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+const (
+	sessionFormatVersion = 1
+	sessionKDF           = "pbkdf2-sha256"
+)
+
+// sessionKDFIterations is a var so tests don't pay for the full work factor
+var sessionKDFIterations = 600000
+
+// encryptedSession is the on-disk envelope for an encrypted session.json
+type encryptedSession struct {
+	Version    int    `json:"gogram_session_version"`
+	Provider   string `json:"provider"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SessionStore reads and writes goinsta session exports, encrypting them at rest
+type SessionStore struct {
+	provider SecretProvider
+	encrypt  bool
+}
+
+// NewSessionStore creates a session store; a nil provider or encrypt=false writes plaintext
+func NewSessionStore(provider SecretProvider, encrypt bool) *SessionStore {
+	return &SessionStore{
+		provider: provider,
+		encrypt:  encrypt && provider != nil,
+	}
+}
+
+// Save writes session data atomically with owner-only permissions
+func (s *SessionStore) Save(path string, data []byte) error {
+	out := data
+	if s.encrypt {
+		sealed, err := s.seal(data)
+		if err != nil {
+			return err
+		}
+		out = sealed
+	}
+
+	return storage.WriteFileAtomic(path, out, storage.PrivateFileMode)
+}
+
+// Load reads a session file, decrypting it if needed. The bool reports whether
+// the file on disk was encrypted.
+func (s *SessionStore) Load(path string) ([]byte, bool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	envelope, ok := parseEnvelope(raw)
+	if !ok {
+		return raw, false, nil
+	}
+
+	data, err := s.open(envelope)
+	if err != nil {
+		return nil, true, err
+	}
+	return data, true, nil
+}
+
+// Migrate rewrites a plaintext session file in encrypted form. It reports
+// whether the file was changed.
+func (s *SessionStore) Migrate(path string) (bool, error) {
+	if !s.encrypt {
+		return false, fmt.Errorf("session encryption is disabled")
+	}
+
+	data, encrypted, err := s.Load(path)
+	if err != nil {
+		return false, err
+	}
+
+	if encrypted {
+		// Still tighten permissions on files written by older versions
+		return false, os.Chmod(path, storage.PrivateFileMode)
+	}
+
+	if err := s.Save(path, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IsEncryptedSession reports whether the file at path is an encrypted session
+func IsEncryptedSession(path string) (bool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	_, ok := parseEnvelope(raw)
+	return ok, nil
+}
+
+// parseEnvelope detects our envelope; plain goinsta exports never carry the version field
+func parseEnvelope(raw []byte) (*encryptedSession, bool) {
+	var envelope encryptedSession
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, false
+	}
+	if envelope.Version == 0 || len(envelope.Ciphertext) == 0 {
+		return nil, false
+	}
+	return &envelope, true
+}
+
+// seal encrypts data with AES-256-GCM under a key derived from the provider secret
+func (s *SessionStore) seal(data []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	gcm, err := s.cipher(salt, sessionKDFIterations)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	envelope := encryptedSession{
+		Version:    sessionFormatVersion,
+		Provider:   s.provider.Name(),
+		KDF:        sessionKDF,
+		Iterations: sessionKDFIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, []byte(s.provider.Name())),
+	}
+
+	return json.Marshal(envelope)
+}
+
+// open decrypts an envelope written by seal
+func (s *SessionStore) open(envelope *encryptedSession) ([]byte, error) {
+	if s.provider == nil {
+		return nil, fmt.Errorf("session is encrypted but no secret provider is configured")
+	}
+	if envelope.Version != sessionFormatVersion || envelope.KDF != sessionKDF {
+		return nil, fmt.Errorf("unsupported session format (version %d, kdf %s)", envelope.Version, envelope.KDF)
+	}
+	if envelope.Provider != s.provider.Name() {
+		return nil, fmt.Errorf("session was encrypted with the %s provider, but %s is configured", envelope.Provider, s.provider.Name())
+	}
+
+	gcm, err := s.cipher(envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, []byte(envelope.Provider))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt session (wrong key or passphrase?)")
+	}
+	return data, nil
+}
+
+// cipher derives the AES key for a salt and returns the AEAD
+func (s *SessionStore) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	secret, err := s.provider.Secret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session secret: %v", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, string(secret), salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type staticProvider struct {
+	name   string
+	secret string
+}
+
+func (p *staticProvider) Name() string            { return p.name }
+func (p *staticProvider) Secret() ([]byte, error) { return []byte(p.secret), nil }
+
+func init() {
+	sessionKDFIterations = 1000
+}
+
+func TestSessionStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user", "session.json")
+	store := NewSessionStore(&staticProvider{name: "keyfile", secret: "s3cret"}, true)
+
+	session := []byte(`{"username":"alice","token":"abc123"}`)
+	if err := store.Save(path, session); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	if bytes.Contains(raw, []byte("abc123")) {
+		t.Error("Expected session file to not contain the token in cleartext")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+
+	loaded, encrypted, err := store.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !encrypted {
+		t.Error("Expected Load to report an encrypted file")
+	}
+	if !bytes.Equal(loaded, session) {
+		t.Errorf("Expected %s, got %s", session, loaded)
+	}
+}
+
+func TestSessionStoreWrongSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	writer := NewSessionStore(&staticProvider{name: "passphrase", secret: "right"}, true)
+	if err := writer.Save(path, []byte(`{"username":"bob"}`)); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reader := NewSessionStore(&staticProvider{name: "passphrase", secret: "wrong"}, true)
+	if _, _, err := reader.Load(path); err == nil {
+		t.Error("Expected an error decrypting with the wrong secret")
+	}
+
+	other := NewSessionStore(&staticProvider{name: "keyfile", secret: "right"}, true)
+	if _, _, err := other.Load(path); err == nil || !strings.Contains(err.Error(), "passphrase provider") {
+		t.Errorf("Expected a provider mismatch error, got %v", err)
+	}
+}
+
+func TestSessionStoreMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	plaintext := []byte(`{"username":"carol","token":"xyz"}`)
+	if err := os.WriteFile(path, plaintext, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	store := NewSessionStore(&staticProvider{name: "keyfile", secret: "k"}, true)
+
+	loaded, encrypted, err := store.Load(path)
+	if err != nil || encrypted || !bytes.Equal(loaded, plaintext) {
+		t.Fatalf("Expected plaintext session to load unchanged, got %s (encrypted=%v, err=%v)", loaded, encrypted, err)
+	}
+
+	migrated, err := store.Migrate(path)
+	if err != nil || !migrated {
+		t.Fatalf("Expected migration to succeed, got migrated=%v err=%v", migrated, err)
+	}
+
+	if ok, _ := IsEncryptedSession(path); !ok {
+		t.Error("Expected session to be encrypted after migration")
+	}
+
+	migrated, err = store.Migrate(path)
+	if err != nil || migrated {
+		t.Errorf("Expected second migration to be a no-op, got migrated=%v err=%v", migrated, err)
+	}
+}
+
+func TestKeyfileProviderCreatesKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.key")
+	provider := &KeyfileProvider{Path: path}
+
+	first, err := provider.Secret()
+	if err != nil {
+		t.Fatalf("Secret failed: %v", err)
+	}
+	if len(first) != 32 {
+		t.Errorf("Expected a 32 byte key, got %d bytes", len(first))
+	}
+
+	second, err := provider.Secret()
+	if err != nil {
+		t.Fatalf("Secret failed: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("Expected the key file to be reused")
+	}
+
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/abhi-praj/GoGram/internal/output"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
@@ -18,15 +20,54 @@ var DefaultConfig = map[string]interface{}{
 		"current_username": "",
 	},
 	"chat": map[string]interface{}{
-		"layout": "compact",
-		"colors": true,
+		"layout":           "compact",
+		"colors":           true,
+		"inline_images":    true,
+		"image_protocol":   "auto",
+		"image_max_width":  40,
+		"image_max_height": 20,
 	},
 	"scheduling": map[string]interface{}{
 		"default_schedule_duration": "01:00",
 	},
+	"broadcast": map[string]interface{}{
+		"confirm_above": 5,
+		"delay_seconds": 3,
+		"max_retries":   3,
+	},
+	"notifications": map[string]interface{}{
+		"mode":  "all",
+		"rules": []interface{}{},
+		"quiet_hours": map[string]interface{}{
+			"start":  "",
+			"end":    "",
+			"digest": true,
+		},
+		"chats": map[string]interface{}{},
+		"sinks": []interface{}{},
+		"terminal": map[string]interface{}{
+			"bell":  true,
+			"title": true,
+		},
+		"exec": map[string]interface{}{
+			"command": "",
+		},
+		"webhook": map[string]interface{}{
+			"url":     "",
+			"headers": map[string]interface{}{},
+		},
+	},
 	"privacy": map[string]interface{}{
 		"invisible_mode": false,
 	},
+	"media": map[string]interface{}{
+		"auto_download":            false,
+		"max_concurrent_downloads": 3,
+	},
+	"security": map[string]interface{}{
+		"encrypt_sessions": true,
+		"secret_provider":  "keyfile",
+	},
 	"advanced": map[string]interface{}{
 		"debug_mode":       false,
 		"georgist_credits": 627,
@@ -69,6 +110,9 @@ func (c *Config) initialize() {
 		advanced["media_dir"] = filepath.Join(c.configDir, "media")
 		advanced["generated_dir"] = filepath.Join(c.configDir, "generated")
 	}
+	if security, ok := DefaultConfig["security"].(map[string]interface{}); ok {
+		security["key_file"] = filepath.Join(c.configDir, "session.key")
+	}
 
 	c.loadConfig()
 }
@@ -138,6 +182,58 @@ func (c *Config) Get(key string, defaultValue interface{}) interface{} {
 	return current
 }
 
+// GetString retrieves a config value as a string
+func (c *Config) GetString(key, defaultValue string) string {
+	value := c.Get(key, defaultValue)
+	if value == nil {
+		return defaultValue
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// GetBool retrieves a config value as a bool, accepting the string form that
+// `config set` stores
+func (c *Config) GetBool(key string, defaultValue bool) bool {
+	switch value := c.Get(key, defaultValue).(type) {
+	case bool:
+		return value
+	case string:
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// GetInt retrieves a config value as an int, accepting the string form that
+// `config set` stores
+func (c *Config) GetInt(key string, defaultValue int) int {
+	switch value := c.Get(key, defaultValue).(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case string:
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// UnmarshalKey decodes a config section, such as a list of rules, into out
+func (c *Config) UnmarshalKey(key string, out interface{}) error {
+	if err := c.viper.UnmarshalKey(key, out); err != nil {
+		return fmt.Errorf("invalid %s config: %v", key, err)
+	}
+	return nil
+}
+
 // Set sets a configuration value by key
 func (c *Config) Set(key string, value interface{}) error {
 	keys := strings.Split(key, ".")
@@ -178,6 +274,15 @@ type KeyValue struct {
 	Value interface{}
 }
 
+// Record converts the pair into its output record
+func (kv KeyValue) Record() output.ConfigRecord {
+	value := ""
+	if kv.Value != nil {
+		value = fmt.Sprintf("%v", kv.Value)
+	}
+	return output.ConfigRecord{Key: kv.Key, Value: value}
+}
+
 // ideg flattenMap i just looked at GOrilla docs for this
 func (c *Config) flattenMap(prefix string, m map[string]interface{}, result *[]KeyValue) {
 	for k, v := range m {
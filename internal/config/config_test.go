@@ -81,3 +81,22 @@ func TestSetAndGet(t *testing.T) {
 	// Clean up
 	cfg.Set(testKey, nil)
 }
+
+func TestUnmarshalKey(t *testing.T) {
+	cfg := GetInstance()
+
+	var notifications struct {
+		Mode       string
+		Rules      []map[string]interface{}
+		QuietHours struct {
+			Start  string
+			Digest bool
+		} `mapstructure:"quiet_hours"`
+	}
+	if err := cfg.UnmarshalKey("notifications", &notifications); err != nil {
+		t.Fatalf("UnmarshalKey() failed: %v", err)
+	}
+	if notifications.Mode != "all" || len(notifications.Rules) != 0 || !notifications.QuietHours.Digest {
+		t.Errorf("Expected the default notifications config, got %+v", notifications)
+	}
+}
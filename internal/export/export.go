@@ -0,0 +1,313 @@
+// Package export writes chat history to JSON, Markdown, HTML and plain text
+// files for archiving
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatText     Format = "txt"
+)
+
+// Formats lists the supported formats in help order
+var Formats = []Format{FormatJSON, FormatMarkdown, FormatHTML, FormatText}
+
+// ParseFormat validates a format name, accepting "markdown", "text" and
+// "htm" as aliases
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "json":
+		return FormatJSON, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "html", "htm":
+		return FormatHTML, nil
+	case "txt", "text":
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("unknown export format: %s (expected json, md, html or txt)", name)
+}
+
+// Extension is the file extension for the format, including the dot
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Options control how an export is written
+type Options struct {
+	// Dir is the directory the export is written to. Local media paths are
+	// made relative to it when possible.
+	Dir string
+	// Generated is the export time shown in the header
+	Generated time.Time
+	// Since and Until describe the exported range; zero values are open ends
+	Since, Until time.Time
+}
+
+// Write renders messages, oldest first, in the given format
+func Write(w io.Writer, format Format, c *chat.Chat, messages []*chat.Message, opts Options) error {
+	if opts.Generated.IsZero() {
+		opts.Generated = time.Now()
+	}
+
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, c, messages, opts)
+	case FormatMarkdown:
+		return writeMarkdown(w, c, messages, opts)
+	case FormatHTML:
+		return writeHTML(w, c, messages, opts)
+	case FormatText:
+		return writeText(w, c, messages, opts)
+	}
+	return fmt.Errorf("unknown export format: %s", format)
+}
+
+// Document is the JSON form of an export
+type Document struct {
+	Chat      ChatInfo  `json:"chat"`
+	Generated time.Time `json:"generated"`
+	Since     string    `json:"since,omitempty"`
+	Until     string    `json:"until,omitempty"`
+	Messages  []Entry   `json:"messages"`
+}
+
+// ChatInfo describes the exported chat
+type ChatInfo struct {
+	ID           string   `json:"id"`
+	ThreadID     string   `json:"thread_id"`
+	Title        string   `json:"title"`
+	IsGroup      bool     `json:"is_group"`
+	Participants []string `json:"participants"`
+}
+
+// Entry is one exported message
+type Entry struct {
+	ID         string      `json:"id"`
+	Time       time.Time   `json:"time"`
+	Sender     string      `json:"sender"`
+	Username   string      `json:"username,omitempty"`
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ReplyTo    *Reply      `json:"reply_to,omitempty"`
+	Attachment *Attachment `json:"attachment,omitempty"`
+}
+
+// Reply is the message an exported message answers
+type Reply struct {
+	ID     string `json:"id"`
+	Sender string `json:"sender,omitempty"`
+	Text   string `json:"text,omitempty"`
+}
+
+// Attachment points at a message's media, online and on disk
+type Attachment struct {
+	Kind      string `json:"kind"`
+	URL       string `json:"url,omitempty"`
+	LocalPath string `json:"local_path,omitempty"`
+	Label     string `json:"label,omitempty"`
+}
+
+func chatInfo(c *chat.Chat) ChatInfo {
+	info := ChatInfo{ID: c.InternalID, ThreadID: c.ID, Title: c.Title, IsGroup: c.IsGroup}
+	for _, user := range c.Users {
+		info.Participants = append(info.Participants, user.Username)
+	}
+	return info
+}
+
+func newEntry(msg *chat.Message, opts Options) Entry {
+	entry := Entry{
+		ID:       msg.ID,
+		Time:     msg.Timestamp,
+		Sender:   msg.Sender,
+		Username: msg.Username,
+		Type:     msg.Type,
+		Text:     msg.Text,
+	}
+	if r := msg.ReplyTo; r != nil {
+		entry.ReplyTo = &Reply{ID: r.ID, Sender: r.Sender, Text: r.Text}
+	}
+	if a := msg.Attachment; a != nil {
+		entry.Attachment = &Attachment{
+			Kind:      a.Kind,
+			URL:       a.URL,
+			LocalPath: mediaPath(a.LocalPath, opts.Dir),
+			Label:     msg.AttachmentLabel(),
+		}
+	}
+	return entry
+}
+
+// mediaPath makes a downloaded file's path relative to the export directory
+// so the export and its media can be moved together
+func mediaPath(path, dir string) string {
+	if path == "" || dir == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func rangeLabel(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func writeJSON(w io.Writer, c *chat.Chat, messages []*chat.Message, opts Options) error {
+	doc := Document{
+		Chat:      chatInfo(c),
+		Generated: opts.Generated,
+		Since:     rangeLabel(opts.Since),
+		Until:     rangeLabel(opts.Until),
+		Messages:  make([]Entry, 0, len(messages)),
+	}
+	for _, msg := range messages {
+		doc.Messages = append(doc.Messages, newEntry(msg, opts))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// body is the message text with its media label in front
+func body(msg *chat.Message) string {
+	label := msg.AttachmentLabel()
+	switch {
+	case label == "":
+		return msg.Text
+	case msg.Text == "":
+		return label
+	default:
+		return label + " " + msg.Text
+	}
+}
+
+// sender prefers the display name, falling back to the username
+func sender(msg *chat.Message) string {
+	if msg.Sender != "" {
+		return msg.Sender
+	}
+	if msg.Username != "" {
+		return msg.Username
+	}
+	return "Unknown"
+}
+
+// period describes the exported range for headers
+func period(opts Options) string {
+	switch {
+	case opts.Since.IsZero() && opts.Until.IsZero():
+		return "full history"
+	case opts.Since.IsZero():
+		return "until " + opts.Until.Format("2006-01-02 15:04")
+	case opts.Until.IsZero():
+		return "since " + opts.Since.Format("2006-01-02 15:04")
+	default:
+		return opts.Since.Format("2006-01-02 15:04") + " to " + opts.Until.Format("2006-01-02 15:04")
+	}
+}
+
+func writeText(w io.Writer, c *chat.Chat, messages []*chat.Message, opts Options) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\nExported %s, %s, %d messages\n\n",
+		c.Title, opts.Generated.Format("2006-01-02 15:04"), period(opts), len(messages))
+
+	for _, msg := range messages {
+		if r := msg.ReplyTo; r != nil {
+			fmt.Fprintf(&b, "    > %s: %s\n", r.Sender, oneLine(r.Text))
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s\n", msg.Timestamp.Format("2006-01-02 15:04"), sender(msg), body(msg))
+		if a := msg.Attachment; a != nil && a.LocalPath != "" {
+			fmt.Fprintf(&b, "    file: %s\n", mediaPath(a.LocalPath, opts.Dir))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdown(w io.Writer, c *chat.Chat, messages []*chat.Message, opts Options) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n_Exported %s, %s, %d messages_\n",
+		c.Title, opts.Generated.Format("2006-01-02 15:04"), period(opts), len(messages))
+
+	day := ""
+	for _, msg := range messages {
+		if d := msg.Timestamp.Format("Monday, 2 January 2006"); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n", day)
+		}
+
+		fmt.Fprintf(&b, "\n**%s** (%s)\n", sender(msg), msg.Timestamp.Format("15:04"))
+		if r := msg.ReplyTo; r != nil {
+			fmt.Fprintf(&b, "> %s: %s\n\n", r.Sender, oneLine(r.Text))
+		}
+		if text := body(msg); text != "" {
+			b.WriteString(text + "\n")
+		}
+		if a := msg.Attachment; a != nil {
+			if link := mediaLink(a, opts); link != "" {
+				if a.Kind == chat.AttachmentPhoto && a.LocalPath != "" {
+					fmt.Fprintf(&b, "\n![%s](<%s>)\n", a.Kind, link)
+				} else {
+					fmt.Fprintf(&b, "\n[%s](<%s>)\n", a.Kind, link)
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mediaLink prefers the downloaded copy over the Instagram URL, which expires
+func mediaLink(a *chat.Attachment, opts Options) string {
+	if a.LocalPath != "" {
+		return mediaPath(a.LocalPath, opts.Dir)
+	}
+	return a.URL
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// FileName is the default export file name: the chat title made safe for
+// file systems, the export date and the format's extension
+func FileName(c *chat.Chat, format Format, now time.Time) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_', r == '.':
+			return r
+		case unicode.IsSpace(r):
+			return '-'
+		}
+		return -1
+	}, c.Title)
+	name = strings.Trim(name, ".-")
+	if name == "" {
+		name = "chat-" + c.InternalID
+	}
+	return name + "-" + now.Format("2006-01-02") + format.Extension()
+}
@@ -0,0 +1,184 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Davincible/goinsta/v3"
+	"github.com/abhi-praj/GoGram/internal/chat"
+)
+
+func testChat() *chat.Chat {
+	return &chat.Chat{
+		ID:         "340282366841710300949128",
+		InternalID: "3",
+		Title:      "Support: Ana & Bo",
+		Users:      []*goinsta.User{{Username: "ana"}, {Username: "bo"}},
+		IsGroup:    true,
+	}
+}
+
+func testMessages(photo string) []*chat.Message {
+	at := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	return []*chat.Message{
+		{ID: "1", Sender: "Ana", Username: "ana", Timestamp: at, Type: chat.MessageTypeText, Text: "Order <42> is late"},
+		{
+			ID: "2", Sender: "You", Timestamp: at.Add(5 * time.Minute), Type: chat.MessageTypeText, Text: "Sorry, checking now",
+			ReplyTo: &chat.ReplyContext{ID: "1", Sender: "Ana", Text: "Order <42>\nis late"},
+		},
+		{
+			ID: "3", Sender: "Ana", Username: "ana", Timestamp: at.Add(24 * time.Hour), Type: chat.MessageTypePhoto,
+			Attachment: &chat.Attachment{Kind: chat.AttachmentPhoto, URL: "https://cdn.example/p.jpg", Width: 640, Height: 480, LocalPath: photo},
+		},
+	}
+}
+
+func writePhoto(t *testing.T, path string) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	testCases := map[string]Format{"json": FormatJSON, "Markdown": FormatMarkdown, " html ": FormatHTML, "text": FormatText}
+	for name, expected := range testCases {
+		if f, err := ParseFormat(name); err != nil || f != expected {
+			t.Errorf("ParseFormat(%q) = %q, %v, expected %q", name, f, err, expected)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("expected an error for pdf")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	if name := FileName(testChat(), FormatHTML, now); name != "Support-Ana--Bo-2026-03-15.html" {
+		t.Errorf("unexpected file name %q", name)
+	}
+	if name := FileName(&chat.Chat{InternalID: "7", Title: "../"}, FormatText, now); name != "chat-7-2026-03-15.txt" {
+		t.Errorf("unexpected file name %q", name)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "media", "3.png")
+	opts := Options{Dir: dir, Generated: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, testChat(), testMessages(photo), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Chat.ID != "3" || len(doc.Chat.Participants) != 2 || len(doc.Messages) != 3 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+	if r := doc.Messages[1].ReplyTo; r == nil || r.ID != "1" || r.Sender != "Ana" {
+		t.Errorf("expected reply context, got %+v", r)
+	}
+	if a := doc.Messages[2].Attachment; a == nil || a.LocalPath != "media/3.png" || a.URL == "" {
+		t.Errorf("expected a relative media path, got %+v", a)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatText, testChat(), testMessages(""), Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"[2026-03-14 09:30] Ana: Order <42> is late\n",
+		"    > Ana: Order <42> is late\n[2026-03-14 09:35] You: Sorry, checking now\n",
+		"[2026-03-15 09:30] Ana: [photo 640x480]\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMarkdown, testChat(), testMessages(""), Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"# Support: Ana & Bo\n",
+		"## Saturday, 14 March 2026\n",
+		"## Sunday, 15 March 2026\n",
+		"**You** (09:35)\n> Ana: Order <42> is late\n",
+		"[photo](<https://cdn.example/p.jpg>)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "3.png")
+	writePhoto(t, photo)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatHTML, testChat(), testMessages(photo), Options{Dir: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Order &lt;42&gt; is late") {
+		t.Error("expected message text to be escaped")
+	}
+	if !strings.Contains(out, `<img src="data:image/jpeg;base64,`) {
+		t.Error("expected an inline thumbnail")
+	}
+	if strings.Contains(out, "3.png") {
+		t.Error("expected no reference to the photo file once it is inlined")
+	}
+	if !strings.Contains(out, `class="reply"`) {
+		t.Error("expected reply context")
+	}
+}
+
+func TestWriteHTMLMissingPhoto(t *testing.T) {
+	var buf bytes.Buffer
+	photo := filepath.Join(t.TempDir(), "gone.png")
+	if err := Write(&buf, FormatHTML, testChat(), testMessages(photo), Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `<a href="`+photo+`">`) {
+		t.Error("expected a link when the photo can't be inlined")
+	}
+}
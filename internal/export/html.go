@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"image/jpeg"
+	"io"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+	"github.com/abhi-praj/GoGram/internal/termimg"
+)
+
+// thumbnailSize is the longest side of inline photo thumbnails, in pixels
+const thumbnailSize = 320
+
+var page = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 760px; margin: 2em auto; padding: 0 1em; color: #222; }
+header p { color: #777; }
+h2 { font-size: 0.9em; color: #777; text-align: center; margin: 2em 0 1em; }
+.message { margin: 0.6em 0; }
+.meta { font-size: 0.85em; color: #555; }
+.meta time { color: #999; margin-left: 0.4em; }
+.text { white-space: pre-wrap; word-wrap: break-word; margin-top: 0.15em; }
+.reply { border-left: 3px solid #ccc; padding-left: 0.6em; color: #777; font-size: 0.9em; margin-top: 0.2em; }
+.media { color: #777; }
+img { display: block; max-width: 100%; border-radius: 8px; margin-top: 0.3em; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<p>Exported {{.Generated}}, {{.Period}}, {{len .Messages}} messages</p>
+</header>
+{{range .Messages}}{{if .Day}}<h2>{{.Day}}</h2>
+{{end}}<div class="message" id="m{{.ID}}">
+<div class="meta"><strong>{{.Sender}}</strong><time datetime="{{.Datetime}}">{{.Time}}</time></div>
+{{with .Reply}}<div class="reply"><strong>{{.Sender}}</strong>: {{.Text}}</div>
+{{end}}{{with .Label}}<div class="media">{{.}}</div>
+{{end}}{{with .Thumbnail}}<img src="{{.}}" alt="photo">
+{{end}}{{with .Link}}<div class="media"><a href="{{.}}">open media</a></div>
+{{end}}{{with .Text}}<div class="text">{{.}}</div>
+{{end}}</div>
+{{end}}</body>
+</html>
+`))
+
+type htmlPage struct {
+	Title     string
+	Generated string
+	Period    string
+	Messages  []htmlMessage
+}
+
+type htmlMessage struct {
+	ID        string
+	Day       string
+	Sender    string
+	Time      string
+	Datetime  string
+	Reply     *Reply
+	Label     string
+	Text      string
+	Thumbnail template.URL
+	Link      string
+}
+
+func writeHTML(w io.Writer, c *chat.Chat, messages []*chat.Message, opts Options) error {
+	data := htmlPage{
+		Title:     c.Title,
+		Generated: opts.Generated.Format("2006-01-02 15:04"),
+		Period:    period(opts),
+		Messages:  make([]htmlMessage, 0, len(messages)),
+	}
+
+	day := ""
+	for _, msg := range messages {
+		m := htmlMessage{
+			ID:       msg.ID,
+			Sender:   sender(msg),
+			Time:     msg.Timestamp.Format("15:04"),
+			Datetime: msg.Timestamp.Format(time.RFC3339),
+			Label:    msg.AttachmentLabel(),
+			Text:     msg.Text,
+		}
+		if d := msg.Timestamp.Format("Monday, 2 January 2006"); d != day {
+			day = d
+			m.Day = d
+		}
+		if r := msg.ReplyTo; r != nil {
+			m.Reply = &Reply{ID: r.ID, Sender: r.Sender, Text: oneLine(r.Text)}
+		}
+		if a := msg.Attachment; a != nil {
+			if a.Kind == chat.AttachmentPhoto && a.LocalPath != "" {
+				m.Thumbnail = thumbnail(a.LocalPath)
+			}
+			if m.Thumbnail == "" {
+				m.Link = mediaLink(a, opts)
+			}
+		}
+		data.Messages = append(data.Messages, m)
+	}
+
+	return page.Execute(w, data)
+}
+
+// thumbnail inlines a downloaded photo as a small JPEG data URI so the page
+// needs no files next to it. Unreadable images are left out.
+func thumbnail(path string) template.URL {
+	img, err := termimg.Load(path)
+	if err != nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, termimg.Thumbnail(img, thumbnailSize), &jpeg.Options{Quality: 75}); err != nil {
+		return ""
+	}
+	return template.URL("data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
@@ -2,9 +2,12 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -18,21 +21,41 @@ import (
 	"github.com/abhi-praj/GoGram/internal/chat"
 	"github.com/abhi-praj/GoGram/internal/client"
 	"github.com/abhi-praj/GoGram/internal/config"
+	"github.com/abhi-praj/GoGram/internal/history"
+	"github.com/abhi-praj/GoGram/internal/notify"
+	"github.com/abhi-praj/GoGram/internal/outbox"
+	"github.com/abhi-praj/GoGram/internal/schedule"
 	pb "github.com/abhi-praj/GoGram/proto/generated"
 )
 
+// maxUploadSize is the largest request the server accepts, which bounds
+// SendMedia uploads
+const maxUploadSize = 100 << 20
+
+// messenger is the part of chat.DirectMessages that sends for RPCs, so tests
+// can stand in for Instagram
+type messenger interface {
+	SendMedia(internalID, path string) error
+	BroadcastTargets(ids []string, label string) ([]*chat.Chat, error)
+	Broadcast(ctx context.Context, targets []*chat.Chat, text string, progress func(chat.BroadcastResult)) []chat.BroadcastResult
+}
+
 // Server implements the InstagramService gRPC server
 type Server struct {
 	pb.UnimplementedInstagramServiceServer
 	authInstance   *auth.InstagramAuth
 	clientInstance *client.ClientWrapper
 	dmInstance     *chat.DirectMessages
-	config         *config.Config
+	// messenger is dmInstance while logged in
+	messenger messenger
+	config    *config.Config
 
 	// Streaming connections
 	messageStreams map[string][]pb.InstagramService_StreamMessagesServer
 	notifStreams   []pb.InstagramService_StreamNotificationsServer
 	streamMutex    sync.RWMutex
+	// reactionWatchers stops the reaction poller for each streamed chat
+	reactionWatchers map[string]context.CancelFunc
 
 	// Server control
 	grpcServer *grpc.Server
@@ -42,10 +65,11 @@ type Server struct {
 // NewServer creates a new gRPC server instance
 func NewServer() *Server {
 	return &Server{
-		authInstance:   auth.NewInstagramAuth(),
-		config:         config.GetInstance(),
-		messageStreams: make(map[string][]pb.InstagramService_StreamMessagesServer),
-		notifStreams:   make([]pb.InstagramService_StreamNotificationsServer, 0),
+		authInstance:     auth.NewInstagramAuth(),
+		config:           config.GetInstance(),
+		messageStreams:   make(map[string][]pb.InstagramService_StreamMessagesServer),
+		notifStreams:     make([]pb.InstagramService_StreamNotificationsServer, 0),
+		reactionWatchers: make(map[string]context.CancelFunc),
 	}
 }
 
@@ -57,7 +81,7 @@ func (s *Server) Start(address string) error {
 	}
 
 	s.listener = lis
-	s.grpcServer = grpc.NewServer()
+	s.grpcServer = grpc.NewServer(grpc.MaxRecvMsgSize(maxUploadSize))
 	pb.RegisterInstagramServiceServer(s.grpcServer, s)
 
 	log.Printf("gRPC server starting on %s", address)
@@ -96,12 +120,31 @@ func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 	// Store successful login
 	s.clientInstance = clientWrapper
 	s.dmInstance = chat.NewDirectMessages(clientWrapper)
+	s.messenger = s.dmInstance
 
-	// Start notifications
+	// Start notifications, streaming them to StreamNotifications clients too
+	s.dmInstance.AddNotifier("grpc", notify.NotifierFunc(func(_ context.Context, n notify.Notification) error {
+		s.BroadcastNotification(n.ChatID, n.ChatTitle, n.Sender, n.Text, n.UnreadCount)
+		return nil
+	}))
 	if err := s.dmInstance.StartNotifications(); err != nil {
 		log.Printf("Warning: Could not start notifications: %v", err)
 	}
 
+	// Send scheduled messages while the server runs
+	if err := s.dmInstance.StartScheduler(func(msg *schedule.Message) {
+		log.Printf("%s", msg.Outcome())
+	}); err != nil {
+		log.Printf("Warning: Could not start scheduled messages: %v", err)
+	}
+
+	// Retry queued messages while the server runs
+	if err := s.dmInstance.StartOutbox(func(item *outbox.Item) {
+		log.Printf("%s", item.Outcome())
+	}); err != nil {
+		log.Printf("Warning: Could not start the outbox: %v", err)
+	}
+
 	return &pb.LoginResponse{
 		Success:  true,
 		Message:  "Login successful",
@@ -120,6 +163,8 @@ func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutR
 	// Stop notifications
 	if s.dmInstance != nil {
 		s.dmInstance.StopNotifications()
+		s.dmInstance.StopScheduler()
+		s.dmInstance.StopOutbox()
 	}
 
 	// Logout
@@ -132,6 +177,7 @@ func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutR
 
 	s.clientInstance = nil
 	s.dmInstance = nil
+	s.messenger = nil
 
 	return &pb.LogoutResponse{
 		Success: true,
@@ -147,8 +193,9 @@ func (s *Server) GetAuthStatus(ctx context.Context, req *emptypb.Empty) (*pb.Aut
 	}
 
 	response := &pb.AuthStatusResponse{
-		IsLoggedIn: true,
-		Username:   s.clientInstance.GetUsername(),
+		IsLoggedIn:    true,
+		Username:      s.clientInstance.GetUsername(),
+		InvisibleMode: client.InvisibleMode(),
 	}
 
 	if s.dmInstance != nil {
@@ -198,10 +245,21 @@ func (s *Server) GetMessages(ctx context.Context, req *pb.GetMessagesRequest) (*
 		return nil, status.Error(codes.Unauthenticated, "Not logged in")
 	}
 
-	messages, err := s.dmInstance.GetChatHistory(req.ChatId, int(req.Limit))
+	var messages []*chat.Message
+	var hasMore bool
+	var err error
+	if req.BeforeMessageId != "" {
+		messages, hasMore, err = s.dmInstance.GetChatHistoryBefore(req.ChatId, req.BeforeMessageId, int(req.Limit))
+	} else {
+		messages, err = s.dmInstance.GetChatHistory(req.ChatId, int(req.Limit))
+		hasMore = len(messages) == int(req.Limit) // Simple heuristic
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to get messages: %v", err)
 	}
+	if err := s.dmInstance.LoadReactions(req.ChatId, messages); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	// Convert to protobuf format
 	pbMessages := make([]*pb.Message, len(messages))
@@ -209,10 +267,15 @@ func (s *Server) GetMessages(ctx context.Context, req *pb.GetMessagesRequest) (*
 		pbMessages[i] = s.convertMessageToPB(msg)
 	}
 
-	return &pb.GetMessagesResponse{
+	resp := &pb.GetMessagesResponse{
 		Messages: pbMessages,
-		HasMore:  len(messages) == int(req.Limit), // Simple heuristic
-	}, nil
+		HasMore:  hasMore,
+	}
+	// Pass the oldest message ID back as before_message_id for the next page
+	if hasMore && len(messages) > 0 {
+		resp.NextCursor = messages[len(messages)-1].ID
+	}
+	return resp, nil
 }
 
 func (s *Server) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
@@ -234,6 +297,394 @@ func (s *Server) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*
 	}, nil
 }
 
+func (s *Server) SendMedia(ctx context.Context, req *pb.SendMediaRequest) (*pb.SendMediaResponse, error) {
+	if s.messenger == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+
+	// Only uploaded data is accepted: the server must not send files from
+	// its own filesystem on behalf of whoever can reach it
+	if len(req.Data) == 0 || req.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "data and filename are required")
+	}
+
+	// Keep the extension so the media type can still be detected
+	tmp, err := os.CreateTemp("", "gogram-*"+filepath.Ext(req.Filename))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to buffer media: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(req.Data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to buffer media: %v", err)
+	}
+	path := tmp.Name()
+
+	if err := s.messenger.SendMedia(req.ChatId, path); err != nil {
+		return &pb.SendMediaResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.SendMediaResponse{Success: true}, nil
+}
+
+func (s *Server) ReactToMessage(ctx context.Context, req *pb.ReactToMessageRequest) (*pb.ReactToMessageResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" || req.MessageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id and message_id are required")
+	}
+
+	var err error
+	if req.Remove {
+		err = s.dmInstance.Unreact(req.ChatId, req.MessageId)
+	} else {
+		err = s.dmInstance.React(req.ChatId, req.MessageId, req.Emoji)
+	}
+	if err != nil {
+		return &pb.ReactToMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.ReactToMessageResponse{Success: true}, nil
+}
+
+func (s *Server) ScheduleMessage(ctx context.Context, req *pb.ScheduleMessageRequest) (*pb.ScheduleMessageResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" || req.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id and text are required")
+	}
+
+	var sendAt time.Time
+	if req.SendAt != nil {
+		sendAt = req.SendAt.AsTime()
+		if !sendAt.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "send_at is in the past")
+		}
+	} else {
+		var err error
+		if sendAt, err = chat.ScheduleTime(req.When); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid when: %v", err)
+		}
+	}
+
+	msg, err := s.dmInstance.ScheduleMessage(req.ChatId, req.Text, sendAt)
+	if err != nil {
+		return &pb.ScheduleMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.ScheduleMessageResponse{
+		Success:   true,
+		Scheduled: s.convertScheduledToPB(msg),
+	}, nil
+}
+
+func (s *Server) ListScheduled(ctx context.Context, req *pb.ListScheduledRequest) (*pb.ListScheduledResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+
+	messages, err := s.dmInstance.ListScheduled(req.IncludeDone)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list scheduled messages: %v", err)
+	}
+
+	response := &pb.ListScheduledResponse{}
+	for _, msg := range messages {
+		response.Messages = append(response.Messages, s.convertScheduledToPB(msg))
+	}
+	return response, nil
+}
+
+func (s *Server) CancelScheduled(ctx context.Context, req *pb.CancelScheduledRequest) (*pb.CancelScheduledResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if _, err := s.dmInstance.CancelScheduled(req.Id); err != nil {
+		return &pb.CancelScheduledResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return &pb.CancelScheduledResponse{Success: true}, nil
+}
+
+func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	c, err := s.dmInstance.StartConversation(req.Username, req.Message)
+	var queued *chat.QueuedError
+	if err != nil && !errors.As(err, &queued) {
+		return &pb.StartConversationResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	resp := &pb.StartConversationResponse{
+		Success: true,
+		Chat:    s.convertChatToPB(c),
+	}
+	if queued != nil {
+		resp.Error = queued.Error()
+	}
+	return resp, nil
+}
+
+func (s *Server) Broadcast(req *pb.BroadcastRequest, stream pb.InstagramService_BroadcastServer) error {
+	if s.messenger == nil {
+		return status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.Message == "" {
+		return status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	chats, err := s.messenger.BroadcastTargets(req.ChatIds, req.Label)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Failed to resolve chats: %v", err)
+	}
+
+	// Stop sending if the client goes away, since nobody would see the results
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	done := 0
+	s.messenger.Broadcast(ctx, chats, req.Message, func(result chat.BroadcastResult) {
+		done++
+		progress := &pb.BroadcastProgress{
+			ChatId:    result.Chat.InternalID,
+			ChatTitle: result.Chat.Title,
+			Success:   result.Err == nil,
+			Attempts:  int32(result.Attempts),
+			Done:      int32(done),
+			Total:     int32(len(chats)),
+		}
+		if result.Err != nil {
+			progress.Error = result.Err.Error()
+		}
+		if err := stream.Send(progress); err != nil {
+			cancel()
+		}
+	})
+	return nil
+}
+
+func (s *Server) SearchMessages(ctx context.Context, req *pb.SearchMessagesRequest) (*pb.SearchMessagesResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+
+	q := history.Query{
+		Text:     req.Query,
+		ThreadID: req.ChatId,
+		Sender:   req.Sender,
+		HasMedia: req.HasMedia,
+		Limit:    int(req.Limit),
+	}
+	if req.Since != nil {
+		q.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		q.Until = req.Until.AsTime()
+	}
+
+	results, err := s.dmInstance.SearchMessages(q)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to search messages: %v", err)
+	}
+
+	pbResults := make([]*pb.SearchResult, len(results))
+	for i, r := range results {
+		pbResults[i] = &pb.SearchResult{
+			ChatId:    s.dmInstance.InternalIDForThread(r.Message.ThreadID),
+			ChatTitle: r.ThreadTitle,
+			MessageId: r.Message.ID,
+			Sender:    r.Message.Sender,
+			Timestamp: timestamppb.New(r.Message.Time),
+			Kind:      r.Message.Kind,
+			Text:      r.Message.Text,
+			Snippet:   r.Snippet,
+		}
+	}
+	return &pb.SearchMessagesResponse{Results: pbResults}, nil
+}
+
+func (s *Server) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if len(req.Usernames) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "at least two usernames are required")
+	}
+
+	group, err := s.dmInstance.CreateGroup(req.Usernames, req.Title)
+	if err != nil {
+		return &pb.CreateGroupResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return &pb.CreateGroupResponse{
+		Success: true,
+		Chat:    s.convertChatToPB(group),
+	}, nil
+}
+
+func (s *Server) RenameGroup(ctx context.Context, req *pb.RenameGroupRequest) (*pb.GroupActionResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" || req.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id and title are required")
+	}
+	return groupActionResponse(s.dmInstance.RenameGroup(req.ChatId, req.Title)), nil
+}
+
+func (s *Server) AddGroupMembers(ctx context.Context, req *pb.AddGroupMembersRequest) (*pb.GroupActionResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" || len(req.Usernames) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "chat_id and usernames are required")
+	}
+	return groupActionResponse(s.dmInstance.AddGroupMembers(req.ChatId, req.Usernames)), nil
+}
+
+func (s *Server) RemoveGroupMember(ctx context.Context, req *pb.RemoveGroupMemberRequest) (*pb.GroupActionResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" || req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id and username are required")
+	}
+	return groupActionResponse(s.dmInstance.RemoveGroupMember(req.ChatId, req.Username)), nil
+}
+
+func (s *Server) LeaveGroup(ctx context.Context, req *pb.LeaveGroupRequest) (*pb.GroupActionResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id is required")
+	}
+	return groupActionResponse(s.dmInstance.LeaveGroup(req.ChatId)), nil
+}
+
+func (s *Server) MuteChat(ctx context.Context, req *pb.MuteChatRequest) (*pb.GroupActionResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id is required")
+	}
+	return groupActionResponse(s.dmInstance.SetMuted(req.ChatId, req.Muted)), nil
+}
+
+// groupActionResponse reports the result of a group change
+func groupActionResponse(err error) *pb.GroupActionResponse {
+	if err != nil {
+		return &pb.GroupActionResponse{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	return &pb.GroupActionResponse{Success: true}
+}
+
+func (s *Server) ListMessageRequests(ctx context.Context, req *emptypb.Empty) (*pb.ListMessageRequestsResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+
+	requests, err := s.dmInstance.GetMessageRequests()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get message requests: %v", err)
+	}
+
+	pbRequests := make([]*pb.Chat, len(requests))
+	for i, request := range requests {
+		pbRequests[i] = s.convertChatToPB(request)
+	}
+	return &pb.ListMessageRequestsResponse{Requests: pbRequests}, nil
+}
+
+func (s *Server) PreviewMessageRequest(ctx context.Context, req *pb.PreviewMessageRequestRequest) (*pb.GetMessagesResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id is required")
+	}
+
+	messages, err := s.dmInstance.PreviewMessageRequest(req.ChatId, int(req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get messages: %v", err)
+	}
+
+	pbMessages := make([]*pb.Message, len(messages))
+	for i, msg := range messages {
+		pbMessages[i] = s.convertMessageToPB(msg)
+	}
+	return &pb.GetMessagesResponse{Messages: pbMessages}, nil
+}
+
+func (s *Server) RespondToMessageRequest(ctx context.Context, req *pb.RespondToMessageRequestRequest) (*pb.RespondToMessageRequestResponse, error) {
+	if s.dmInstance == nil {
+		return nil, status.Error(codes.Unauthenticated, "Not logged in")
+	}
+	if req.ChatId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chat_id is required")
+	}
+
+	var accepted *chat.Chat
+	var err error
+	switch req.Action {
+	case pb.MessageRequestAction_ACCEPT:
+		accepted, err = s.dmInstance.AcceptMessageRequest(req.ChatId)
+	case pb.MessageRequestAction_DECLINE:
+		err = s.dmInstance.DeclineMessageRequest(req.ChatId)
+	case pb.MessageRequestAction_BLOCK:
+		err = s.dmInstance.BlockMessageRequest(req.ChatId)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown action %v", req.Action)
+	}
+	if err != nil {
+		return &pb.RespondToMessageRequestResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	resp := &pb.RespondToMessageRequestResponse{Success: true}
+	if accepted != nil {
+		resp.Chat = s.convertChatToPB(accepted)
+	}
+	return resp, nil
+}
+
 func (s *Server) StartInteractiveChat(ctx context.Context, req *pb.StartInteractiveChatRequest) (*pb.StartInteractiveChatResponse, error) {
 	if s.dmInstance == nil {
 		return nil, status.Error(codes.Unauthenticated, "Not logged in")
@@ -266,6 +717,11 @@ func (s *Server) StreamMessages(req *pb.StreamMessagesRequest, stream pb.Instagr
 		s.messageStreams[req.ChatId] = make([]pb.InstagramService_StreamMessagesServer, 0)
 	}
 	s.messageStreams[req.ChatId] = append(s.messageStreams[req.ChatId], stream)
+	if s.reactionWatchers[req.ChatId] == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.reactionWatchers[req.ChatId] = cancel
+		go s.watchReactions(ctx, req.ChatId)
+	}
 	s.streamMutex.Unlock()
 
 	// Keep the stream alive
@@ -280,11 +736,45 @@ func (s *Server) StreamMessages(req *pb.StreamMessagesRequest, stream pb.Instagr
 			break
 		}
 	}
+	if len(s.messageStreams[req.ChatId]) == 0 {
+		if cancel := s.reactionWatchers[req.ChatId]; cancel != nil {
+			cancel()
+			delete(s.reactionWatchers, req.ChatId)
+		}
+	}
 	s.streamMutex.Unlock()
 
 	return nil
 }
 
+// reactionPollInterval is how often streamed chats are checked for reaction changes
+const reactionPollInterval = 10 * time.Second
+
+// watchReactions polls a chat's recent messages and sends MESSAGE_UPDATED
+// whenever a reaction is added or removed
+func (s *Server) watchReactions(ctx context.Context, chatID string) {
+	tracker := chat.NewReactionTracker()
+	ticker := time.NewTicker(reactionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.dmInstance != nil {
+			messages, err := s.dmInstance.GetChatHistory(chatID, 20)
+			if err == nil && s.dmInstance.LoadReactions(chatID, messages) == nil {
+				for _, msg := range tracker.Changed(messages) {
+					s.BroadcastMessageUpdate(chatID, msg, pb.MessageUpdateType_MESSAGE_UPDATED)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (s *Server) StreamNotifications(req *emptypb.Empty, stream pb.InstagramService_StreamNotificationsServer) error {
 	if s.dmInstance == nil {
 		return status.Error(codes.Unauthenticated, "Not logged in")
@@ -363,12 +853,18 @@ func (s *Server) ListConfig(ctx context.Context, req *emptypb.Empty) (*pb.ListCo
 
 func (s *Server) convertChatToPB(chat *chat.Chat) *pb.Chat {
 	pbChat := &pb.Chat{
-		Id:          chat.ID,
-		InternalId:  chat.InternalID,
-		Title:       chat.Title,
-		LastMessage: chat.LastMessage,
-		UnreadCount: int32(chat.UnreadCount),
-		IsGroup:     chat.IsGroup,
+		Id:           chat.ID,
+		InternalId:   chat.InternalID,
+		Title:        chat.Title,
+		LastMessage:  chat.LastMessage,
+		UnreadCount:  int32(chat.UnreadCount),
+		IsGroup:      chat.IsGroup,
+		Muted:        chat.Muted,
+		Pending:      chat.Pending,
+		Pinned:       chat.Pinned,
+		MutedLocally: chat.MutedLocally,
+		Archived:     chat.Archived,
+		Labels:       chat.Labels,
 	}
 
 	if !chat.LastActivity.IsZero() {
@@ -390,12 +886,25 @@ func (s *Server) convertChatToPB(chat *chat.Chat) *pb.Chat {
 	return pbChat
 }
 
+func (s *Server) convertScheduledToPB(msg *schedule.Message) *pb.ScheduledMessage {
+	return &pb.ScheduledMessage{
+		Id:        msg.ID,
+		ChatId:    s.dmInstance.InternalIDForThread(msg.ChatID),
+		ChatTitle: msg.ChatTitle,
+		Text:      msg.Text,
+		SendAt:    timestamppb.New(msg.SendAt),
+		Status:    string(msg.Status),
+		Error:     msg.Error,
+	}
+}
+
 func (s *Server) convertMessageToPB(msg *chat.Message) *pb.Message {
 	pbMsg := &pb.Message{
 		Id:     msg.ID,
 		Text:   msg.Text,
 		Sender: msg.Sender,
 		Type:   pb.MessageType_TEXT, // Default to text
+		Kind:   msg.Type,
 	}
 
 	if !msg.Timestamp.IsZero() {
@@ -403,15 +912,42 @@ func (s *Server) convertMessageToPB(msg *chat.Message) *pb.Message {
 	}
 
 	// Map message types
-	switch msg.Type {
-	case "text":
-		pbMsg.Type = pb.MessageType_TEXT
-	case "media":
+	switch {
+	case chat.IsMediaType(msg.Type):
 		pbMsg.Type = pb.MessageType_MEDIA
-	case "system":
+	case msg.Type == chat.MessageTypeSystem:
 		pbMsg.Type = pb.MessageType_SYSTEM
 	}
 
+	for _, r := range msg.Reactions {
+		reaction := &pb.Reaction{
+			Emoji:    r.Emoji,
+			SenderId: r.SenderID,
+			Sender:   r.Sender,
+		}
+		if !r.Timestamp.IsZero() {
+			reaction.Timestamp = timestamppb.New(r.Timestamp)
+		}
+		pbMsg.Reactions = append(pbMsg.Reactions, reaction)
+	}
+
+	if a := msg.Attachment; a != nil {
+		pbMsg.Attachment = &pb.Attachment{
+			Kind:       a.Kind,
+			Url:        a.URL,
+			PreviewUrl: a.PreviewURL,
+			Width:      int32(a.Width),
+			Height:     int32(a.Height),
+			DurationMs: a.Duration.Milliseconds(),
+			MediaId:    a.MediaID,
+			Code:       a.Code,
+			Owner:      a.Owner,
+			Caption:    a.Caption,
+			Title:      a.Title,
+			LocalPath:  a.LocalPath,
+		}
+	}
+
 	return pbMsg
 }
 
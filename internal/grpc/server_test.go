@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/abhi-praj/GoGram/internal/chat"
+	pb "github.com/abhi-praj/GoGram/proto/generated"
+)
+
+// stubMessenger records what the server asks it to send
+type stubMessenger struct {
+	chatID string
+	path   string
+	data   []byte
+	err    error
+
+	// chats can be broadcast to; failing ones report their error
+	chats     []*chat.Chat
+	failing   map[string]error
+	ids       []string
+	label     string
+	broadcast string
+}
+
+func (m *stubMessenger) SendMedia(internalID, path string) error {
+	m.chatID, m.path = internalID, path
+	m.data, _ = os.ReadFile(path)
+	return m.err
+}
+
+func (m *stubMessenger) BroadcastTargets(ids []string, label string) ([]*chat.Chat, error) {
+	m.ids, m.label = ids, label
+	if len(ids) == 0 && label == "" {
+		return nil, errors.New("no chats given")
+	}
+	return m.chats, nil
+}
+
+func (m *stubMessenger) Broadcast(ctx context.Context, targets []*chat.Chat, text string, progress func(chat.BroadcastResult)) []chat.BroadcastResult {
+	m.broadcast = text
+	var results []chat.BroadcastResult
+	for _, target := range targets {
+		result := chat.BroadcastResult{Chat: target, Attempts: 1, Err: m.failing[target.InternalID]}
+		results = append(results, result)
+		progress(result)
+	}
+	return results
+}
+
+// newTestClient serves s over an in-memory connection and returns a client for it
+func newTestClient(t *testing.T, s *Server) pb.InstagramServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(maxUploadSize))
+	pb.RegisterInstagramServiceServer(server, s)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewInstagramServiceClient(conn)
+}
+
+func TestSendMedia(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := newTestClient(t, &Server{}).SendMedia(ctx, &pb.SendMediaRequest{ChatId: "100003", Data: []byte("jpeg"), Filename: "a.jpg"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated before login, got %v", err)
+	}
+
+	m := &stubMessenger{}
+	c := newTestClient(t, &Server{messenger: m})
+
+	invalid := []*pb.SendMediaRequest{
+		{ChatId: "100003", Filename: "a.jpg"},
+		{ChatId: "100003", Data: []byte("jpeg")},
+	}
+	for _, req := range invalid {
+		if _, err := c.SendMedia(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+	if m.chatID != "" {
+		t.Errorf("Expected invalid requests not to send, got a send to %s", m.chatID)
+	}
+
+	resp, err := c.SendMedia(ctx, &pb.SendMediaRequest{ChatId: "100003", Data: []byte("jpeg"), Filename: "../photo.JPG"})
+	if err != nil || !resp.Success {
+		t.Fatalf("SendMedia failed: %v %v", resp, err)
+	}
+	if m.chatID != "100003" || filepath.Ext(m.path) != ".JPG" || string(m.data) != "jpeg" {
+		t.Errorf("Expected the upload to be sent as a .JPG file, got %s %q %q", m.chatID, m.path, m.data)
+	}
+	if _, err := os.Stat(m.path); !os.IsNotExist(err) {
+		t.Errorf("Expected the buffered upload %s to be removed", m.path)
+	}
+
+	m.err = errors.New("upload rejected")
+	resp, err = c.SendMedia(ctx, &pb.SendMediaRequest{ChatId: "100003", Data: []byte("jpeg"), Filename: "a.jpg"})
+	if err != nil || resp.Success || resp.Error != "upload rejected" {
+		t.Errorf("Expected the send error in the response, got %v %v", resp, err)
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	ctx := context.Background()
+
+	// Errors from a server-streaming call arrive on the first Recv
+	recvErr := func(c pb.InstagramServiceClient, req *pb.BroadcastRequest) error {
+		stream, err := c.Broadcast(ctx, req)
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	if err := recvErr(newTestClient(t, &Server{}), &pb.BroadcastRequest{ChatIds: []string{"100003"}, Message: "hi"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated before login, got %v", err)
+	}
+
+	m := &stubMessenger{
+		chats: []*chat.Chat{
+			{InternalID: "100003", Title: "Alice"},
+			{InternalID: "100004", Title: "Bob"},
+			{InternalID: "100005", Title: "Carol"},
+		},
+		failing: map[string]error{"100004": errors.New("blocked")},
+	}
+	c := newTestClient(t, &Server{messenger: m})
+
+	invalid := []*pb.BroadcastRequest{
+		{ChatIds: []string{"100003"}},
+		{Message: "hi"},
+	}
+	for _, req := range invalid {
+		if err := recvErr(c, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+	if m.broadcast != "" {
+		t.Errorf("Expected invalid requests not to send, got %q", m.broadcast)
+	}
+
+	stream, err := c.Broadcast(ctx, &pb.BroadcastRequest{Label: "friends", Message: "party at 8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var updates []*pb.BroadcastProgress
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		updates = append(updates, progress)
+	}
+
+	if m.label != "friends" || m.broadcast != "party at 8" {
+		t.Errorf("Expected label friends and the message to be passed on, got %q %q", m.label, m.broadcast)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("Expected one update per chat, got %d", len(updates))
+	}
+	for i, update := range updates {
+		target := m.chats[i]
+		failed := target.InternalID == "100004"
+		if update.ChatId != target.InternalID || update.ChatTitle != target.Title || update.Done != int32(i+1) || update.Total != 3 {
+			t.Errorf("Unexpected update %d: %v", i, update)
+		}
+		if update.Success == failed || (failed && update.Error != "blocked") {
+			t.Errorf("Expected update %d to report success %v, got %v", i, !failed, update)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+// Message is a message kept in the local history
+type Message struct {
+	ID       string    `json:"id"`
+	ThreadID string    `json:"thread_id"`
+	Sender   string    `json:"sender"`
+	Username string    `json:"username,omitempty"`
+	Text     string    `json:"text"`
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	// Caption is the caption or title of shared media, searched along with Text
+	Caption  string `json:"caption,omitempty"`
+	HasMedia bool   `json:"has_media,omitempty"`
+}
+
+// ImportedPrefix starts the IDs of messages imported from an Instagram data
+// download, which don't carry the message IDs the API uses
+const ImportedPrefix = "archive:"
+
+// Imported reports whether the message came from a data download
+func (m Message) Imported() bool {
+	return strings.HasPrefix(m.ID, ImportedPrefix)
+}
+
+// fingerprint identifies a message across sources. Data downloads only keep
+// timestamps to the millisecond, so times are compared to the second. The
+// sender and kind keep apart messages without text, such as a photo and a
+// like sent in the same second.
+func (m Message) fingerprint() string {
+	return fmt.Sprintf("%d\x00%s\x00%s\x00%s", m.Time.Unix(), m.Sender, m.Kind, m.Text)
+}
+
+// Thread is everything stored for one chat, oldest message first
+type Thread struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Messages []Message `json:"messages"`
+}
+
+// Store keeps message history as one JSON file per thread, so it grows past
+// what the API returns and can be searched offline
+type Store struct {
+	dir   string
+	mutex sync.Mutex
+	index *Index // built on the first search, then kept up to date
+}
+
+// NewStore creates a store that keeps its files in dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Add merges messages into a thread's history, skipping ones already stored,
+// and returns how many were new. An empty title keeps the stored one.
+func (s *Store) Add(threadID, title string, messages []Message) (int, error) {
+	if threadID == "" {
+		return 0, fmt.Errorf("thread ID is required")
+	}
+
+	path := s.threadPath(threadID)
+	unlock, err := storage.Lock(path)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	thread, err := readThread(path)
+	if err != nil {
+		return 0, err
+	}
+	thread.ID = threadID
+	if title != "" {
+		thread.Title = title
+	}
+
+	known := make(map[string]bool, len(thread.Messages))
+	// Imported messages have made-up IDs, so they're matched against
+	// messages from the API by their fingerprint instead
+	seen := map[bool]map[string]bool{false: {}, true: {}}
+	for _, msg := range thread.Messages {
+		known[msg.ID] = true
+		seen[msg.Imported()][msg.fingerprint()] = true
+	}
+
+	var added []Message
+	for _, msg := range messages {
+		if msg.ID == "" || known[msg.ID] || seen[!msg.Imported()][msg.fingerprint()] {
+			continue
+		}
+		known[msg.ID] = true
+		seen[msg.Imported()][msg.fingerprint()] = true
+		msg.ThreadID = threadID
+		added = append(added, msg)
+	}
+	if len(added) == 0 && title == "" {
+		return 0, nil
+	}
+
+	thread.Messages = append(thread.Messages, added...)
+	sort.SliceStable(thread.Messages, func(i, j int) bool {
+		return thread.Messages[i].Time.Before(thread.Messages[j].Time)
+	})
+	if err := storage.WriteJSON(path, thread); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	if s.index != nil {
+		s.index.setTitle(threadID, thread.Title)
+		s.index.add(added)
+	}
+	s.mutex.Unlock()
+	return len(added), nil
+}
+
+// Thread loads one thread's history. A thread with nothing stored comes back
+// empty rather than as an error.
+func (s *Store) Thread(threadID string) (*Thread, error) {
+	thread, err := readThread(s.threadPath(threadID))
+	if err != nil {
+		return nil, err
+	}
+	thread.ID = threadID
+	return thread, nil
+}
+
+// Threads loads every stored thread
+func (s *Store) Threads() ([]*Thread, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]*Thread, 0, len(paths))
+	for _, path := range paths {
+		thread, err := readThread(path)
+		if err != nil {
+			return nil, err
+		}
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// Search finds stored messages matching q, newest first
+func (s *Store) Search(q Query) ([]Result, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.index == nil {
+		threads, err := s.Threads()
+		if err != nil {
+			return nil, err
+		}
+		s.index = NewIndex(threads)
+	}
+	return s.index.Search(q), nil
+}
+
+func (s *Store) threadPath(threadID string) string {
+	// Thread IDs are numeric, but keep anything odd from escaping the directory
+	name := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(threadID)
+	return filepath.Join(s.dir, name+".json")
+}
+
+func readThread(path string) (*Thread, error) {
+	thread := &Thread{}
+	if err := storage.ReadJSON(path, thread); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return thread, nil
+}
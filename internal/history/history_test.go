@@ -0,0 +1,172 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+var base = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func sample() []Message {
+	return []Message{
+		{ID: "1", Sender: "Alice", Username: "alice", Text: "Lunch tomorrow?", Time: base},
+		{ID: "2", Sender: "You", Text: "Sure, the usual place", Time: base.Add(time.Minute)},
+		{ID: "3", Sender: "Alice", Username: "alice", Kind: "photo", Caption: "Lunch menu", HasMedia: true, Time: base.Add(2 * time.Minute)},
+	}
+}
+
+func TestStoreAddSkipsDuplicates(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	added, err := store.Add("t1", "Alice", sample())
+	if err != nil || added != 3 {
+		t.Fatalf("Expected 3 new messages, got %d, %v", added, err)
+	}
+
+	// Overlapping pages come back from the API all the time
+	more := append(sample()[1:], Message{ID: "0", Text: "Hi", Time: base.Add(-time.Hour)})
+	added, err = store.Add("t1", "", more)
+	if err != nil || added != 1 {
+		t.Fatalf("Expected 1 new message, got %d, %v", added, err)
+	}
+
+	thread, err := store.Thread("t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thread.Title != "Alice" || len(thread.Messages) != 4 || thread.Messages[0].ID != "0" {
+		t.Errorf("Unexpected thread %+v", thread)
+	}
+	if thread.Messages[1].ThreadID != "t1" {
+		t.Errorf("Expected messages to carry their thread ID, got %q", thread.Messages[1].ThreadID)
+	}
+}
+
+func TestStoreAddMatchesImportedMessages(t *testing.T) {
+	store := NewStore(t.TempDir())
+	store.Add("t1", "Alice", sample())
+
+	imported := []Message{
+		// The same message as ID 1, as a data download keeps it
+		{ID: ImportedPrefix + "t1:1", Sender: "Alice", Text: "Lunch tomorrow?", Time: base.Add(400 * time.Millisecond)},
+		{ID: ImportedPrefix + "t1:2", Sender: "Alice", Text: "ok", Time: base.Add(-time.Hour)},
+		{ID: ImportedPrefix + "t1:3", Sender: "Alice", Text: "ok", Time: base.Add(-time.Hour)},
+	}
+	added, err := store.Add("t1", "", imported)
+	if err != nil || added != 2 {
+		t.Fatalf("Expected 2 new messages, got %d, %v", added, err)
+	}
+
+	// Importing again adds nothing, and the API doesn't bring back what was
+	// imported first
+	if added, _ := store.Add("t1", "", imported); added != 0 {
+		t.Errorf("Expected a second import to add nothing, got %d", added)
+	}
+	if added, _ := store.Add("t1", "", []Message{{ID: "9", Sender: "Alice", Text: "ok", Time: base.Add(-time.Hour)}}); added != 0 {
+		t.Errorf("Expected the API copy of an imported message to be skipped, got %d", added)
+	}
+}
+
+func TestStoreAddKeepsDistinctMessagesWithoutText(t *testing.T) {
+	store := NewStore(t.TempDir())
+	live := []Message{
+		{ID: "1", Sender: "Alice", Kind: "photo", HasMedia: true, Time: base},
+		{ID: "2", Sender: "Alice", Kind: "like", Time: base.Add(200 * time.Millisecond)},
+		{ID: "3", Sender: "You", Kind: "photo", HasMedia: true, Time: base.Add(500 * time.Millisecond)},
+	}
+	if added, err := store.Add("t1", "Alice", live); err != nil || added != 3 {
+		t.Fatalf("Expected 3 new messages, got %d, %v", added, err)
+	}
+
+	imported := []Message{
+		// The same photo as ID 1
+		{ID: ImportedPrefix + "t1:1", Sender: "Alice", Kind: "photo", HasMedia: true, Time: base.Add(100 * time.Millisecond)},
+		// A video from the same second, which only the download has
+		{ID: ImportedPrefix + "t1:2", Sender: "Alice", Kind: "video", HasMedia: true, Time: base.Add(300 * time.Millisecond)},
+		// A photo from Bob in the same second
+		{ID: ImportedPrefix + "t1:3", Sender: "Bob", Kind: "photo", HasMedia: true, Time: base.Add(600 * time.Millisecond)},
+	}
+	added, err := store.Add("t1", "", imported)
+	if err != nil || added != 2 {
+		t.Fatalf("Expected 2 new messages, got %d, %v", added, err)
+	}
+
+	thread, err := store.Thread("t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range thread.Messages {
+		if msg.ID == ImportedPrefix+"t1:1" {
+			t.Errorf("Expected the imported copy of photo 1 to be skipped")
+		}
+	}
+}
+
+func TestStoreSearch(t *testing.T) {
+	store := NewStore(t.TempDir())
+	store.Add("t1", "Alice", sample())
+	store.Add("t2", "Bob", []Message{{ID: "b1", Sender: "Bob", Username: "bob", Text: "Lunchbox for sale", Time: base}})
+
+	results, err := store.Search(Query{Text: "lunch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].Message.ID != "3" {
+		t.Fatalf("Expected 3 results, newest first, got %+v", results)
+	}
+
+	// Messages added after the index is built are found too
+	store.Add("t2", "", []Message{{ID: "b2", Sender: "Bob", Text: "lunch is on me", Time: base.Add(time.Hour)}})
+	results, _ = store.Search(Query{Text: "lunch", ThreadID: "t2"})
+	if len(results) != 2 || results[0].Message.ID != "b2" || results[0].ThreadTitle != "Bob" {
+		t.Errorf("Expected Bob's two messages, got %+v", results)
+	}
+}
+
+func TestIndexFilters(t *testing.T) {
+	idx := NewIndex([]*Thread{{ID: "t1", Title: "Alice", Messages: sample()}})
+
+	tests := []struct {
+		name     string
+		query    Query
+		expected []string
+	}{
+		{"all words must match", Query{Text: "usual place"}, []string{"2"}},
+		{"word prefixes", Query{Text: "tomor"}, []string{"1"}},
+		{"not inside words", Query{Text: "unch"}, nil},
+		{"caption", Query{Text: "menu"}, []string{"3"}},
+		{"sender username", Query{Text: "lunch", Sender: "ALI"}, []string{"3", "1"}},
+		{"has media", Query{HasMedia: true}, []string{"3"}},
+		{"date range", Query{Since: base.Add(time.Minute), Until: base.Add(2 * time.Minute)}, []string{"2"}},
+		{"limit", Query{Limit: 1}, []string{"3"}},
+	}
+
+	for _, tc := range tests {
+		var ids []string
+		for _, result := range idx.Search(tc.query) {
+			ids = append(ids, result.Message.ID)
+		}
+		if len(ids) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, ids)
+				break
+			}
+		}
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	text := "We talked about a lot of things before we finally got round to the budget for next year's offsite"
+	snippet := Snippet(text, []string{"budget"})
+	if snippet != "…before we finally got round to the budget for next year's offsite" {
+		t.Errorf("Unexpected snippet %q", snippet)
+	}
+
+	if got := Snippet("short one", []string{"one"}); got != "short one" {
+		t.Errorf("Expected short text to be kept whole, got %q", got)
+	}
+}
@@ -0,0 +1,255 @@
+package history
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+)
+
+// snippetRadius is how many characters of context a snippet shows on each
+// side of the first match
+const snippetRadius = 40
+
+// Query is a full-text search with optional filters. Every word in Text has
+// to match the start of a word in the message; an empty Text matches all
+// messages that pass the filters.
+type Query struct {
+	Text     string
+	ThreadID string
+	// Sender matches part of the sender's name or username
+	Sender   string
+	Since    time.Time
+	Until    time.Time
+	HasMedia bool
+	Limit    int
+}
+
+// Result is a message that matched a search
+type Result struct {
+	Message     Message
+	ThreadTitle string
+	// Snippet is the text around the first match
+	Snippet string
+}
+
+// Record converts the result into its output record. chatID is the chat's
+// internal ID.
+func (r Result) Record(chatID string) output.SearchResultRecord {
+	return output.SearchResultRecord{
+		ChatID:    chatID,
+		ChatTitle: r.ThreadTitle,
+		MessageID: r.Message.ID,
+		Sender:    r.Message.Sender,
+		Timestamp: output.TimePtr(r.Message.Time),
+		Kind:      r.Message.Kind,
+		Text:      r.Message.Text,
+		Snippet:   r.Snippet,
+	}
+}
+
+// Index is an inverted index from words to the messages containing them
+type Index struct {
+	messages []Message
+	titles   map[string]string
+	postings map[string][]int // word -> message positions, ascending
+	words    []string         // sorted keys of postings, for prefix lookups
+}
+
+// NewIndex indexes the messages of threads
+func NewIndex(threads []*Thread) *Index {
+	idx := &Index{
+		titles:   make(map[string]string),
+		postings: make(map[string][]int),
+	}
+	for _, thread := range threads {
+		idx.titles[thread.ID] = thread.Title
+		idx.add(thread.Messages)
+	}
+	return idx
+}
+
+func (idx *Index) setTitle(threadID, title string) {
+	idx.titles[threadID] = title
+}
+
+func (idx *Index) add(messages []Message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	for _, msg := range messages {
+		pos := len(idx.messages)
+		idx.messages = append(idx.messages, msg)
+
+		seen := make(map[string]bool)
+		for _, word := range Tokenize(msg.Text + " " + msg.Caption) {
+			if seen[word] {
+				continue
+			}
+			seen[word] = true
+			idx.postings[word] = append(idx.postings[word], pos)
+		}
+	}
+
+	idx.words = idx.words[:0]
+	for word := range idx.postings {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+}
+
+// Search returns the messages matching q, newest first
+func (idx *Index) Search(q Query) []Result {
+	terms := Tokenize(q.Text)
+
+	var candidates []int
+	if len(terms) == 0 {
+		candidates = make([]int, len(idx.messages))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	} else {
+		candidates = idx.lookup(terms[0])
+		for _, term := range terms[1:] {
+			candidates = intersect(candidates, idx.lookup(term))
+		}
+	}
+
+	sender := strings.ToLower(q.Sender)
+	var results []Result
+	for _, pos := range candidates {
+		msg := idx.messages[pos]
+		switch {
+		case q.ThreadID != "" && msg.ThreadID != q.ThreadID,
+			sender != "" && !strings.Contains(strings.ToLower(msg.Sender), sender) && !strings.Contains(strings.ToLower(msg.Username), sender),
+			!q.Since.IsZero() && msg.Time.Before(q.Since),
+			!q.Until.IsZero() && !msg.Time.Before(q.Until),
+			q.HasMedia && !msg.HasMedia:
+			continue
+		}
+
+		results = append(results, Result{
+			Message:     msg,
+			ThreadTitle: idx.titles[msg.ThreadID],
+			Snippet:     Snippet(msg.Text+" "+msg.Caption, terms),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Message.Time.After(results[j].Message.Time)
+	})
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// lookup returns the positions of messages with a word starting with prefix
+func (idx *Index) lookup(prefix string) []int {
+	start := sort.SearchStrings(idx.words, prefix)
+
+	var positions []int
+	for _, word := range idx.words[start:] {
+		if !strings.HasPrefix(word, prefix) {
+			break
+		}
+		positions = union(positions, idx.postings[word])
+	}
+	return positions
+}
+
+// Tokenize splits text into lowercase words
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Snippet cuts text down to the part around the first word matching one of
+// terms, marking cuts with "…"
+func Snippet(text string, terms []string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+
+	match := -1
+	for i := range lower {
+		// Only count matches at the start of a word
+		if i > 0 && (unicode.IsLetter(lower[i-1]) || unicode.IsNumber(lower[i-1])) {
+			continue
+		}
+		for _, term := range terms {
+			if strings.HasPrefix(string(lower[i:]), term) {
+				match = i
+				break
+			}
+		}
+		if match >= 0 {
+			break
+		}
+	}
+	if match < 0 {
+		match = 0
+	}
+
+	// Cut at spaces so the snippet doesn't start or end halfway through a word
+	start := max(match-snippetRadius, 0)
+	for start > 0 && start < match && runes[start-1] != ' ' {
+		start++
+	}
+	end := min(match+snippetRadius, len(runes))
+	for end < len(runes) && end > match && runes[end] != ' ' {
+		end--
+	}
+	snippet := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// union merges two ascending position lists
+func union(a, b []int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			merged = append(merged, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			merged = append(merged, b[j])
+			j++
+		default:
+			merged = append(merged, a[i])
+			i++
+			j++
+		}
+	}
+	return merged
+}
+
+// intersect keeps the positions found in both ascending lists
+func intersect(a, b []int) []int {
+	var common []int
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case b[j] < a[i]:
+			j++
+		default:
+			common = append(common, a[i])
+			i++
+			j++
+		}
+	}
+	return common
+}
@@ -0,0 +1,657 @@
+package media
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+const (
+	indexFile             = "index.json"
+	partSuffix            = ".part"
+	validatorSuffix       = ".validator" // after partSuffix, holds the ETag or Last-Modified
+	defaultMaxConcurrency = 3
+)
+
+// Auto-download policies for a chat
+const (
+	PolicyDefault = "default"
+	PolicyAlways  = "on"
+	PolicyNever   = "off"
+)
+
+// Request identifies one attachment to download
+type Request struct {
+	ChatID    string // Instagram thread ID
+	MessageID string
+	URL       string
+	Kind      string // photo, video or audio
+}
+
+// Entry is a downloaded attachment
+type Entry struct {
+	ChatID       string    `json:"chat_id"`
+	MessageID    string    `json:"message_id"`
+	Kind         string    `json:"kind"`
+	Path         string    `json:"path"`
+	SHA256       string    `json:"sha256"`
+	Size         int64     `json:"size"`
+	URL          string    `json:"url"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+// Record converts the entry into its output record. chatID is the chat's
+// internal ID.
+func (e *Entry) Record(chatID string) output.MediaRecord {
+	return output.MediaRecord{
+		ChatID:       chatID,
+		MessageID:    e.MessageID,
+		Kind:         e.Kind,
+		Path:         e.Path,
+		Size:         e.Size,
+		SHA256:       e.SHA256,
+		DownloadedAt: output.TimePtr(e.DownloadedAt),
+	}
+}
+
+// Result is the outcome of one download in a batch
+type Result struct {
+	Request Request
+	Entry   *Entry
+	Err     error
+}
+
+// index is the on-disk state for one account
+type index struct {
+	Entries  []*Entry          `json:"entries"`
+	Policies map[string]string `json:"policies"`
+}
+
+// Manager downloads attachments into media_dir/<account>/<chat>/, keeping an
+// index so each file is fetched once and identical content is stored once
+type Manager struct {
+	dir          string
+	autoDownload bool
+	client       *http.Client
+	slots        chan struct{}
+
+	mutex    sync.Mutex
+	index    *index
+	loaded   os.FileInfo // the index file as it was when index was read
+	inflight map[string]*sync.WaitGroup
+}
+
+// NewManager creates a manager rooted at dir. autoDownload is the policy for
+// chats without their own setting.
+func NewManager(dir string, maxConcurrent int, autoDownload bool) *Manager {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrency
+	}
+
+	return &Manager{
+		dir:          dir,
+		autoDownload: autoDownload,
+		client:       &http.Client{Timeout: 10 * time.Minute},
+		slots:        make(chan struct{}, maxConcurrent),
+		inflight:     make(map[string]*sync.WaitGroup),
+	}
+}
+
+// Dir returns the account's media directory
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+func (m *Manager) indexPath() string {
+	return filepath.Join(m.dir, indexFile)
+}
+
+// read loads the index from disk; callers hold m.mutex
+func (m *Manager) read() (*index, error) {
+	info, _ := os.Stat(m.indexPath())
+
+	idx := &index{}
+	if err := storage.ReadJSON(m.indexPath(), idx); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load media index: %v", err)
+	}
+	if idx.Policies == nil {
+		idx.Policies = make(map[string]string)
+	}
+
+	m.index, m.loaded = idx, info
+	return idx, nil
+}
+
+// load returns the index, re-reading it when another process has replaced the
+// file. If it can't be read the last good copy is used; changes go through
+// update, which reports the error. Callers hold m.mutex.
+func (m *Manager) load() *index {
+	if m.index != nil && m.loaded != nil {
+		// Saves replace the file, so an unchanged one is the same file
+		if info, err := os.Stat(m.indexPath()); err == nil && os.SameFile(info, m.loaded) && info.ModTime().Equal(m.loaded.ModTime()) {
+			return m.index
+		}
+	}
+
+	if idx, err := m.read(); err == nil {
+		return idx
+	}
+	if m.index == nil {
+		return &index{Policies: make(map[string]string)}
+	}
+	return m.index
+}
+
+// update changes the index with fn and saves it. The file is reloaded under
+// a lock file, so several GoGram processes can share it. Callers hold m.mutex.
+func (m *Manager) update(fn func(*index) error) error {
+	unlock, err := storage.Lock(m.indexPath())
+	if err != nil {
+		return fmt.Errorf("failed to lock media index: %v", err)
+	}
+	defer unlock()
+
+	idx, err := m.read()
+	if err != nil {
+		return err
+	}
+	if err := fn(idx); err != nil {
+		return err
+	}
+
+	if err := storage.WriteJSON(m.indexPath(), idx); err != nil {
+		return fmt.Errorf("failed to save media index: %v", err)
+	}
+	m.loaded, _ = os.Stat(m.indexPath())
+	return nil
+}
+
+// Lookup returns the entry for a message if its file is still on disk
+func (m *Manager) Lookup(chatID, messageID string) *Entry {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.lookup(chatID, messageID)
+}
+
+func (m *Manager) lookup(chatID, messageID string) *Entry {
+	for _, entry := range m.load().Entries {
+		if entry.ChatID == chatID && entry.MessageID == messageID {
+			if _, err := os.Stat(entry.Path); err == nil {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+// Entries lists downloads for a chat, or all chats when chatID is empty, newest first
+func (m *Manager) Entries(chatID string) []*Entry {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var entries []*Entry
+	for _, entry := range m.load().Entries {
+		if chatID == "" || entry.ChatID == chatID {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].DownloadedAt.After(entries[j].DownloadedAt)
+	})
+	return entries
+}
+
+// Find looks an entry up by message ID, file path or SHA-256 prefix
+func (m *Manager) Find(ref string) *Entry {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, entry := range m.load().Entries {
+		if entry.MessageID == ref || entry.Path == ref || filepath.Base(entry.Path) == ref {
+			return entry
+		}
+	}
+	if len(ref) >= 8 {
+		for _, entry := range m.load().Entries {
+			if strings.HasPrefix(entry.SHA256, ref) {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+// Policy returns a chat's auto-download setting
+func (m *Manager) Policy(chatID string) string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if policy, ok := m.load().Policies[chatID]; ok {
+		return policy
+	}
+	return PolicyDefault
+}
+
+// SetPolicy sets a chat's auto-download setting
+func (m *Manager) SetPolicy(chatID, policy string) error {
+	switch policy {
+	case PolicyDefault, PolicyAlways, PolicyNever:
+	default:
+		return fmt.Errorf("unknown policy %q (use on, off or default)", policy)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.update(func(idx *index) error {
+		if policy == PolicyDefault {
+			delete(idx.Policies, chatID)
+		} else {
+			idx.Policies[chatID] = policy
+		}
+		return nil
+	})
+}
+
+// ShouldAutoDownload reports whether new attachments in a chat are fetched automatically
+func (m *Manager) ShouldAutoDownload(chatID string) bool {
+	switch m.Policy(chatID) {
+	case PolicyAlways:
+		return true
+	case PolicyNever:
+		return false
+	}
+	return m.autoDownload
+}
+
+// Download fetches one attachment, returning the existing entry if it was
+// already downloaded. Interrupted downloads resume from their .part file.
+func (m *Manager) Download(req Request) (*Entry, error) {
+	if req.URL == "" {
+		return nil, fmt.Errorf("message %s has no downloadable media", req.MessageID)
+	}
+
+	key := req.ChatID + "/" + req.MessageID
+	for {
+		m.mutex.Lock()
+		if entry := m.lookup(req.ChatID, req.MessageID); entry != nil {
+			m.mutex.Unlock()
+			return entry, nil
+		}
+
+		// Wait for a concurrent download of the same message, then re-check
+		if wg, busy := m.inflight[key]; busy {
+			m.mutex.Unlock()
+			wg.Wait()
+			continue
+		}
+
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		m.inflight[key] = wg
+		m.mutex.Unlock()
+
+		entry, err := m.download(req)
+
+		m.mutex.Lock()
+		delete(m.inflight, key)
+		m.mutex.Unlock()
+		wg.Done()
+
+		return entry, err
+	}
+}
+
+// DownloadAll downloads attachments concurrently, bounded by the manager's limit
+func (m *Manager) DownloadAll(reqs []Request) []Result {
+	results := make([]Result, len(reqs))
+
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req Request) {
+			defer wg.Done()
+			entry, err := m.Download(req)
+			results[i] = Result{Request: req, Entry: entry, Err: err}
+		}(i, req)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// download does the transfer for Download once it holds the message's slot
+func (m *Manager) download(req Request) (*Entry, error) {
+	m.slots <- struct{}{}
+	defer func() { <-m.slots }()
+
+	chatDir := filepath.Join(m.dir, sanitize(req.ChatID))
+	if err := storage.EnsureDir(chatDir); err != nil {
+		return nil, err
+	}
+
+	partPath := filepath.Join(chatDir, sanitize(req.MessageID)+partSuffix)
+	contentType, err := m.fetch(req.URL, partPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download media for message %s: %v", req.MessageID, err)
+	}
+	os.Remove(partPath + validatorSuffix)
+
+	sum, size, err := hashFile(partPath)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		ChatID:       req.ChatID,
+		MessageID:    req.MessageID,
+		Kind:         req.Kind,
+		SHA256:       sum,
+		Size:         size,
+		URL:          req.URL,
+		DownloadedAt: time.Now(),
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	err = m.update(func(idx *index) error {
+		// Identical content is stored once; later messages point at the first
+		// copy. That includes this message if another process finished it first.
+		for _, existing := range idx.Entries {
+			if existing.SHA256 != sum {
+				continue
+			}
+			if _, err := os.Stat(existing.Path); err == nil {
+				os.Remove(partPath)
+				if existing.ChatID == req.ChatID && existing.MessageID == req.MessageID {
+					entry = existing
+					return nil
+				}
+				entry.Path = existing.Path
+				break
+			}
+		}
+
+		if entry.Path == "" {
+			entry.Path = filepath.Join(chatDir, sanitize(req.MessageID)+extension(req.URL, contentType, req.Kind))
+			if err := os.Rename(partPath, entry.Path); err != nil {
+				return fmt.Errorf("failed to move download into place: %v", err)
+			}
+		}
+
+		idx.Entries = append(idx.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// fetch downloads url into partPath. A .part file is resumed only when the
+// server still has the same content: the request carries If-Range with the
+// validator saved when the download started, and the reply must start where
+// the file ends. Otherwise the file is downloaded again from the start. It
+// returns the response content type.
+func (m *Manager) fetch(rawURL, partPath string) (string, error) {
+	validatorPath := partPath + validatorSuffix
+
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+	validator, _ := os.ReadFile(validatorPath)
+	if offset > 0 && len(validator) == 0 {
+		offset = 0
+	}
+
+	for {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		if err != nil {
+			return "", err
+		}
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.Header.Set("If-Range", string(validator))
+		}
+
+		resp, err := m.client.Do(req)
+		if err != nil {
+			return "", err
+		}
+
+		flags := os.O_CREATE | os.O_WRONLY
+		switch resp.StatusCode {
+		case http.StatusOK:
+			flags |= os.O_TRUNC
+			if err := saveValidator(validatorPath, resp.Header); err != nil {
+				resp.Body.Close()
+				return "", err
+			}
+		case http.StatusPartialContent:
+			if start, ok := rangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+				// Not the rest of our file; start over
+				resp.Body.Close()
+				if offset == 0 {
+					return "", fmt.Errorf("unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+				}
+				offset = 0
+				continue
+			}
+			flags |= os.O_APPEND
+		case http.StatusRequestedRangeNotSatisfiable:
+			resp.Body.Close()
+			// If-Range matched, so the .part file already holds the whole body
+			if offset > 0 {
+				return "", nil
+			}
+			return "", fmt.Errorf("HTTP %s", resp.Status)
+		default:
+			resp.Body.Close()
+			return "", fmt.Errorf("HTTP %s", resp.Status)
+		}
+
+		err = copyBody(partPath, flags, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		return resp.Header.Get("Content-Type"), nil
+	}
+}
+
+// saveValidator keeps what identifies this version of the file so a resumed
+// download can ask for the rest of the same version. Weak ETags can't be used
+// with If-Range.
+func saveValidator(path string, header http.Header) error {
+	validator := header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = header.Get("Last-Modified")
+	}
+	if validator == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return storage.WriteFileAtomic(path, []byte(validator), storage.PrivateFileMode)
+}
+
+// rangeStart returns the first byte of a "bytes first-last/length" Content-Range
+func rangeStart(contentRange string) (int64, bool) {
+	spec, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(strings.TrimSpace(first), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return start, true
+}
+
+func copyBody(path string, flags int, body io.Reader) error {
+	file, err := os.OpenFile(path, flags, storage.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Prune deletes downloads older than maxAge (all of them when maxAge is 0),
+// optionally limited to one chat, plus stale partial downloads. It returns
+// the number of entries removed and the bytes freed.
+func (m *Manager) Prune(maxAge time.Duration, chatID string) (int, int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cutoff := time.Now().Add(-maxAge)
+	var removed []*Entry
+	var freed int64
+	err := m.update(func(idx *index) error {
+		var kept []*Entry
+		for _, entry := range idx.Entries {
+			if (chatID == "" || entry.ChatID == chatID) && (maxAge == 0 || entry.DownloadedAt.Before(cutoff)) {
+				removed = append(removed, entry)
+			} else {
+				kept = append(kept, entry)
+			}
+		}
+
+		// Only delete files no remaining entry shares
+		stillUsed := make(map[string]bool)
+		for _, entry := range kept {
+			stillUsed[entry.Path] = true
+		}
+
+		deleted := make(map[string]bool)
+		for _, entry := range removed {
+			if stillUsed[entry.Path] || deleted[entry.Path] {
+				continue
+			}
+			if info, err := os.Stat(entry.Path); err == nil {
+				freed += info.Size()
+			}
+			if err := os.Remove(entry.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %v", entry.Path, err)
+			}
+			deleted[entry.Path] = true
+		}
+
+		freed += m.prunePartials(cutoff, maxAge == 0, chatID)
+
+		idx.Entries = kept
+		return nil
+	})
+	if err != nil {
+		return 0, freed, err
+	}
+	return len(removed), freed, nil
+}
+
+// prunePartials removes abandoned .part files; callers hold m.mutex
+func (m *Manager) prunePartials(cutoff time.Time, all bool, chatID string) int64 {
+	pattern := filepath.Join(m.dir, "*", "*"+partSuffix)
+	if chatID != "" {
+		pattern = filepath.Join(m.dir, sanitize(chatID), "*"+partSuffix)
+	}
+
+	matches, _ := filepath.Glob(pattern)
+	var freed int64
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || (!all && info.ModTime().After(cutoff)) {
+			continue
+		}
+		if os.Remove(match) == nil {
+			freed += info.Size()
+			os.Remove(match + validatorSuffix)
+		}
+	}
+	return freed
+}
+
+func hashFile(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash %s: %v", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// extension picks a file extension from the URL, then the content type, then the kind
+func extension(rawURL, contentType, kind string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		if ext := strings.ToLower(path.Ext(u.Path)); ext != "" && len(ext) <= 5 {
+			return ext
+		}
+	}
+
+	if contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+			switch mediaType {
+			case "image/jpeg":
+				return ".jpg"
+			case "video/mp4":
+				return ".mp4"
+			}
+			if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+				return exts[0]
+			}
+		}
+	}
+
+	switch kind {
+	case "photo":
+		return ".jpg"
+	case "video":
+		return ".mp4"
+	case "audio":
+		return ".m4a"
+	}
+	return ".bin"
+}
+
+// sanitize keeps IDs safe to use as path components
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == 0 || r == ':' {
+			return '_'
+		}
+		return r
+	}, filepath.Base("/"+name))
+}
@@ -0,0 +1,375 @@
+package media
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+// newFileServer serves body at every path, honouring Range requests
+func newFileServer(body string, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "image/jpeg")
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(body))
+	}))
+}
+
+func TestDownloadStoresFileAndIndex(t *testing.T) {
+	var requests int32
+	server := newFileServer("jpeg bytes", &requests)
+	defer server.Close()
+
+	dir := t.TempDir()
+	m := NewManager(dir, 2, false)
+
+	entry, err := m.Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/a.jpg", Kind: "photo"})
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	if entry.Path != filepath.Join(dir, "thread1", "m1.jpg") {
+		t.Errorf("Unexpected path %s", entry.Path)
+	}
+	if data, _ := os.ReadFile(entry.Path); string(data) != "jpeg bytes" {
+		t.Errorf("Unexpected file contents %q", data)
+	}
+
+	// A second manager reads the index back instead of downloading again
+	again, err := NewManager(dir, 2, false).Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/a.jpg"})
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if again.Path != entry.Path || requests != 1 {
+		t.Errorf("Expected the cached download, got %s after %d requests", again.Path, requests)
+	}
+}
+
+func TestDownloadDeduplicatesContent(t *testing.T) {
+	var requests int32
+	server := newFileServer("same content", &requests)
+	defer server.Close()
+
+	m := NewManager(t.TempDir(), 2, false)
+
+	first, err := m.Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/x.mp4"})
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	second, err := m.Download(Request{ChatID: "thread2", MessageID: "m2", URL: server.URL + "/y.mp4"})
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	if first.Path != second.Path {
+		t.Errorf("Expected identical content to share a file, got %s and %s", first.Path, second.Path)
+	}
+	if _, err := os.Stat(filepath.Join(m.Dir(), "thread2", "m2"+partSuffix)); !os.IsNotExist(err) {
+		t.Error("Expected the duplicate partial file to be removed")
+	}
+}
+
+// writePart leaves an interrupted download of thread1/m1 in dir
+func writePart(t *testing.T, dir, data, validator string) {
+	t.Helper()
+	partPath := filepath.Join(dir, "thread1", "m1"+partSuffix)
+	if err := os.MkdirAll(filepath.Dir(partPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partPath, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if validator != "" {
+		if err := os.WriteFile(partPath+validatorSuffix, []byte(validator), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDownloadResumesPartialFile(t *testing.T) {
+	var requests int32
+	var rangeHeader, ifRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		rangeHeader, ifRange = r.Header.Get("Range"), r.Header.Get("If-Range")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader("0123456789"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	writePart(t, dir, "01234", `"v1"`)
+
+	entry, err := NewManager(dir, 1, false).Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/v.mp4"})
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	if rangeHeader != "bytes=5-" || ifRange != `"v1"` {
+		t.Errorf("Expected a range request from byte 5 if still %q, got %q if %q", `"v1"`, rangeHeader, ifRange)
+	}
+	if data, _ := os.ReadFile(entry.Path); string(data) != "0123456789" {
+		t.Errorf("Expected resumed file to be complete, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "thread1", "m1"+partSuffix+validatorSuffix)); !os.IsNotExist(err) {
+		t.Error("Expected the validator to be removed once the download finished")
+	}
+}
+
+func TestDownloadRestartsWhenPartialFileIsStale(t *testing.T) {
+	testCases := []struct {
+		name      string
+		validator string
+		handler   http.HandlerFunc
+	}{
+		{
+			// The file changed since the part was saved, so If-Range fails and
+			// the server sends the whole new file
+			name:      "changed",
+			validator: `"old"`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"new"`)
+				http.ServeContent(w, r, "", time.Time{}, strings.NewReader("abcdefghij"))
+			},
+		},
+		{
+			// The server answers with a range that doesn't continue the part
+			name:      "wrong range",
+			validator: `"v1"`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Range") != "" {
+					w.Header().Set("Content-Range", "bytes 2-9/10")
+					w.WriteHeader(http.StatusPartialContent)
+					w.Write([]byte("cdefghij"))
+					return
+				}
+				w.Write([]byte("abcdefghij"))
+			},
+		},
+		{
+			// Nothing says which version the part came from
+			name: "no validator",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.ServeContent(w, r, "", time.Time{}, strings.NewReader("abcdefghij"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		dir := t.TempDir()
+		writePart(t, dir, "01234", tc.validator)
+		server := httptest.NewServer(tc.handler)
+
+		entry, err := NewManager(dir, 1, false).Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/v.mp4"})
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: Download failed: %v", tc.name, err)
+			continue
+		}
+		if data, _ := os.ReadFile(entry.Path); string(data) != "abcdefghij" {
+			t.Errorf("%s: Expected the file to be downloaded again, got %q", tc.name, data)
+		}
+	}
+}
+
+func TestDownloadAllRespectsConcurrencyLimit(t *testing.T) {
+	var active, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, r.URL.Path)
+	}))
+	defer server.Close()
+
+	m := NewManager(t.TempDir(), 2, false)
+
+	var reqs []Request
+	for i := 0; i < 6; i++ {
+		id := fmt.Sprintf("m%d", i)
+		reqs = append(reqs, Request{ChatID: "thread1", MessageID: id, URL: server.URL + "/" + id + ".jpg"})
+	}
+
+	for _, result := range m.DownloadAll(reqs) {
+		if result.Err != nil {
+			t.Errorf("Download of %s failed: %v", result.Request.MessageID, result.Err)
+		}
+	}
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent downloads, saw %d", peak)
+	}
+	if len(m.Entries("thread1")) != 6 {
+		t.Errorf("Expected 6 entries, got %d", len(m.Entries("thread1")))
+	}
+}
+
+func TestDownloadReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	m := NewManager(t.TempDir(), 1, false)
+	if _, err := m.Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL}); err == nil {
+		t.Error("Expected an error for a 404 response")
+	}
+	if _, err := m.Download(Request{ChatID: "thread1", MessageID: "m2"}); err == nil {
+		t.Error("Expected an error for a message without a URL")
+	}
+	if len(m.Entries("")) != 0 {
+		t.Error("Expected failed downloads not to be indexed")
+	}
+}
+
+func TestPruneKeepsSharedFiles(t *testing.T) {
+	var requests int32
+	server := newFileServer("shared", &requests)
+	defer server.Close()
+
+	m := NewManager(t.TempDir(), 1, false)
+	old, _ := m.Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/a.jpg"})
+	recent, _ := m.Download(Request{ChatID: "thread2", MessageID: "m2", URL: server.URL + "/b.jpg"})
+	ageEntry(t, m.Dir(), old.MessageID, 48*time.Hour)
+
+	removed, _, err := m.Prune(24*time.Hour, "")
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 entry removed, got %d", removed)
+	}
+	if _, err := os.Stat(recent.Path); err != nil {
+		t.Error("Expected a file still referenced by another entry to be kept")
+	}
+
+	removed, freed, err := m.Prune(0, "thread2")
+	if err != nil || removed != 1 || freed != int64(len("shared")) {
+		t.Errorf("Expected pruning everything to free the file, got %d removed, %d bytes, %v", removed, freed, err)
+	}
+	if _, err := os.Stat(recent.Path); !os.IsNotExist(err) {
+		t.Error("Expected the file to be deleted once unreferenced")
+	}
+}
+
+// ageEntry backdates a message's entry in the index on disk
+func ageEntry(t *testing.T, dir, messageID string, age time.Duration) {
+	t.Helper()
+	path := filepath.Join(dir, indexFile)
+	idx := &index{}
+	if err := storage.ReadJSON(path, idx); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range idx.Entries {
+		if entry.MessageID == messageID {
+			entry.DownloadedAt = time.Now().Add(-age)
+		}
+	}
+	if err := storage.WriteJSON(path, idx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagersShareIndex(t *testing.T) {
+	var requests int32
+	server := newFileServer("bytes", &requests)
+	defer server.Close()
+
+	// Two managers on one directory stand in for two GoGram processes
+	dir := t.TempDir()
+	first := NewManager(dir, 1, false)
+	second := NewManager(dir, 1, false)
+
+	if _, err := first.Download(Request{ChatID: "thread1", MessageID: "m1", URL: server.URL + "/a.jpg"}); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if err := second.SetPolicy("thread2", PolicyAlways); err != nil {
+		t.Fatalf("SetPolicy failed: %v", err)
+	}
+	if _, err := first.Download(Request{ChatID: "thread3", MessageID: "m3", URL: server.URL + "/c.jpg"}); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	reloaded := NewManager(dir, 1, false)
+	if len(reloaded.Entries("")) != 2 || reloaded.Policy("thread2") != PolicyAlways {
+		t.Errorf("Expected both managers' changes to be kept, got %d entries and policy %q",
+			len(reloaded.Entries("")), reloaded.Policy("thread2"))
+	}
+	if second.Lookup("thread3", "m3") == nil {
+		t.Error("Expected a manager to see downloads made by another")
+	}
+}
+
+func TestCorruptIndexIsNotOverwritten(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, indexFile)
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(dir, 1, false)
+	if m.Policy("thread1") != PolicyDefault {
+		t.Error("Expected an unreadable index to read as empty")
+	}
+	if err := m.SetPolicy("thread1", PolicyNever); err == nil {
+		t.Error("Expected saving over an unreadable index to fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{not json" {
+		t.Errorf("Expected the index to be left alone, got %q", data)
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManager(dir, 1, true)
+
+	if !m.ShouldAutoDownload("thread1") {
+		t.Error("Expected chats to follow the global default")
+	}
+	if err := m.SetPolicy("thread1", PolicyNever); err != nil {
+		t.Fatalf("SetPolicy failed: %v", err)
+	}
+	if err := m.SetPolicy("thread1", "sometimes"); err == nil {
+		t.Error("Expected an unknown policy to be rejected")
+	}
+
+	reloaded := NewManager(dir, 1, true)
+	if reloaded.ShouldAutoDownload("thread1") || reloaded.Policy("thread1") != PolicyNever {
+		t.Error("Expected the per-chat policy to be saved")
+	}
+
+	if err := reloaded.SetPolicy("thread1", PolicyDefault); err != nil {
+		t.Fatalf("SetPolicy failed: %v", err)
+	}
+	if !reloaded.ShouldAutoDownload("thread1") {
+		t.Error("Expected default to fall back to the global setting")
+	}
+}
+
+func TestExtension(t *testing.T) {
+	testCases := []struct {
+		url, contentType, kind, expected string
+	}{
+		{"https://cdn.example/v/t51/abc.jpg?stp=dst", "", "photo", ".jpg"},
+		{"https://cdn.example/v/abc", "video/mp4", "video", ".mp4"},
+		{"https://cdn.example/v/abc", "", "audio", ".m4a"},
+		{"https://cdn.example/v/abc", "", "", ".bin"},
+	}
+
+	for _, tc := range testCases {
+		if got := extension(tc.url, tc.contentType, tc.kind); got != tc.expected {
+			t.Errorf("extension(%q, %q, %q) = %q, expected %q", tc.url, tc.contentType, tc.kind, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package notify
+
+import "sync"
+
+// DigestEntry sums up the messages a chat got during quiet hours
+type DigestEntry struct {
+	Count  int
+	Latest Message
+}
+
+// Digest collects deferred messages until quiet hours end
+type Digest struct {
+	mutex   sync.Mutex
+	entries map[string]*DigestEntry
+	order   []string // chat IDs in the order their first message arrived
+}
+
+// Add records a deferred message
+func (d *Digest) Add(msg Message) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.entries == nil {
+		d.entries = make(map[string]*DigestEntry)
+	}
+	entry, ok := d.entries[msg.ChatID]
+	if !ok {
+		entry = &DigestEntry{}
+		d.entries[msg.ChatID] = entry
+		d.order = append(d.order, msg.ChatID)
+	}
+	entry.Count++
+	entry.Latest = msg
+}
+
+// Len returns how many messages are waiting
+func (d *Digest) Len() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	n := 0
+	for _, entry := range d.entries {
+		n += entry.Count
+	}
+	return n
+}
+
+// Take returns one entry per chat and empties the digest
+func (d *Digest) Take() []DigestEntry {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	entries := make([]DigestEntry, 0, len(d.order))
+	for _, chatID := range d.order {
+		entries = append(entries, *d.entries[chatID])
+	}
+	d.entries, d.order = nil, nil
+	return entries
+}
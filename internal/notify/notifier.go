@@ -0,0 +1,322 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink names for Config.Sinks and Rule.Sinks
+const (
+	SinkDesktop  = "desktop"  // freedesktop notification over D-Bus, or macOS Notification Center
+	SinkTerminal = "terminal" // terminal bell and window title
+	SinkExec     = "exec"     // run a command
+	SinkWebhook  = "webhook"  // HTTP POST
+)
+
+// sinkTimeout bounds how long a single sink may take
+const sinkTimeout = 10 * time.Second
+
+// Notification is what sinks deliver
+type Notification struct {
+	ChatID      string    `json:"chat_id"`
+	ThreadID    string    `json:"thread_id"`
+	ChatTitle   string    `json:"chat_title"`
+	Sender      string    `json:"sender"`
+	Username    string    `json:"username,omitempty"`
+	Text        string    `json:"message_preview"`
+	Time        time.Time `json:"timestamp"`
+	UnreadCount int       `json:"unread_count"`
+	Rule        string    `json:"rule,omitempty"`
+}
+
+// Summary is a one line description for titles and banners
+func (n Notification) Summary() string {
+	if n.Sender == "" || n.Sender == n.ChatTitle {
+		return n.ChatTitle
+	}
+	return fmt.Sprintf("%s in %s", n.Sender, n.ChatTitle)
+}
+
+// Notifier delivers notifications somewhere outside the app
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// TerminalConfig configures the terminal sink
+type TerminalConfig struct {
+	Bell  bool `mapstructure:"bell"`
+	Title bool `mapstructure:"title"`
+}
+
+// ExecConfig configures the exec sink
+type ExecConfig struct {
+	Command string `mapstructure:"command"`
+}
+
+// WebhookConfig configures the webhook sink
+type WebhookConfig struct {
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// NewNotifiers builds the named sinks from cfg. The terminal sink writes to
+// terminal.
+func NewNotifiers(cfg Config, names []string, terminal io.Writer) (map[string]Notifier, error) {
+	notifiers := make(map[string]Notifier, len(names))
+	for _, name := range names {
+		if _, ok := notifiers[name]; ok {
+			continue
+		}
+
+		switch name {
+		case SinkDesktop:
+			notifiers[name] = Desktop{}
+		case SinkTerminal:
+			notifiers[name] = Terminal{Out: terminal, Bell: cfg.Terminal.Bell, Title: cfg.Terminal.Title}
+		case SinkExec:
+			if strings.TrimSpace(cfg.Exec.Command) == "" {
+				return nil, fmt.Errorf("the exec sink needs exec.command")
+			}
+			notifiers[name] = Exec{Command: cfg.Exec.Command}
+		case SinkWebhook:
+			if !strings.HasPrefix(cfg.Webhook.URL, "http://") && !strings.HasPrefix(cfg.Webhook.URL, "https://") {
+				return nil, fmt.Errorf("the webhook sink needs an http(s) webhook.url")
+			}
+			notifiers[name] = Webhook{URL: cfg.Webhook.URL, Headers: cfg.Webhook.Headers}
+		default:
+			return nil, fmt.Errorf("unknown sink %q (expected desktop, terminal, exec or webhook)", name)
+		}
+	}
+	return notifiers, nil
+}
+
+// Desktop shows a desktop notification. It uses gdbus to reach the
+// freedesktop notification service, or osascript on macOS.
+type Desktop struct{}
+
+// Notify implements Notifier
+func (Desktop) Notify(ctx context.Context, n Notification) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(n.Text), appleScriptString(n.Summary()))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "linux", "freebsd", "openbsd":
+		// Notify(app_name, replaces_id, app_icon, summary, body, actions, hints, expire_timeout)
+		cmd = exec.CommandContext(ctx, "gdbus", "call", "--session",
+			"--dest", "org.freedesktop.Notifications",
+			"--object-path", "/org/freedesktop/Notifications",
+			"--method", "org.freedesktop.Notifications.Notify",
+			"GoGram", "0", "", gvariantString(n.Summary()), gvariantString(n.Text), "[]", "{}", "-1")
+	default:
+		return fmt.Errorf("desktop notifications not supported on %s", runtime.GOOS)
+	}
+	return runSink(cmd)
+}
+
+// gvariantString quotes s as a GVariant string literal for gdbus
+func gvariantString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Terminal rings the terminal bell and shows the latest notification in the
+// window title
+type Terminal struct {
+	Out   io.Writer
+	Bell  bool
+	Title bool
+}
+
+// Notify implements Notifier
+func (t Terminal) Notify(_ context.Context, n Notification) error {
+	var b strings.Builder
+	if t.Title {
+		// OSC 2 sets the window title; control characters would end it early
+		title := strings.Map(func(r rune) rune {
+			if r < ' ' || r == 0x7f {
+				return ' '
+			}
+			return r
+		}, "GoGram: "+n.Summary())
+		fmt.Fprintf(&b, "\x1b]2;%s\x07", title)
+	}
+	if t.Bell {
+		b.WriteString("\a")
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(t.Out, b.String())
+	return err
+}
+
+// SwitchWriter forwards writes to a writer that can be swapped or turned
+// off, such as while a full screen UI owns the terminal
+type SwitchWriter struct {
+	mutex sync.Mutex
+	out   io.Writer
+}
+
+// NewSwitchWriter returns a SwitchWriter writing to out
+func NewSwitchWriter(out io.Writer) *SwitchWriter {
+	return &SwitchWriter{out: out}
+}
+
+// Set replaces the writer, nil discarding writes, and returns the old one
+func (w *SwitchWriter) Set(out io.Writer) io.Writer {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	previous := w.out
+	w.out = out
+	return previous
+}
+
+// Write implements io.Writer
+func (w *SwitchWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.out == nil {
+		return len(p), nil
+	}
+	return w.out.Write(p)
+}
+
+// Exec runs a shell command for each notification. The notification is
+// passed as JSON on stdin and in IG_* environment variables.
+type Exec struct {
+	Command string
+}
+
+// Notify implements Notifier
+func (e Exec) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/c", e.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", e.Command)
+	}
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"IG_CHAT_ID="+n.ChatID,
+		"IG_THREAD_ID="+n.ThreadID,
+		"IG_CHAT_TITLE="+n.ChatTitle,
+		"IG_SENDER="+n.Sender,
+		"IG_USERNAME="+n.Username,
+		"IG_MESSAGE="+n.Text,
+		"IG_TIMESTAMP="+n.Time.Format(time.RFC3339),
+		"IG_RULE="+n.Rule,
+	)
+	return runSink(cmd)
+}
+
+// runSink runs a sink command, keeping its stderr for the error
+func runSink(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %v (%s)", cmd.Args[0], err, msg)
+		}
+		return fmt.Errorf("%s: %v", cmd.Args[0], err)
+	}
+	return nil
+}
+
+// Webhook POSTs each notification as JSON
+type Webhook struct {
+	URL     string
+	Headers map[string]string
+	// Client defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// Notify implements Notifier
+func (w Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GoGram")
+	for key, value := range w.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %s", resp.Status)
+	}
+	return nil
+}
+
+// Deliver sends n to each notifier at once and waits for them, up to
+// sinkTimeout. It returns the errors by sink name.
+func Deliver(notifiers map[string]Notifier, n Notification) map[string]error {
+	ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+	defer cancel()
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(notifiers))
+	for name, notifier := range notifiers {
+		go func(name string, notifier Notifier) {
+			results <- result{name, notifier.Notify(ctx, n)}
+		}(name, notifier)
+	}
+
+	var errs map[string]error
+	for range notifiers {
+		if r := <-results; r.err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[r.name] = r.err
+		}
+	}
+	return errs
+}
+
+// NotifierFunc adapts a function to a Notifier
+type NotifierFunc func(ctx context.Context, n Notification) error
+
+// Notify implements Notifier
+func (f NotifierFunc) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNotification = Notification{
+	ChatID:    "3",
+	ThreadID:  "340282366841710300949128",
+	ChatTitle: "Book club",
+	Sender:    "Alice",
+	Username:  "alice",
+	Text:      "See you at 7",
+	Time:      time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC),
+	Rule:      "friends",
+}
+
+func TestTerminal(t *testing.T) {
+	var out bytes.Buffer
+	terminal := Terminal{Out: &out, Bell: true, Title: true}
+
+	n := testNotification
+	n.Sender = "Al\x07ice"
+	if err := terminal.Notify(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "\x1b]2;GoGram: Al ice in Book club\x07\a"; out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestSwitchWriter(t *testing.T) {
+	var first, second bytes.Buffer
+	w := NewSwitchWriter(&first)
+	terminal := Terminal{Out: w, Bell: true}
+
+	terminal.Notify(context.Background(), testNotification)
+	if previous := w.Set(nil); previous != &first {
+		t.Errorf("Expected Set to return the old writer, got %v", previous)
+	}
+	terminal.Notify(context.Background(), testNotification)
+	w.Set(&second)
+	terminal.Notify(context.Background(), testNotification)
+
+	if first.String() != "\a" || second.String() != "\a" {
+		t.Errorf("Expected one bell each side of the pause, got %q and %q", first.String(), second.String())
+	}
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	path := filepath.Join(t.TempDir(), "out")
+	exec := Exec{Command: `{ printf '%s|%s\n' "$IG_CHAT_TITLE" "$IG_RULE"; cat; } > "` + path + `"`}
+
+	if err := exec.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, body, _ := strings.Cut(string(data), "\n")
+	if env != "Book club|friends" {
+		t.Errorf("Unexpected environment %q", env)
+	}
+	var got Notification
+	if err := json.Unmarshal([]byte(body), &got); err != nil || got != testNotification {
+		t.Errorf("Expected the notification as JSON on stdin, got %q (%v)", body, err)
+	}
+
+	if err := (Exec{Command: "echo broken >&2; exit 3"}).Notify(context.Background(), testNotification); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Expected the command's stderr in the error, got %v", err)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var got Notification
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		if got.ChatID == "fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	webhook := Webhook{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer xyz"}}
+	if err := webhook.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	if got != testNotification || token != "Bearer xyz" {
+		t.Errorf("Unexpected request %+v with token %q", got, token)
+	}
+
+	n := testNotification
+	n.ChatID = "fail"
+	if err := webhook.Notify(context.Background(), n); err == nil {
+		t.Error("Expected an HTTP error status to fail")
+	}
+}
+
+func TestNewNotifiers(t *testing.T) {
+	cfg := Config{Exec: ExecConfig{Command: "true"}}
+	notifiers, err := NewNotifiers(cfg, []string{SinkTerminal, SinkExec, SinkTerminal}, io.Discard)
+	if err != nil || len(notifiers) != 2 {
+		t.Errorf("Expected terminal and exec sinks, got %v, %v", notifiers, err)
+	}
+
+	for _, name := range []string{SinkWebhook, "pager"} {
+		if _, err := NewNotifiers(cfg, []string{name}, io.Discard); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestDecisionSinks(t *testing.T) {
+	engine, err := NewEngine(Config{
+		Sinks: []string{SinkTerminal},
+		Rules: []Rule{{Name: "boss", Senders: []string{"boss"}, Sinks: []string{SinkDesktop, SinkWebhook}}},
+	}, "me")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := engine.Evaluate(Message{Username: "boss"}, at("12:00")); !reflect.DeepEqual(d.Sinks, []string{SinkDesktop, SinkWebhook}) {
+		t.Errorf("Expected the rule's sinks, got %v", d.Sinks)
+	}
+	if d := engine.Evaluate(Message{Username: "friend"}, at("12:00")); !reflect.DeepEqual(d.Sinks, []string{SinkTerminal}) {
+		t.Errorf("Expected the default sinks, got %v", d.Sinks)
+	}
+	if names := engine.SinkNames(); len(names) != 3 {
+		t.Errorf("Expected three sink names, got %v", names)
+	}
+
+	if _, err := NewEngine(Config{Rules: []Rule{{Sinks: []string{"email"}}}}, "me"); err == nil {
+		t.Error("Expected an unknown sink to be rejected")
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	failing := NotifierFunc(func(context.Context, Notification) error { return io.ErrClosedPipe })
+	var delivered Notification
+	working := NotifierFunc(func(_ context.Context, n Notification) error { delivered = n; return nil })
+
+	errs := Deliver(map[string]Notifier{"a": failing, "b": working}, testNotification)
+	if len(errs) != 1 || errs["a"] != io.ErrClosedPipe || delivered != testNotification {
+		t.Errorf("Unexpected errors %v, delivered %+v", errs, delivered)
+	}
+}
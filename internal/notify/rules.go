@@ -0,0 +1,346 @@
+// Package notify decides which new messages deserve a notification and
+// delivers notifications to sinks such as the desktop or a webhook
+package notify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/config"
+)
+
+// Modes say which messages in a chat notify
+const (
+	ModeAll   = "all"   // every message
+	ModeRules = "rules" // only messages a rule matches
+	ModeOff   = "off"   // none
+)
+
+// Config is the notifications section of config.yaml
+type Config struct {
+	Mode       string     `mapstructure:"mode"`
+	Rules      []Rule     `mapstructure:"rules"`
+	QuietHours QuietHours `mapstructure:"quiet_hours"`
+	// Chats overrides Mode per chat, keyed by internal or thread ID
+	Chats map[string]string `mapstructure:"chats"`
+	// Sinks are where notifications go besides the app itself, unless the
+	// matching rule names its own
+	Sinks    []string       `mapstructure:"sinks"`
+	Terminal TerminalConfig `mapstructure:"terminal"`
+	Exec     ExecConfig     `mapstructure:"exec"`
+	Webhook  WebhookConfig  `mapstructure:"webhook"`
+}
+
+// Rule picks out messages. Every condition that's set has to hold; a rule
+// with none matches every message.
+type Rule struct {
+	Name string `mapstructure:"name"`
+	// Mentions matches messages that mention your @username
+	Mentions bool `mapstructure:"mentions"`
+	// Keywords matches messages containing any of these words, ignoring case
+	Keywords []string `mapstructure:"keywords"`
+	// Regex matches messages the regular expression finds a match in
+	Regex string `mapstructure:"regex"`
+	// Senders matches messages from these usernames or display names
+	Senders []string `mapstructure:"senders"`
+	// Chats limits the rule to these chats, by internal or thread ID
+	Chats []string `mapstructure:"chats"`
+	// IgnoreQuietHours lets the rule's messages through during quiet hours
+	IgnoreQuietHours bool `mapstructure:"ignore_quiet_hours"`
+	// Sinks replaces Config.Sinks for the rule's messages
+	Sinks []string `mapstructure:"sinks"`
+}
+
+// QuietHours hold notifications back between Start and End, given as 15:04
+// in local time. Held messages are summed up when quiet hours end if Digest
+// is set, and dropped otherwise.
+type QuietHours struct {
+	Start  string `mapstructure:"start"`
+	End    string `mapstructure:"end"`
+	Digest bool   `mapstructure:"digest"`
+}
+
+// LoadConfig reads the notifications section of the config file
+func LoadConfig() (Config, error) {
+	cfg := Config{Mode: ModeAll}
+	if err := config.GetInstance().UnmarshalKey("notifications", &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Message is what rules look at in a new message
+type Message struct {
+	ChatID    string // internal ID
+	ThreadID  string
+	ChatTitle string
+	Sender    string // display name
+	Username  string
+	Text      string
+}
+
+// Action is what to do with a message
+type Action int
+
+const (
+	Drop   Action = iota // don't notify
+	Notify               // notify now
+	Defer                // quiet hours: add to the digest
+)
+
+// Decision is the outcome of evaluating a message
+type Decision struct {
+	Action Action
+	// Rule is the name of the first rule that matched, if any
+	Rule string
+	// Sinks are where to send the notification
+	Sinks []string
+}
+
+// Engine evaluates messages against a Config
+type Engine struct {
+	mode       string
+	rules      []rule
+	chats      map[string]string
+	quietStart int // minutes after midnight
+	quietEnd   int
+	quiet      bool
+	digest     bool
+	sinks      []string
+}
+
+// rule is a Rule with its patterns compiled
+type rule struct {
+	Rule
+	keywords *regexp.Regexp
+	regex    *regexp.Regexp
+	mention  *regexp.Regexp
+}
+
+// NewEngine checks cfg and prepares it for evaluation. username is the
+// logged in account, for mention rules.
+func NewEngine(cfg Config, username string) (*Engine, error) {
+	e := &Engine{
+		mode:   strings.ToLower(cfg.Mode),
+		chats:  make(map[string]string, len(cfg.Chats)),
+		digest: cfg.QuietHours.Digest,
+		sinks:  cfg.Sinks,
+	}
+	if e.mode == "" {
+		e.mode = ModeAll
+	}
+	if err := checkMode(e.mode); err != nil {
+		return nil, err
+	}
+	if err := checkSinks(e.sinks); err != nil {
+		return nil, err
+	}
+
+	for chatID, mode := range cfg.Chats {
+		mode = strings.ToLower(mode)
+		if err := checkMode(mode); err != nil {
+			return nil, fmt.Errorf("chat %s: %v", chatID, err)
+		}
+		e.chats[chatID] = mode
+	}
+
+	for i, r := range cfg.Rules {
+		compiled, err := compileRule(r, username)
+		if err != nil {
+			name := r.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("rule %s: %v", name, err)
+		}
+		e.rules = append(e.rules, compiled)
+	}
+
+	if cfg.QuietHours.Start != "" || cfg.QuietHours.End != "" {
+		start, err := parseClock(cfg.QuietHours.Start)
+		if err != nil {
+			return nil, fmt.Errorf("quiet_hours.start: %v", err)
+		}
+		end, err := parseClock(cfg.QuietHours.End)
+		if err != nil {
+			return nil, fmt.Errorf("quiet_hours.end: %v", err)
+		}
+		e.quietStart, e.quietEnd, e.quiet = start, end, start != end
+	}
+	return e, nil
+}
+
+func checkMode(mode string) error {
+	switch mode {
+	case ModeAll, ModeRules, ModeOff:
+		return nil
+	}
+	return fmt.Errorf("unknown mode %q (expected all, rules or off)", mode)
+}
+
+func checkSinks(sinks []string) error {
+	for _, sink := range sinks {
+		switch sink {
+		case SinkDesktop, SinkTerminal, SinkExec, SinkWebhook:
+		default:
+			return fmt.Errorf("unknown sink %q (expected desktop, terminal, exec or webhook)", sink)
+		}
+	}
+	return nil
+}
+
+// SinkNames returns every sink the config sends to
+func (e *Engine) SinkNames() []string {
+	names := append([]string(nil), e.sinks...)
+	for _, r := range e.rules {
+		names = append(names, r.Sinks...)
+	}
+	return names
+}
+
+// DefaultSinks returns the sinks for messages no rule picks sinks for
+func (e *Engine) DefaultSinks() []string {
+	return e.sinks
+}
+
+func compileRule(r Rule, username string) (rule, error) {
+	compiled := rule{Rule: r}
+	if err := checkSinks(r.Sinks); err != nil {
+		return rule{}, err
+	}
+
+	if len(r.Keywords) > 0 {
+		words := make([]string, 0, len(r.Keywords))
+		for _, keyword := range r.Keywords {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				words = append(words, regexp.QuoteMeta(keyword))
+			}
+		}
+		if len(words) == 0 {
+			return rule{}, fmt.Errorf("keywords are all empty")
+		}
+		// Whole words only, so "art" doesn't match "party"
+		compiled.keywords = regexp.MustCompile(`(?i)(^|\W)(` + strings.Join(words, "|") + `)($|\W)`)
+	}
+
+	if r.Regex != "" {
+		re, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return rule{}, fmt.Errorf("invalid regex: %v", err)
+		}
+		compiled.regex = re
+	}
+
+	if r.Mentions && username != "" {
+		compiled.mention = regexp.MustCompile(`(?i)(^|[^\w.])@` + regexp.QuoteMeta(username) + `($|[^\w.]|\.(\s|$))`)
+	}
+	return compiled, nil
+}
+
+// parseClock reads a 15:04 time of day as minutes after midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time like 22:00", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// matches reports whether the rule picks out msg
+func (r *rule) matches(msg Message) bool {
+	if len(r.Chats) > 0 && !contains(r.Chats, msg.ChatID) && !contains(r.Chats, msg.ThreadID) {
+		return false
+	}
+	if len(r.Senders) > 0 && !fromSender(r.Senders, msg) {
+		return false
+	}
+	if r.Mentions && (r.mention == nil || !r.mention.MatchString(msg.Text)) {
+		return false
+	}
+	if r.keywords != nil && !r.keywords.MatchString(msg.Text) {
+		return false
+	}
+	if r.regex != nil && !r.regex.MatchString(msg.Text) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func fromSender(senders []string, msg Message) bool {
+	for _, sender := range senders {
+		sender = strings.TrimPrefix(strings.TrimSpace(sender), "@")
+		if sender == "" {
+			continue
+		}
+		if strings.EqualFold(sender, msg.Username) || strings.EqualFold(sender, msg.Sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate decides what to do with a message that arrived at now
+func (e *Engine) Evaluate(msg Message, now time.Time) Decision {
+	mode := e.mode
+	if m, ok := e.chats[msg.ChatID]; ok {
+		mode = m
+	} else if m, ok := e.chats[msg.ThreadID]; ok {
+		mode = m
+	}
+	if mode == ModeOff {
+		return Decision{Action: Drop}
+	}
+
+	var matched *rule
+	for i := range e.rules {
+		if e.rules[i].matches(msg) {
+			matched = &e.rules[i]
+			break
+		}
+	}
+	if mode == ModeRules && matched == nil {
+		return Decision{Action: Drop}
+	}
+
+	decision := Decision{Action: Notify, Sinks: e.sinks}
+	if matched != nil {
+		decision.Rule = matched.Name
+		if len(matched.Sinks) > 0 {
+			decision.Sinks = matched.Sinks
+		}
+	}
+	if e.Quiet(now) && (matched == nil || !matched.IgnoreQuietHours) {
+		decision.Action = Drop
+		if e.digest {
+			decision.Action = Defer
+		}
+	}
+	return decision
+}
+
+// Quiet reports whether t falls in quiet hours. Quiet hours may run past
+// midnight, such as 22:00 to 07:00.
+func (e *Engine) Quiet(t time.Time) bool {
+	if !e.quiet {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if e.quietStart < e.quietEnd {
+		return minute >= e.quietStart && minute < e.quietEnd
+	}
+	return minute >= e.quietStart || minute < e.quietEnd
+}
@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func at(clock string) time.Time {
+	t, _ := time.Parse("15:04", clock)
+	return t
+}
+
+func TestEvaluateRules(t *testing.T) {
+	engine, err := NewEngine(Config{
+		Mode: ModeRules,
+		Rules: []Rule{
+			{Name: "mentions", Mentions: true},
+			{Name: "urgent", Keywords: []string{"urgent", "ASAP"}},
+			{Name: "family", Senders: []string{"@Mum"}, Chats: []string{"1"}},
+			{Name: "codes", Regex: `\b\d{6}\b`},
+		},
+	}, "me.here")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		msg      Message
+		expected string // matched rule, "" for dropped
+	}{
+		{Message{ChatID: "2", Text: "hey @me.here, look"}, "mentions"},
+		{Message{ChatID: "2", Text: "hey @me.here."}, "mentions"},
+		{Message{ChatID: "2", Text: "hey @me.herebefore"}, ""},
+		{Message{ChatID: "2", Text: "email me@me.here"}, ""},
+		{Message{ChatID: "2", Text: "need this Urgent!"}, "urgent"},
+		{Message{ChatID: "2", Text: "insurgents"}, ""},
+		{Message{ChatID: "1", Username: "mum", Text: "dinner"}, "family"},
+		{Message{ChatID: "2", Username: "mum", Text: "dinner"}, ""},
+		{Message{ChatID: "2", Text: "your code is 123456"}, "codes"},
+		{Message{ChatID: "2", Text: "hello"}, ""},
+	}
+
+	for _, tc := range testCases {
+		decision := engine.Evaluate(tc.msg, at("12:00"))
+		if tc.expected == "" {
+			if decision.Action != Drop {
+				t.Errorf("%q: expected a drop, got %+v", tc.msg.Text, decision)
+			}
+		} else if decision.Action != Notify || decision.Rule != tc.expected {
+			t.Errorf("%q: expected rule %s, got %+v", tc.msg.Text, tc.expected, decision)
+		}
+	}
+}
+
+func TestEvaluateChatModes(t *testing.T) {
+	engine, err := NewEngine(Config{
+		Chats: map[string]string{"1": "off", "thread-2": "rules"},
+		Rules: []Rule{{Name: "vip", Senders: []string{"boss"}}},
+	}, "me")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := engine.Evaluate(Message{ChatID: "3", Text: "hi"}, at("12:00")); d.Action != Notify {
+		t.Errorf("Expected mode all to notify, got %+v", d)
+	}
+	if d := engine.Evaluate(Message{ChatID: "1", Username: "boss"}, at("12:00")); d.Action != Drop {
+		t.Errorf("Expected chat 1 to be off, got %+v", d)
+	}
+	if d := engine.Evaluate(Message{ChatID: "2", ThreadID: "thread-2", Username: "friend"}, at("12:00")); d.Action != Drop {
+		t.Errorf("Expected chat 2 to only notify for rules, got %+v", d)
+	}
+	if d := engine.Evaluate(Message{ChatID: "2", ThreadID: "thread-2", Username: "boss"}, at("12:00")); d.Action != Notify || d.Rule != "vip" {
+		t.Errorf("Expected the vip rule to match, got %+v", d)
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	engine, err := NewEngine(Config{
+		Rules:      []Rule{{Name: "vip", Senders: []string{"boss"}, IgnoreQuietHours: true}},
+		QuietHours: QuietHours{Start: "22:00", End: "07:30", Digest: true},
+	}, "me")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for clock, quiet := range map[string]bool{"21:59": false, "22:00": true, "03:00": true, "07:29": true, "07:30": false} {
+		if engine.Quiet(at(clock)) != quiet {
+			t.Errorf("Quiet(%s) = %v", clock, !quiet)
+		}
+	}
+
+	if d := engine.Evaluate(Message{Username: "friend"}, at("23:00")); d.Action != Defer {
+		t.Errorf("Expected the message to be deferred, got %+v", d)
+	}
+	if d := engine.Evaluate(Message{Username: "boss"}, at("23:00")); d.Action != Notify {
+		t.Errorf("Expected the vip rule to ignore quiet hours, got %+v", d)
+	}
+
+	engine, _ = NewEngine(Config{QuietHours: QuietHours{Start: "09:00", End: "17:00"}}, "me")
+	if d := engine.Evaluate(Message{}, at("12:00")); d.Action != Drop {
+		t.Errorf("Expected the message to be dropped without a digest, got %+v", d)
+	}
+}
+
+func TestNewEngineErrors(t *testing.T) {
+	configs := []Config{
+		{Mode: "some"},
+		{Chats: map[string]string{"1": "never"}},
+		{Rules: []Rule{{Regex: "("}}},
+		{Rules: []Rule{{Keywords: []string{" "}}}},
+		{QuietHours: QuietHours{Start: "22:00"}},
+		{QuietHours: QuietHours{Start: "25:00", End: "07:00"}},
+	}
+	for _, cfg := range configs {
+		if _, err := NewEngine(cfg, "me"); err == nil {
+			t.Errorf("Expected %+v to be rejected", cfg)
+		}
+	}
+}
+
+func TestDigest(t *testing.T) {
+	var digest Digest
+	digest.Add(Message{ChatID: "2", Text: "one"})
+	digest.Add(Message{ChatID: "1", Text: "two"})
+	digest.Add(Message{ChatID: "2", Text: "three"})
+
+	if digest.Len() != 3 {
+		t.Errorf("Expected 3 messages, got %d", digest.Len())
+	}
+	entries := digest.Take()
+	if len(entries) != 2 || entries[0].Count != 2 || entries[0].Latest.Text != "three" || entries[1].Latest.ChatID != "1" {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+	if digest.Len() != 0 || len(digest.Take()) != 0 {
+		t.Error("Expected Take to empty the digest")
+	}
+}
@@ -0,0 +1,378 @@
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+// Status is where a queued message is in its life
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSending Status = "sending"
+	StatusSent    Status = "sent"
+	StatusFailed  Status = "failed"
+)
+
+const (
+	// firstBackoff is the wait after the first failed attempt; it doubles
+	// with every attempt up to maxBackoff
+	firstBackoff = 5 * time.Second
+	maxBackoff   = 10 * time.Minute
+	// giveUpAfter is how long a message keeps being retried before it is
+	// marked failed and left for the user to retry or drop
+	giveUpAfter = 24 * time.Hour
+	// staleSending is how long a message may sit in "sending" before the
+	// process that claimed it is assumed to have died
+	staleSending = 2 * time.Minute
+	// keepSent is how long delivered messages stay in the list
+	keepSent = 24 * time.Hour
+)
+
+// Item is a message waiting to be delivered to a chat
+type Item struct {
+	ID string `json:"id"`
+	// Key is sent as the client context so Instagram drops duplicates when
+	// an attempt that looked failed actually got through
+	Key         string    `json:"key"`
+	ChatID      string    `json:"chat_id"` // Instagram thread ID
+	ChatTitle   string    `json:"chat_title"`
+	Text        string    `json:"text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	Status      Status    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Record converts the item into its output record. chatID is the chat's
+// internal ID.
+func (i *Item) Record(chatID string) output.OutboxRecord {
+	record := output.OutboxRecord{
+		ID:        i.ID,
+		ChatID:    chatID,
+		ChatTitle: i.ChatTitle,
+		Text:      i.Text,
+		CreatedAt: output.TimePtr(i.CreatedAt),
+		Attempts:  i.Attempts,
+		Status:    string(i.Status),
+		Error:     i.Error,
+	}
+	if i.Status == StatusPending {
+		record.NextAttempt = output.TimePtr(i.NextAttempt)
+	}
+	return record
+}
+
+// Outcome describes what happened to a message, for reporting
+func (i *Item) Outcome() string {
+	switch i.Status {
+	case StatusSent:
+		return fmt.Sprintf("Queued message sent to %s: %s", i.ChatTitle, preview(i.Text))
+	case StatusFailed:
+		return fmt.Sprintf("Queued message %s to %s failed: %s (outbox retry %s to try again)", i.ID, i.ChatTitle, i.Error, i.ID)
+	}
+	return fmt.Sprintf("Queued message %s to %s is %s", i.ID, i.ChatTitle, i.Status)
+}
+
+func preview(text string) string {
+	runes := []rune(text)
+	if len(runes) > 50 {
+		return string(runes[:47]) + "..."
+	}
+	return text
+}
+
+// backoff is how long to wait after the given number of failed attempts
+func backoff(attempts int) time.Duration {
+	wait := firstBackoff
+	for n := 1; n < attempts && wait < maxBackoff; n++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	return wait
+}
+
+// Store keeps queued messages in a JSON file. Every change reloads the file
+// under a lock file, so several GoGram processes can share it.
+type Store struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewStore creates a store backed by path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file the store is kept in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add queues text for a chat under the idempotency key. The item starts out
+// claimed, since the caller is expected to try sending it straight away.
+func (s *Store) Add(key, chatID, chatTitle, text string) (*Item, error) {
+	return s.add(key, chatID, chatTitle, text, StatusSending)
+}
+
+// Queue adds text for a chat to be sent by the next worker round, after any
+// messages already queued
+func (s *Store) Queue(key, chatID, chatTitle, text string) (*Item, error) {
+	return s.add(key, chatID, chatTitle, text, StatusPending)
+}
+
+func (s *Store) add(key, chatID, chatTitle, text string, status Status) (*Item, error) {
+	now := time.Now()
+	item := &Item{
+		ID:          newID(),
+		Key:         key,
+		ChatID:      chatID,
+		ChatTitle:   chatTitle,
+		Text:        text,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		NextAttempt: now,
+		Status:      status,
+	}
+
+	err := s.update(func(items []*Item) ([]*Item, error) {
+		return append(items, item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// List returns every item in the store, oldest first
+func (s *Store) List() ([]*Item, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	items, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	sortItems(items)
+	return items, nil
+}
+
+// Waiting counts the messages to a chat that are still to be sent
+func (s *Store) Waiting(chatID string) (int, error) {
+	items, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+
+	waiting := 0
+	for _, item := range items {
+		if item.ChatID == chatID && (item.Status == StatusPending || item.Status == StatusSending) {
+			waiting++
+		}
+	}
+	return waiting, nil
+}
+
+// Claim marks pending items whose next attempt is due by now as sending and
+// returns them, oldest first. Items a dead process left sending are claimed
+// again; the idempotency key keeps that from producing duplicates. A chat's
+// messages go out in order, so nothing is claimed behind an older message to
+// the same chat that is still waiting.
+func (s *Store) Claim(now time.Time) ([]*Item, error) {
+	var due []*Item
+	err := s.update(func(items []*Item) ([]*Item, error) {
+		sortItems(items)
+		blocked := make(map[string]bool)
+		for _, item := range items {
+			stale := item.Status == StatusSending && now.Sub(item.UpdatedAt) > staleSending
+			ready := (item.Status == StatusPending && !item.NextAttempt.After(now)) || stale
+			if ready && !blocked[item.ChatID] {
+				item.Status = StatusSending
+				item.UpdatedAt = now
+				due = append(due, item)
+			} else if item.Status == StatusPending || item.Status == StatusSending {
+				blocked[item.ChatID] = true
+			}
+		}
+		return items, nil
+	})
+	return due, err
+}
+
+// Finish records an attempt to send a claimed item. Temporary errors leave
+// it pending with a backoff until it is giveUpAfter old; other errors mark it
+// failed straight away.
+func (s *Store) Finish(id string, sendErr error, temporary bool) (*Item, error) {
+	return s.modify(id, func(item *Item) error {
+		now := time.Now()
+		item.UpdatedAt = now
+		if sendErr == nil {
+			item.Status = StatusSent
+			item.Error = ""
+			return nil
+		}
+
+		item.Attempts++
+		item.Error = sendErr.Error()
+		item.Status = StatusFailed
+		if temporary && now.Sub(item.CreatedAt) < giveUpAfter {
+			item.Status = StatusPending
+			item.NextAttempt = now.Add(backoff(item.Attempts))
+		}
+		return nil
+	})
+}
+
+// Retry makes a pending or failed item due immediately
+func (s *Store) Retry(id string) (*Item, error) {
+	return s.modify(id, func(item *Item) error {
+		if item.Status != StatusPending && item.Status != StatusFailed {
+			return fmt.Errorf("queued message %s is %s", id, item.Status)
+		}
+		requeue(item, time.Now())
+		return nil
+	})
+}
+
+// RetryAll makes every pending and failed item due immediately
+func (s *Store) RetryAll() ([]*Item, error) {
+	var retried []*Item
+	err := s.update(func(items []*Item) ([]*Item, error) {
+		now := time.Now()
+		for _, item := range items {
+			if item.Status == StatusPending || item.Status == StatusFailed {
+				requeue(item, now)
+				retried = append(retried, item)
+			}
+		}
+		return items, nil
+	})
+	return retried, err
+}
+
+// requeue makes item due at now. One that has already been given up on
+// gets another full giveUpAfter of automatic retries.
+func requeue(item *Item, now time.Time) {
+	item.Status = StatusPending
+	item.NextAttempt = now
+	item.UpdatedAt = now
+	if now.Sub(item.CreatedAt) >= giveUpAfter {
+		item.CreatedAt = now
+	}
+}
+
+// Drop removes an item that hasn't been sent, so it never will be
+func (s *Store) Drop(id string) (*Item, error) {
+	var dropped *Item
+	err := s.update(func(items []*Item) ([]*Item, error) {
+		for i, item := range items {
+			if item.ID != id {
+				continue
+			}
+			if item.Status == StatusSending || item.Status == StatusSent {
+				return nil, fmt.Errorf("queued message %s is already %s", id, item.Status)
+			}
+			dropped = item
+			return append(items[:i], items[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("no queued message with ID %s", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dropped, nil
+}
+
+// modify applies fn to the item with the given ID and saves it
+func (s *Store) modify(id string, fn func(*Item) error) (*Item, error) {
+	var found *Item
+	err := s.update(func(items []*Item) ([]*Item, error) {
+		for _, item := range items {
+			if item.ID == id {
+				if err := fn(item); err != nil {
+					return nil, err
+				}
+				found = item
+				return items, nil
+			}
+		}
+		return nil, fmt.Errorf("no queued message with ID %s", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// update applies fn to the stored items and saves the result, holding both
+// the in-process mutex and the cross-process lock file
+func (s *Store) update(fn func([]*Item) ([]*Item, error)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := storage.Lock(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to lock outbox: %v", err)
+	}
+	defer unlock()
+
+	items, err := s.load()
+	if err != nil {
+		return err
+	}
+	if items, err = fn(items); err != nil {
+		return err
+	}
+	return s.save(items)
+}
+
+func (s *Store) load() ([]*Item, error) {
+	var items []*Item
+	if err := storage.ReadJSON(s.path, &items); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load outbox: %v", err)
+	}
+	return items, nil
+}
+
+// save writes items, dropping sent ones older than keepSent
+func (s *Store) save(items []*Item) error {
+	kept := make([]*Item, 0, len(items))
+	for _, item := range items {
+		if item.Status != StatusSent || time.Since(item.UpdatedAt) < keepSent {
+			kept = append(kept, item)
+		}
+	}
+	sortItems(kept)
+
+	if err := storage.WriteJSON(s.path, kept); err != nil {
+		return fmt.Errorf("failed to save outbox: %v", err)
+	}
+	return nil
+}
+
+func sortItems(items []*Item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+}
+
+// newID returns a short random ID that's easy to type
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,195 @@
+package outbox
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{4, 40 * time.Second},
+		{7, 320 * time.Second},
+		{8, maxBackoff},
+		{20, maxBackoff},
+	}
+
+	for _, tc := range testCases {
+		if got := backoff(tc.attempts); got != tc.expected {
+			t.Errorf("backoff(%d) = %v, expected %v", tc.attempts, got, tc.expected)
+		}
+	}
+}
+
+func TestStoreLifecycle(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+
+	item, err := store.Add("key-1", "t1", "Alice", "hello")
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if item.Status != StatusSending {
+		t.Errorf("Expected a new item to start out claimed, got %s", item.Status)
+	}
+
+	// A temporary failure leaves it pending with a backoff
+	item, err = store.Finish(item.ID, errors.New("offline"), true)
+	if err != nil || item.Status != StatusPending || item.Attempts != 1 {
+		t.Fatalf("Expected a pending item after a temporary failure, got %+v (%v)", item, err)
+	}
+	if !item.NextAttempt.After(time.Now()) {
+		t.Error("Expected the next attempt to be in the future")
+	}
+
+	// It isn't claimed again before its backoff is up
+	if due, _ := store.Claim(time.Now()); len(due) != 0 {
+		t.Errorf("Expected nothing due yet, got %+v", due)
+	}
+	due, err := store.Claim(item.NextAttempt)
+	if err != nil || len(due) != 1 || due[0].Key != "key-1" {
+		t.Fatalf("Expected the item to be due after its backoff, got %+v (%v)", due, err)
+	}
+
+	// A permanent failure gives up straight away
+	item, _ = store.Finish(item.ID, errors.New("blocked"), false)
+	if item.Status != StatusFailed || item.Error != "blocked" {
+		t.Errorf("Expected a failed item, got %+v", item)
+	}
+
+	if item, err = store.Retry(item.ID); err != nil || item.Status != StatusPending {
+		t.Errorf("Expected retry to make the item pending, got %+v (%v)", item, err)
+	}
+
+	// A second store on the same file sees the same item
+	items, err := NewStore(store.Path()).List()
+	if err != nil || len(items) != 1 || items[0].ID != item.ID {
+		t.Fatalf("Expected one stored item, got %+v (%v)", items, err)
+	}
+
+	if _, err := store.Drop(item.ID); err != nil {
+		t.Errorf("Drop failed: %v", err)
+	}
+	if _, err := store.Drop(item.ID); err == nil {
+		t.Error("Expected dropping twice to fail")
+	}
+}
+
+func TestFinishGivesUpOnOldItems(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+	item, _ := store.Add("key-1", "t1", "Alice", "hello")
+
+	store.modify(item.ID, func(item *Item) error {
+		item.CreatedAt = time.Now().Add(-giveUpAfter - time.Minute)
+		return nil
+	})
+
+	item, _ = store.Finish(item.ID, errors.New("offline"), true)
+	if item.Status != StatusFailed {
+		t.Errorf("Expected an item past its retry window to fail, got %s", item.Status)
+	}
+
+	retried, err := store.RetryAll()
+	if err != nil || len(retried) != 1 {
+		t.Fatalf("Expected one item to be retried, got %+v (%v)", retried, err)
+	}
+	if time.Since(retried[0].CreatedAt) > time.Minute {
+		t.Error("Expected a retry to start a new retry window")
+	}
+}
+
+func TestClaimRecoversStaleSending(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+	item, _ := store.Add("key-1", "t1", "Alice", "hello")
+
+	if due, _ := store.Claim(time.Now()); len(due) != 0 {
+		t.Errorf("Expected an in-flight item to be left alone, got %+v", due)
+	}
+
+	due, err := store.Claim(time.Now().Add(staleSending + time.Minute))
+	if err != nil || len(due) != 1 || due[0].ID != item.ID {
+		t.Errorf("Expected an abandoned send to be claimed again, got %+v (%v)", due, err)
+	}
+}
+
+func TestClaimKeepsChatOrder(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+
+	// t1's first message is backing off, and a second is queued behind it
+	first, _ := store.Add("key-1", "t1", "Alice", "first")
+	first, _ = store.Finish(first.ID, errors.New("offline"), true)
+	second, _ := store.Queue("key-2", "t1", "Alice", "second")
+	other, _ := store.Queue("key-3", "t2", "Bob", "hi")
+
+	if waiting, err := store.Waiting("t1"); err != nil || waiting != 2 {
+		t.Errorf("Expected 2 messages waiting for t1, got %d (%v)", waiting, err)
+	}
+
+	due, err := store.Claim(time.Now())
+	if err != nil || len(due) != 1 || due[0].ID != other.ID {
+		t.Fatalf("Expected only t2's message to be due, got %+v (%v)", due, err)
+	}
+
+	due, err = store.Claim(first.NextAttempt)
+	if err != nil || len(due) != 2 || due[0].ID != first.ID || due[1].ID != second.ID {
+		t.Fatalf("Expected t1's messages to be due in order, got %+v (%v)", due, err)
+	}
+}
+
+func TestWorkerRunOnce(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+	offline := errors.New("offline")
+
+	for _, chatID := range []string{"t1", "t2", "t3"} {
+		item, _ := store.Add("key-"+chatID, chatID, chatID, "hi")
+		store.Finish(item.ID, offline, true)
+	}
+
+	var sent []string
+	var reported []*Item
+	failing := map[string]error{"t2": errors.New("not allowed")}
+	w := NewWorker(store, func(item *Item) error {
+		sent = append(sent, item.ChatID)
+		return failing[item.ChatID]
+	}, func(err error) bool {
+		return err == offline
+	}, func(item *Item) {
+		reported = append(reported, item)
+	})
+
+	if err := w.RunOnce(time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if len(sent) != 3 || len(reported) != 3 {
+		t.Fatalf("Expected all three to be attempted and reported, got %v and %+v", sent, reported)
+	}
+	if reported[0].Status != StatusSent || reported[1].Status != StatusFailed || reported[2].Status != StatusSent {
+		t.Errorf("Unexpected outcomes %s, %s, %s", reported[0].Status, reported[1].Status, reported[2].Status)
+	}
+
+	// While still offline, the first failure stops the round
+	for _, chatID := range []string{"t4", "t5"} {
+		item, _ := store.Add("key-"+chatID, chatID, chatID, "hi")
+		store.Finish(item.ID, offline, true)
+	}
+	sent = nil
+	failing = map[string]error{"t4": offline, "t5": offline}
+	if err := w.RunOnce(time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("Expected one attempt while offline, got %v", sent)
+	}
+
+	items, _ := store.List()
+	for _, item := range items {
+		if item.Status == StatusSending {
+			t.Errorf("Expected %s to be handed back, still sending", item.ChatID)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"sync"
+	"time"
+)
+
+// checkInterval is how often the worker looks for messages due a retry
+const checkInterval = 5 * time.Second
+
+// Worker retries queued messages in the background
+type Worker struct {
+	store     *Store
+	send      func(*Item) error
+	temporary func(error) bool
+	report    func(*Item)
+	mutex     sync.Mutex
+	stopChan  chan struct{}
+}
+
+// NewWorker creates a worker that sends through send, uses temporary to
+// decide whether a failure is worth retrying, and tells report about every
+// message that was delivered or given up on
+func NewWorker(store *Store, send func(*Item) error, temporary func(error) bool, report func(*Item)) *Worker {
+	return &Worker{
+		store:     store,
+		send:      send,
+		temporary: temporary,
+		report:    report,
+	}
+}
+
+// Start retries due messages now and then every checkInterval
+func (w *Worker) Start() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stopChan != nil {
+		return
+	}
+	w.stopChan = make(chan struct{})
+	go w.run(w.stopChan)
+}
+
+// Stop stops the background retries
+func (w *Worker) Stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stopChan != nil {
+		close(w.stopChan)
+		w.stopChan = nil
+	}
+}
+
+// IsRunning reports whether the worker has been started
+func (w *Worker) IsRunning() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.stopChan != nil
+}
+
+func (w *Worker) run(stop chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce attempts every message due by now. It stops after the first
+// temporary failure, since the rest would most likely fail the same way.
+func (w *Worker) RunOnce(now time.Time) error {
+	due, err := w.store.Claim(now)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range due {
+		finished, err := w.attempt(item)
+		if err != nil {
+			return err
+		}
+		if finished.Status == StatusPending {
+			// Hand the rest back untouched for the next round
+			for _, rest := range due[i+1:] {
+				w.store.modify(rest.ID, func(item *Item) error {
+					item.Status = StatusPending
+					return nil
+				})
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// attempt sends a claimed item once and records the result. Delivered and
+// failed items are reported; ones left pending will be retried.
+func (w *Worker) attempt(item *Item) (*Item, error) {
+	sendErr := w.send(item)
+	finished, err := w.store.Finish(item.ID, sendErr, w.temporary(sendErr))
+	if err != nil {
+		return nil, err
+	}
+	if finished.Status != StatusPending && w.report != nil {
+		w.report(finished)
+	}
+	return finished, nil
+}
@@ -0,0 +1,195 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format for CLI commands
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatTSV   Format = "tsv"
+)
+
+// Formats lists the supported formats in help order
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatTSV}
+
+// ParseFormat validates a format name
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, f := range Formats {
+		if f == format {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format: %s (expected json, yaml, table or tsv)", name)
+}
+
+// Column is a table column with an optional maximum display width
+type Column struct {
+	Header   string
+	MaxWidth int
+}
+
+// Table holds the tabular form of a result for table and tsv output
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// Write renders v as json/yaml, or table as an aligned table or tsv
+func Write(w io.Writer, format Format, v interface{}, table Table) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case FormatTSV:
+		return writeTSV(w, table)
+	case FormatTable, "":
+		return writeTable(w, table)
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// WriteRecord renders a single streamed record, one per line for json and tsv,
+// so long-running commands can be piped into jq or awk
+func WriteRecord(w io.Writer, format Format, v interface{}, row []string) error {
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(v)
+	case FormatYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "---\n%s", data)
+		return err
+	case FormatTSV, FormatTable, "":
+		_, err := fmt.Fprintln(w, strings.Join(escapeTSVRow(row), "\t"))
+		return err
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// writeTable prints an aligned table, truncating cells by display width
+func writeTable(w io.Writer, table Table) error {
+	widths := make([]int, len(table.Columns))
+	for i, col := range table.Columns {
+		widths[i] = runewidth.StringWidth(col.Header)
+	}
+
+	cells := make([][]string, len(table.Rows))
+	for r, row := range table.Rows {
+		cells[r] = make([]string, len(table.Columns))
+		for i := range table.Columns {
+			if i >= len(row) {
+				continue
+			}
+			cell := flattenCell(row[i])
+			if max := table.Columns[i].MaxWidth; max > 0 {
+				cell = Truncate(cell, max)
+			}
+			cells[r][i] = cell
+			if width := runewidth.StringWidth(cell); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	writeRow := func(row []string) error {
+		var line strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				line.WriteString(cell)
+			} else {
+				line.WriteString(runewidth.FillRight(cell, widths[i]))
+				line.WriteString("  ")
+			}
+		}
+		_, err := fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+		return err
+	}
+
+	headers := make([]string, len(table.Columns))
+	rules := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		headers[i] = col.Header
+		rules[i] = strings.Repeat("-", runewidth.StringWidth(col.Header))
+	}
+
+	if err := writeRow(headers); err != nil {
+		return err
+	}
+	if err := writeRow(rules); err != nil {
+		return err
+	}
+	for _, row := range cells {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTSV prints a header row and tab separated rows without truncation
+func writeTSV(w io.Writer, table Table) error {
+	headers := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		headers[i] = strings.ToLower(strings.ReplaceAll(col.Header, " ", "_"))
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
+		return err
+	}
+
+	for _, row := range table.Rows {
+		if _, err := fmt.Fprintln(w, strings.Join(escapeTSVRow(row), "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeTSVRow escapes the characters that would break a tsv row
+func escapeTSVRow(row []string) []string {
+	escaped := make([]string, len(row))
+	replacer := strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+	for i, cell := range row {
+		escaped[i] = replacer.Replace(cell)
+	}
+	return escaped
+}
+
+// flattenCell keeps multi-line values on one table row
+func flattenCell(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Truncate shortens s to at most width display columns, ending with "..."
+func Truncate(s string, width int) string {
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return runewidth.Truncate(s, width, "")
+	}
+	return runewidth.Truncate(s, width, "...")
+}
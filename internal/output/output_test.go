@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"json", "YAML", " table ", "tsv"} {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("ParseFormat(%q) returned error: %v", name, err)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for unknown format")
+	}
+}
+
+func TestTruncateMultiByte(t *testing.T) {
+	title := "日本語のグループチャット"
+
+	truncated := Truncate(title, 10)
+	if !strings.HasSuffix(truncated, "...") {
+		t.Errorf("Expected truncated title to end with ..., got %q", truncated)
+	}
+	if !utf8Valid(truncated) {
+		t.Errorf("Truncate produced invalid UTF-8: %q", truncated)
+	}
+
+	if Truncate("short", 10) != "short" {
+		t.Error("Expected short strings to be unchanged")
+	}
+}
+
+func TestWriteTableAlignsWideRunes(t *testing.T) {
+	table := Table{
+		Columns: []Column{{Header: "ID"}, {Header: "Title", MaxWidth: 8}, {Header: "Last"}},
+		Rows: [][]string{
+			{"100000", "Café ☕", "hi"},
+			{"100001", "日本語日本語", "yo"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTable, nil, table); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[3], "日本...") {
+		t.Errorf("Expected wide title to be truncated by display width, got %q", lines[3])
+	}
+}
+
+func TestWriteTSVEscapes(t *testing.T) {
+	table := Table{
+		Columns: []Column{{Header: "Chat ID"}, {Header: "Text"}},
+		Rows:    [][]string{{"100000", "line one\nline\ttwo"}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTSV, nil, table); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	expected := "chat_id\ttext\n100000\tline one\\nline\\ttwo\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestChatRecordJSONMatchesProtoFields(t *testing.T) {
+	record := ChatRecord{
+		ID:           "340282366841710300949128",
+		InternalID:   "100000",
+		Title:        "Team",
+		Users:        []UserRecord{},
+		LastActivity: TimePtr(time.Unix(1700000000, 0)),
+		IsGroup:      true,
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	for _, key := range []string{"id", "internal_id", "title", "users", "last_message", "last_activity", "unread_count", "is_group"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("Expected JSON field %q in %s", key, data)
+		}
+	}
+}
+
+func TestMessagesTableSummarizesReactions(t *testing.T) {
+	table := MessagesTable([]MessageRecord{{
+		Sender:    "alice",
+		Kind:      "text",
+		Text:      "hi",
+		Reactions: []ReactionRecord{{Emoji: "❤️"}, {Emoji: "😂"}, {Emoji: "❤️"}},
+	}})
+
+	if text := table.Rows[0][3]; text != "hi  (❤️ 2  😂 1)" {
+		t.Errorf("Unexpected text %q", text)
+	}
+}
+
+func utf8Valid(s string) bool {
+	return strings.ToValidUTF8(s, "�") == s
+}
@@ -0,0 +1,410 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The records below mirror the proto Chat, Message, User, AuthStatusResponse,
+// ConfigKeyValue and NotificationUpdate types field for field, so CLI output
+// and gRPC responses can be consumed the same way. Each type they are made
+// from has a Record method, so this package doesn't depend on any of them.
+
+// UserRecord mirrors the proto User message
+type UserRecord struct {
+	ID            string `json:"id" yaml:"id"`
+	Username      string `json:"username" yaml:"username"`
+	FullName      string `json:"full_name" yaml:"full_name"`
+	ProfilePicURL string `json:"profile_pic_url" yaml:"profile_pic_url"`
+	IsVerified    bool   `json:"is_verified" yaml:"is_verified"`
+}
+
+// ChatRecord mirrors the proto Chat message
+type ChatRecord struct {
+	ID           string       `json:"id" yaml:"id"`
+	InternalID   string       `json:"internal_id" yaml:"internal_id"`
+	Title        string       `json:"title" yaml:"title"`
+	Users        []UserRecord `json:"users" yaml:"users"`
+	LastMessage  string       `json:"last_message" yaml:"last_message"`
+	LastActivity *time.Time   `json:"last_activity,omitempty" yaml:"last_activity,omitempty"`
+	UnreadCount  int          `json:"unread_count" yaml:"unread_count"`
+	IsGroup      bool         `json:"is_group" yaml:"is_group"`
+	Muted        bool         `json:"muted" yaml:"muted"`
+	Pending      bool         `json:"pending" yaml:"pending"`
+	Pinned       bool         `json:"pinned" yaml:"pinned"`
+	MutedLocally bool         `json:"muted_locally" yaml:"muted_locally"`
+	Archived     bool         `json:"archived" yaml:"archived"`
+	Labels       []string     `json:"labels" yaml:"labels"`
+}
+
+// MessageRecord mirrors the proto Message message
+type MessageRecord struct {
+	ID         string            `json:"id" yaml:"id"`
+	Text       string            `json:"text" yaml:"text"`
+	Sender     string            `json:"sender" yaml:"sender"`
+	Timestamp  *time.Time        `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Type       string            `json:"type" yaml:"type"`
+	ChatID     string            `json:"chat_id" yaml:"chat_id"`
+	Kind       string            `json:"kind" yaml:"kind"`
+	Attachment *AttachmentRecord `json:"attachment,omitempty" yaml:"attachment,omitempty"`
+	Reactions  []ReactionRecord  `json:"reactions,omitempty" yaml:"reactions,omitempty"`
+}
+
+// ReactionRecord mirrors the proto Reaction message
+type ReactionRecord struct {
+	Emoji     string     `json:"emoji" yaml:"emoji"`
+	SenderID  string     `json:"sender_id" yaml:"sender_id"`
+	Sender    string     `json:"sender" yaml:"sender"`
+	Timestamp *time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// AttachmentRecord mirrors the proto Attachment message
+type AttachmentRecord struct {
+	Kind       string `json:"kind" yaml:"kind"`
+	URL        string `json:"url" yaml:"url"`
+	PreviewURL string `json:"preview_url" yaml:"preview_url"`
+	Width      int    `json:"width" yaml:"width"`
+	Height     int    `json:"height" yaml:"height"`
+	DurationMs int64  `json:"duration_ms" yaml:"duration_ms"`
+	MediaID    string `json:"media_id" yaml:"media_id"`
+	Code       string `json:"code" yaml:"code"`
+	Owner      string `json:"owner" yaml:"owner"`
+	Caption    string `json:"caption" yaml:"caption"`
+	Title      string `json:"title" yaml:"title"`
+	LocalPath  string `json:"local_path,omitempty" yaml:"local_path,omitempty"`
+}
+
+// StatusRecord mirrors the proto AuthStatusResponse message
+type StatusRecord struct {
+	IsLoggedIn           bool   `json:"is_logged_in" yaml:"is_logged_in"`
+	Username             string `json:"username" yaml:"username"`
+	UnreadCount          int    `json:"unread_count" yaml:"unread_count"`
+	NotificationsRunning bool   `json:"notifications_running" yaml:"notifications_running"`
+	InvisibleMode        bool   `json:"invisible_mode" yaml:"invisible_mode"`
+}
+
+// ConfigRecord mirrors the proto ConfigKeyValue message
+type ConfigRecord struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// NotificationRecord mirrors the proto NotificationUpdate message
+type NotificationRecord struct {
+	ChatID         string     `json:"chat_id" yaml:"chat_id"`
+	ChatTitle      string     `json:"chat_title" yaml:"chat_title"`
+	Sender         string     `json:"sender" yaml:"sender"`
+	MessagePreview string     `json:"message_preview" yaml:"message_preview"`
+	Timestamp      *time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	UnreadCount    int        `json:"unread_count" yaml:"unread_count"`
+}
+
+// MediaRecord is a downloaded attachment
+type MediaRecord struct {
+	ChatID       string     `json:"chat_id" yaml:"chat_id"`
+	MessageID    string     `json:"message_id" yaml:"message_id"`
+	Kind         string     `json:"kind" yaml:"kind"`
+	Path         string     `json:"path" yaml:"path"`
+	Size         int64      `json:"size" yaml:"size"`
+	SHA256       string     `json:"sha256" yaml:"sha256"`
+	DownloadedAt *time.Time `json:"downloaded_at,omitempty" yaml:"downloaded_at,omitempty"`
+}
+
+// ScheduledRecord is a message waiting to be sent later
+type ScheduledRecord struct {
+	ID        string     `json:"id" yaml:"id"`
+	ChatID    string     `json:"chat_id" yaml:"chat_id"`
+	ChatTitle string     `json:"chat_title" yaml:"chat_title"`
+	Text      string     `json:"text" yaml:"text"`
+	SendAt    *time.Time `json:"send_at,omitempty" yaml:"send_at,omitempty"`
+	Status    string     `json:"status" yaml:"status"`
+	Error     string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// OutboxRecord is a message waiting in the outbox to be delivered
+type OutboxRecord struct {
+	ID          string     `json:"id" yaml:"id"`
+	ChatID      string     `json:"chat_id" yaml:"chat_id"`
+	ChatTitle   string     `json:"chat_title" yaml:"chat_title"`
+	Text        string     `json:"text" yaml:"text"`
+	CreatedAt   *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	Attempts    int        `json:"attempts" yaml:"attempts"`
+	NextAttempt *time.Time `json:"next_attempt,omitempty" yaml:"next_attempt,omitempty"`
+	Status      string     `json:"status" yaml:"status"`
+	Error       string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SearchResultRecord mirrors the proto SearchResult message
+type SearchResultRecord struct {
+	ChatID    string     `json:"chat_id" yaml:"chat_id"`
+	ChatTitle string     `json:"chat_title" yaml:"chat_title"`
+	MessageID string     `json:"message_id" yaml:"message_id"`
+	Sender    string     `json:"sender" yaml:"sender"`
+	Timestamp *time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Kind      string     `json:"kind" yaml:"kind"`
+	Text      string     `json:"text" yaml:"text"`
+	Snippet   string     `json:"snippet" yaml:"snippet"`
+}
+
+// LabelRecord is a chat label and how many chats carry it
+type LabelRecord struct {
+	Label string `json:"label" yaml:"label"`
+	Chats int    `json:"chats" yaml:"chats"`
+}
+
+// ImportRecord describes one conversation imported from a data download
+type ImportRecord struct {
+	ChatID   string `json:"chat_id" yaml:"chat_id"`
+	Title    string `json:"title" yaml:"title"`
+	Matched  bool   `json:"matched" yaml:"matched"`
+	Messages int    `json:"messages" yaml:"messages"`
+	Added    int    `json:"added" yaml:"added"`
+}
+
+// ChatsTable is the table form of a chat list
+func ChatsTable(records []ChatRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "ID"},
+		{Header: "Title", MaxWidth: 24},
+		{Header: "Unread"},
+		{Header: "Labels", MaxWidth: 20},
+		{Header: "Last Message", MaxWidth: 40},
+	}}
+
+	for _, r := range records {
+		lastMsg := r.LastMessage
+		if lastMsg == "" {
+			lastMsg = "(no message)"
+		}
+		title := r.Title
+		if r.Pinned {
+			title = "📌 " + title
+		}
+		labels := make([]string, len(r.Labels))
+		for i, label := range r.Labels {
+			labels[i] = "#" + label
+		}
+		table.Rows = append(table.Rows, []string{r.InternalID, title, fmt.Sprintf("%d", r.UnreadCount), strings.Join(labels, " "), lastMsg})
+	}
+	return table
+}
+
+// LabelsTable is the table form of the label list
+func LabelsTable(records []LabelRecord) Table {
+	table := Table{Columns: []Column{{Header: "Label"}, {Header: "Chats"}}}
+	for _, r := range records {
+		table.Rows = append(table.Rows, []string{r.Label, strconv.Itoa(r.Chats)})
+	}
+	return table
+}
+
+// MessagesTable is the table form of a message history, oldest first
+func MessagesTable(records []MessageRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "Time"},
+		{Header: "Sender", MaxWidth: 20},
+		{Header: "Type"},
+		{Header: "Text", MaxWidth: 60},
+	}}
+
+	for _, r := range records {
+		kind := r.Kind
+		if kind == "" {
+			kind = strings.ToLower(r.Type)
+		}
+
+		text := r.Text
+		if text == "" && r.Attachment != nil {
+			text = r.Attachment.LocalPath
+			if text == "" {
+				text = r.Attachment.URL
+			}
+		}
+		if len(r.Reactions) > 0 {
+			text += "  " + reactionSummary(r.Reactions)
+		}
+		table.Rows = append(table.Rows, []string{formatTime(r.Timestamp), r.Sender, kind, text})
+	}
+	return table
+}
+
+// MediaTable is the table form of downloaded media, newest first
+func MediaTable(records []MediaRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "Downloaded"},
+		{Header: "Chat"},
+		{Header: "Kind"},
+		{Header: "Size"},
+		{Header: "Path", MaxWidth: 60},
+	}}
+
+	for _, r := range records {
+		table.Rows = append(table.Rows, []string{formatTime(r.DownloadedAt), r.ChatID, r.Kind, FormatSize(r.Size), r.Path})
+	}
+	return table
+}
+
+// ScheduledTable is the table form of scheduled messages
+func ScheduledTable(records []ScheduledRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "ID"},
+		{Header: "Send At"},
+		{Header: "Status"},
+		{Header: "Chat", MaxWidth: 24},
+		{Header: "Text", MaxWidth: 50},
+	}}
+
+	for _, r := range records {
+		status := r.Status
+		if r.Error != "" {
+			status += ": " + r.Error
+		}
+		table.Rows = append(table.Rows, []string{r.ID, formatTime(r.SendAt), status, r.ChatTitle, r.Text})
+	}
+	return table
+}
+
+// OutboxTable is the table form of queued messages
+func OutboxTable(records []OutboxRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "ID"},
+		{Header: "Queued"},
+		{Header: "Tries"},
+		{Header: "Next Try"},
+		{Header: "Status", MaxWidth: 40},
+		{Header: "Chat", MaxWidth: 24},
+		{Header: "Text", MaxWidth: 40},
+	}}
+
+	for _, r := range records {
+		status := r.Status
+		if r.Error != "" && r.Status != "sent" {
+			status += ": " + r.Error
+		}
+		table.Rows = append(table.Rows, []string{r.ID, formatTime(r.CreatedAt), strconv.Itoa(r.Attempts), formatTime(r.NextAttempt), status, r.ChatTitle, r.Text})
+	}
+	return table
+}
+
+// SearchTable is the table form of search results
+func SearchTable(records []SearchResultRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "Time"},
+		{Header: "Chat", MaxWidth: 24},
+		{Header: "ID"},
+		{Header: "Sender", MaxWidth: 20},
+		{Header: "Match", MaxWidth: 60},
+	}}
+
+	for _, r := range records {
+		table.Rows = append(table.Rows, []string{formatTime(r.Timestamp), r.ChatTitle, r.ChatID, r.Sender, r.Snippet})
+	}
+	return table
+}
+
+// ImportTable is the table form of an archive import
+func ImportTable(records []ImportRecord) Table {
+	table := Table{Columns: []Column{
+		{Header: "ID"},
+		{Header: "Chat", MaxWidth: 30},
+		{Header: "In inbox"},
+		{Header: "Messages"},
+		{Header: "New"},
+	}}
+
+	for _, r := range records {
+		inbox := "yes"
+		if !r.Matched {
+			inbox = "no"
+		}
+		table.Rows = append(table.Rows, []string{r.ChatID, r.Title, inbox, strconv.Itoa(r.Messages), strconv.Itoa(r.Added)})
+	}
+	return table
+}
+
+// FormatSize renders a byte count as B, KB, MB or GB
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	value := float64(n) / unit
+	for _, suffix := range []string{"KB", "MB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f GB", value)
+}
+
+// StatusTable is the table form of the login status
+func StatusTable(record StatusRecord) Table {
+	return Table{
+		Columns: []Column{{Header: "Logged In"}, {Header: "Username"}, {Header: "Unread"}, {Header: "Notifications"}, {Header: "Invisible"}},
+		Rows: [][]string{{
+			fmt.Sprintf("%v", record.IsLoggedIn),
+			record.Username,
+			fmt.Sprintf("%d", record.UnreadCount),
+			fmt.Sprintf("%v", record.NotificationsRunning),
+			fmt.Sprintf("%v", record.InvisibleMode),
+		}},
+	}
+}
+
+// ConfigTable is the table form of config values
+func ConfigTable(records []ConfigRecord) Table {
+	table := Table{Columns: []Column{{Header: "Key"}, {Header: "Value"}}}
+	for _, r := range records {
+		table.Rows = append(table.Rows, []string{r.Key, r.Value})
+	}
+	return table
+}
+
+// NotificationRow is the tsv form of a single notification
+func NotificationRow(record NotificationRecord) []string {
+	return []string{
+		formatTime(record.Timestamp),
+		record.ChatID,
+		record.ChatTitle,
+		record.Sender,
+		record.MessagePreview,
+	}
+}
+
+// reactionSummary counts reactions by emoji, in the order they first appear,
+// the way chat.ReactionSummary does
+func reactionSummary(records []ReactionRecord) string {
+	var order []string
+	counts := make(map[string]int)
+	for _, r := range records {
+		if counts[r.Emoji] == 0 {
+			order = append(order, r.Emoji)
+		}
+		counts[r.Emoji]++
+	}
+
+	parts := make([]string, len(order))
+	for i, emoji := range order {
+		parts[i] = fmt.Sprintf("%s %d", emoji, counts[emoji])
+	}
+	return "(" + strings.Join(parts, "  ") + ")"
+}
+
+// TimePtr is t for a record, or nil when it isn't set
+func TimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,106 @@
+package schedule
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// checkInterval is how often the dispatcher looks for due messages
+	checkInterval = 15 * time.Second
+	// DefaultGrace is how late a message may still be sent. Anything older is
+	// reported as missed instead, since it was probably only relevant then.
+	DefaultGrace = 10 * time.Minute
+)
+
+// Dispatcher sends scheduled messages when they come due
+type Dispatcher struct {
+	store    *Store
+	send     func(*Message) error
+	report   func(*Message)
+	grace    time.Duration
+	mutex    sync.Mutex
+	stopChan chan struct{}
+}
+
+// NewDispatcher creates a dispatcher that sends through send and tells report
+// about every message that was sent, failed or was missed
+func NewDispatcher(store *Store, send func(*Message) error, report func(*Message)) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		send:   send,
+		report: report,
+		grace:  DefaultGrace,
+	}
+}
+
+// Start checks for due messages now and then every checkInterval
+func (d *Dispatcher) Start() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stopChan != nil {
+		return
+	}
+	d.stopChan = make(chan struct{})
+	go d.run(d.stopChan)
+}
+
+// Stop stops the background checks
+func (d *Dispatcher) Stop() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stopChan != nil {
+		close(d.stopChan)
+		d.stopChan = nil
+	}
+}
+
+// IsRunning reports whether the dispatcher has been started
+func (d *Dispatcher) IsRunning() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.stopChan != nil
+}
+
+func (d *Dispatcher) run(stop chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		d.RunOnce(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends the messages due at now and reports missed ones
+func (d *Dispatcher) RunOnce(now time.Time) error {
+	due, reported, err := d.store.Claim(now, d.grace)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range reported {
+		d.notify(msg)
+	}
+
+	for _, msg := range due {
+		finished, err := d.store.Finish(msg.ID, d.send(msg))
+		if err != nil {
+			return err
+		}
+		d.notify(finished)
+	}
+	return nil
+}
+
+func (d *Dispatcher) notify(msg *Message) {
+	if d.report != nil {
+		d.report(msg)
+	}
+}
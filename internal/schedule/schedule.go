@@ -0,0 +1,280 @@
+package schedule
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abhi-praj/GoGram/internal/output"
+	"github.com/abhi-praj/GoGram/internal/storage"
+)
+
+// Status is where a scheduled message is in its life
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSending   Status = "sending"
+	StatusSent      Status = "sent"
+	StatusFailed    Status = "failed"
+	StatusMissed    Status = "missed"
+	StatusCancelled Status = "cancelled"
+)
+
+const (
+	// staleSending is how long a message may sit in "sending" before the
+	// process that claimed it is assumed to have died
+	staleSending = 5 * time.Minute
+	// keepDone is how long sent and cancelled messages stay in the list
+	keepDone = 30 * 24 * time.Hour
+)
+
+// Message is a message waiting to be sent to a chat
+type Message struct {
+	ID        string    `json:"id"`
+	ChatID    string    `json:"chat_id"` // Instagram thread ID
+	ChatTitle string    `json:"chat_title"`
+	Text      string    `json:"text"`
+	SendAt    time.Time `json:"send_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Done reports whether the message needs no more attention
+func (m *Message) Done() bool {
+	return m.Status == StatusSent || m.Status == StatusCancelled
+}
+
+// Record converts the message into its output record. chatID is the chat's
+// internal ID.
+func (m *Message) Record(chatID string) output.ScheduledRecord {
+	return output.ScheduledRecord{
+		ID:        m.ID,
+		ChatID:    chatID,
+		ChatTitle: m.ChatTitle,
+		Text:      m.Text,
+		SendAt:    output.TimePtr(m.SendAt),
+		Status:    string(m.Status),
+		Error:     m.Error,
+	}
+}
+
+// Outcome describes what happened to a message, for reporting
+func (m *Message) Outcome() string {
+	switch m.Status {
+	case StatusSent:
+		return fmt.Sprintf("Scheduled message sent to %s: %s", m.ChatTitle, preview(m.Text))
+	case StatusFailed:
+		return fmt.Sprintf("Scheduled message %s to %s failed: %s", m.ID, m.ChatTitle, m.Error)
+	case StatusMissed:
+		return fmt.Sprintf("Missed scheduled message %s to %s (due %s): %s", m.ID, m.ChatTitle, m.SendAt.Local().Format("Jan 2 15:04"), preview(m.Text))
+	}
+	return fmt.Sprintf("Scheduled message %s to %s is %s", m.ID, m.ChatTitle, m.Status)
+}
+
+func preview(text string) string {
+	runes := []rune(text)
+	if len(runes) > 50 {
+		return string(runes[:47]) + "..."
+	}
+	return text
+}
+
+// Store keeps scheduled messages in a JSON file. Every change reloads the
+// file under a lock file, so several GoGram processes can share it.
+type Store struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewStore creates a store backed by path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file the store is kept in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add schedules text to be sent to a chat at sendAt
+func (s *Store) Add(chatID, chatTitle, text string, sendAt time.Time) (*Message, error) {
+	now := time.Now()
+	msg := &Message{
+		ID:        newID(),
+		ChatID:    chatID,
+		ChatTitle: chatTitle,
+		Text:      text,
+		SendAt:    sendAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Status:    StatusPending,
+	}
+
+	err := s.update(func(messages []*Message) ([]*Message, error) {
+		return append(messages, msg), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// List returns every message in the store, soonest first
+func (s *Store) List() ([]*Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	messages, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	sortMessages(messages)
+	return messages, nil
+}
+
+// Cancel stops a pending message from being sent, or dismisses a missed or
+// failed one
+func (s *Store) Cancel(id string) (*Message, error) {
+	var cancelled *Message
+	err := s.update(func(messages []*Message) ([]*Message, error) {
+		for _, msg := range messages {
+			if msg.ID != id {
+				continue
+			}
+			if msg.Done() || msg.Status == StatusSending {
+				return nil, fmt.Errorf("scheduled message %s is already %s", id, msg.Status)
+			}
+			msg.Status = StatusCancelled
+			msg.UpdatedAt = time.Now()
+			cancelled = msg
+			return messages, nil
+		}
+		return nil, fmt.Errorf("no scheduled message with ID %s", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cancelled, nil
+}
+
+// Claim marks pending messages due by now as sending and returns them. Ones
+// more than grace overdue are marked missed instead, and ones a dead process
+// left sending are marked failed; both are returned as reported so the user
+// hears about them. A claimed message won't be claimed again by another
+// process.
+func (s *Store) Claim(now time.Time, grace time.Duration) (due, reported []*Message, err error) {
+	err = s.update(func(messages []*Message) ([]*Message, error) {
+		for _, msg := range messages {
+			switch {
+			case msg.Status == StatusPending && !msg.SendAt.After(now):
+				if now.Sub(msg.SendAt) > grace {
+					msg.Status = StatusMissed
+					reported = append(reported, msg)
+				} else {
+					msg.Status = StatusSending
+					due = append(due, msg)
+				}
+				msg.UpdatedAt = now
+			case msg.Status == StatusSending && now.Sub(msg.UpdatedAt) > staleSending:
+				msg.Status = StatusFailed
+				msg.Error = "interrupted while sending; it may or may not have been delivered"
+				msg.UpdatedAt = now
+				reported = append(reported, msg)
+			}
+		}
+		return messages, nil
+	})
+	return due, reported, err
+}
+
+// Finish records the result of sending a claimed message
+func (s *Store) Finish(id string, sendErr error) (*Message, error) {
+	var finished *Message
+	err := s.update(func(messages []*Message) ([]*Message, error) {
+		for _, msg := range messages {
+			if msg.ID == id {
+				msg.Status = StatusSent
+				msg.Error = ""
+				if sendErr != nil {
+					msg.Status = StatusFailed
+					msg.Error = sendErr.Error()
+				}
+				msg.UpdatedAt = time.Now()
+				finished = msg
+				return messages, nil
+			}
+		}
+		return nil, fmt.Errorf("no scheduled message with ID %s", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return finished, nil
+}
+
+// update applies fn to the stored messages and saves the result, holding
+// both the in-process mutex and the cross-process lock file
+func (s *Store) update(fn func([]*Message) ([]*Message, error)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := storage.Lock(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to lock scheduled messages: %v", err)
+	}
+	defer unlock()
+
+	messages, err := s.load()
+	if err != nil {
+		return err
+	}
+	if messages, err = fn(messages); err != nil {
+		return err
+	}
+	return s.save(messages)
+}
+
+func (s *Store) load() ([]*Message, error) {
+	var messages []*Message
+	if err := storage.ReadJSON(s.path, &messages); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load scheduled messages: %v", err)
+	}
+	return messages, nil
+}
+
+// save writes messages, dropping finished ones older than keepDone
+func (s *Store) save(messages []*Message) error {
+	kept := make([]*Message, 0, len(messages))
+	for _, msg := range messages {
+		if !msg.Done() || time.Since(msg.UpdatedAt) < keepDone {
+			kept = append(kept, msg)
+		}
+	}
+	sortMessages(kept)
+
+	if err := storage.WriteJSON(s.path, kept); err != nil {
+		return fmt.Errorf("failed to save scheduled messages: %v", err)
+	}
+	return nil
+}
+
+func sortMessages(messages []*Message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].SendAt.Before(messages[j].SendAt)
+	})
+}
+
+// newID returns a short random ID that's easy to type
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.Local)
+
+	testCases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"+90m", now.Add(90 * time.Minute)},
+		{"+1h30m", now.Add(90 * time.Minute)},
+		{"+2d", now.Add(48 * time.Hour)},
+		{"+1d12h", now.Add(36 * time.Hour)},
+		{"18:30", time.Date(2026, 3, 10, 18, 30, 0, 0, time.Local)},
+		{"09:00", time.Date(2026, 3, 11, 9, 0, 0, 0, time.Local)},
+		{"2026-03-12T08:15", time.Date(2026, 3, 12, 8, 15, 0, 0, time.Local)},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseTime(tc.spec, now)
+		if err != nil {
+			t.Errorf("ParseTime(%q) failed: %v", tc.spec, err)
+			continue
+		}
+		if !got.Equal(tc.expected) {
+			t.Errorf("ParseTime(%q) = %v, expected %v", tc.spec, got, tc.expected)
+		}
+	}
+
+	for _, spec := range []string{"+0m", "+soon", "tomorrow", "2026-03-01T08:00", "25:00"} {
+		if _, err := ParseTime(spec, now); err == nil {
+			t.Errorf("Expected ParseTime(%q) to fail", spec)
+		}
+	}
+}
+
+func TestParseDelay(t *testing.T) {
+	if d, err := ParseDelay("01:00"); err != nil || d != time.Hour {
+		t.Errorf("Expected 1h, got %v (%v)", d, err)
+	}
+	if d, err := ParseDelay("90m"); err != nil || d != 90*time.Minute {
+		t.Errorf("Expected 90m, got %v (%v)", d, err)
+	}
+	if _, err := ParseDelay("1:75"); err == nil {
+		t.Error("Expected an error for 75 minutes")
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.Local)
+
+	sendAt, text, err := ParseArgs("+10m see you soon", now, time.Hour)
+	if err != nil || !sendAt.Equal(now.Add(10*time.Minute)) || text != "see you soon" {
+		t.Errorf("Unexpected result %v %q (%v)", sendAt, text, err)
+	}
+
+	sendAt, text, err = ParseArgs("5 apples please", now, time.Hour)
+	if err != nil || !sendAt.Equal(now.Add(time.Hour)) || text != "5 apples please" {
+		t.Errorf("Expected the default delay for plain text, got %v %q (%v)", sendAt, text, err)
+	}
+
+	if _, _, err := ParseArgs("+10m", now, time.Hour); err == nil {
+		t.Error("Expected an error without text")
+	}
+	if _, _, err := ParseArgs("+later hi", now, time.Hour); err == nil {
+		t.Error("Expected an error for a bad duration")
+	}
+	if _, _, err := ParseArgs("2026-03-01T08:00 hi", now, time.Hour); err == nil {
+		t.Error("Expected an error for a time in the past")
+	}
+}
+
+func TestStoreLifecycle(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "scheduled.json"))
+	now := time.Now()
+
+	soon, err := store.Add("t1", "Alice", "hello", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	later, _ := store.Add("t1", "Alice", "later", now.Add(time.Hour))
+	old, _ := store.Add("t2", "Bob", "too late", now.Add(-time.Hour))
+
+	// A second store on the same file sees the same messages
+	messages, err := NewStore(store.Path()).List()
+	if err != nil || len(messages) != 3 || messages[0].ID != old.ID {
+		t.Fatalf("Expected 3 messages, oldest first, got %+v (%v)", messages, err)
+	}
+
+	due, reported, err := store.Claim(now.Add(2*time.Minute), DefaultGrace)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != soon.ID || due[0].Status != StatusSending {
+		t.Errorf("Expected only the first message to be due, got %+v", due)
+	}
+	if len(reported) != 1 || reported[0].ID != old.ID || reported[0].Status != StatusMissed {
+		t.Errorf("Expected the hour-old message to be missed, got %+v", reported)
+	}
+
+	// Claimed messages aren't handed out twice
+	if due, _, _ := store.Claim(now.Add(2*time.Minute), DefaultGrace); len(due) != 0 {
+		t.Errorf("Expected nothing new to be due, got %+v", due)
+	}
+
+	if msg, err := store.Finish(soon.ID, nil); err != nil || msg.Status != StatusSent {
+		t.Errorf("Expected the message to be sent, got %+v (%v)", msg, err)
+	}
+
+	if msg, err := store.Cancel(later.ID); err != nil || msg.Status != StatusCancelled {
+		t.Errorf("Expected the message to be cancelled, got %+v (%v)", msg, err)
+	}
+	if _, err := store.Cancel(later.ID); err == nil {
+		t.Error("Expected cancelling twice to fail")
+	}
+	if _, err := store.Cancel("nope"); err == nil {
+		t.Error("Expected an error for an unknown ID")
+	}
+}
+
+func TestClaimFailsStaleSending(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "scheduled.json"))
+	now := time.Now()
+	store.Add("t1", "Alice", "hello", now)
+
+	if due, _, _ := store.Claim(now, DefaultGrace); len(due) != 1 {
+		t.Fatalf("Expected the message to be claimed")
+	}
+
+	_, reported, err := store.Claim(now.Add(staleSending+time.Minute), DefaultGrace)
+	if err != nil || len(reported) != 1 || reported[0].Status != StatusFailed {
+		t.Errorf("Expected an abandoned send to be reported as failed, got %+v (%v)", reported, err)
+	}
+}
+
+func TestDispatcherRunOnce(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "scheduled.json"))
+	now := time.Now()
+	store.Add("t1", "Alice", "works", now.Add(-time.Minute))
+	store.Add("t2", "Bob", "breaks", now.Add(-time.Minute))
+	store.Add("t3", "Carol", "missed", now.Add(-time.Hour))
+	store.Add("t4", "Dave", "future", now.Add(time.Hour))
+
+	var sent []string
+	outcomes := make(map[string]Status)
+	d := NewDispatcher(store, func(msg *Message) error {
+		sent = append(sent, msg.ChatID)
+		if msg.ChatID == "t2" {
+			return errors.New("boom")
+		}
+		return nil
+	}, func(msg *Message) {
+		outcomes[msg.ChatID] = msg.Status
+	})
+
+	if err := d.RunOnce(now); err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Errorf("Expected 2 sends, got %v", sent)
+	}
+	expected := map[string]Status{"t1": StatusSent, "t2": StatusFailed, "t3": StatusMissed}
+	for chatID, status := range expected {
+		if outcomes[chatID] != status {
+			t.Errorf("Expected %s to be %s, got %q", chatID, status, outcomes[chatID])
+		}
+	}
+	if _, ok := outcomes["t4"]; ok {
+		t.Error("Expected the future message to be left alone")
+	}
+}
@@ -0,0 +1,109 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTime turns a schedule time into an absolute time after now. It accepts
+// "+90m", "+1h30m" or "+2d" relative to now, "15:04" for the next time the
+// clock reads that, "2006-01-02T15:04", and RFC 3339.
+func ParseTime(spec string, now time.Time) (time.Time, error) {
+	if strings.HasPrefix(spec, "+") {
+		d, err := parseDuration(spec[1:])
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid duration %q", spec)
+		}
+		return now.Add(d), nil
+	}
+
+	if t, err := time.ParseInLocation("15:04", spec, now.Location()); err == nil {
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
+		if at, err := time.ParseInLocation(layout, spec, now.Location()); err == nil {
+			return future(spec, at, now)
+		}
+	}
+	if at, err := time.Parse(time.RFC3339, spec); err == nil {
+		return future(spec, at, now)
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q (use +30m, 15:04 or 2006-01-02T15:04)", spec)
+}
+
+func future(spec string, at, now time.Time) (time.Time, error) {
+	if !at.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the past", spec)
+	}
+	return at, nil
+}
+
+// parseDuration is time.ParseDuration with a leading number of days, e.g. "2d" or "1d12h"
+func parseDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if i := strings.Index(s, "d"); i > 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, err
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if s = s[i+1:]; s == "" {
+			return days, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return days + d, nil
+}
+
+// ParseDelay parses scheduling.default_schedule_duration, written as "HH:MM"
+// or a duration like "90m"
+func ParseDelay(s string) (time.Duration, error) {
+	if hours, minutes, ok := strings.Cut(s, ":"); ok {
+		h, errH := strconv.Atoi(hours)
+		m, errM := strconv.Atoi(minutes)
+		if errH != nil || errM != nil || h < 0 || m < 0 || m >= 60 {
+			return 0, fmt.Errorf("invalid delay %q", s)
+		}
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+	}
+
+	d, err := parseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid delay %q", s)
+	}
+	return d, nil
+}
+
+// ParseArgs splits "<time|+duration> <text>". When the first word isn't a
+// time, the whole input is the text and it's sent after defaultDelay.
+func ParseArgs(args string, now time.Time, defaultDelay time.Duration) (time.Time, string, error) {
+	args = strings.TrimSpace(args)
+	first, rest, _ := strings.Cut(args, " ")
+
+	sendAt, err := ParseTime(first, now)
+	if err != nil {
+		// A "+..." or past time was clearly meant as a time
+		if strings.HasPrefix(first, "+") || strings.HasSuffix(err.Error(), "in the past") {
+			return time.Time{}, "", err
+		}
+		sendAt, rest = now.Add(defaultDelay), args
+	}
+
+	text := strings.TrimSpace(rest)
+	if text == "" {
+		return time.Time{}, "", fmt.Errorf("message text is required")
+	}
+	return sendAt, text, nil
+}
@@ -0,0 +1,87 @@
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Markup is how half-block rows are coloured
+type Markup int
+
+const (
+	// ANSI uses 24-bit colour escape sequences
+	ANSI Markup = iota
+	// Tview uses tview's [#rrggbb:#rrggbb] colour tags
+	Tview
+)
+
+// HalfBlocks draws img as rows of "▀" characters, each cell showing two
+// pixels: the top one as the foreground and the bottom one as the background
+func HalfBlocks(img image.Image, maxCols, maxRows int, markup Markup) []string {
+	cols, rows := fitCells(img.Bounds(), maxCols, maxRows)
+	scaled := resize(img, cols, rows*2)
+
+	lines := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		var b strings.Builder
+		for col := 0; col < cols; col++ {
+			top := scaled.RGBAAt(col, row*2)
+			bottom := scaled.RGBAAt(col, row*2+1)
+
+			if markup == Tview {
+				fmt.Fprintf(&b, "[#%02x%02x%02x:#%02x%02x%02x]▀", top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+			} else {
+				fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀", top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+			}
+		}
+
+		if markup == Tview {
+			b.WriteString("[-:-]")
+		} else {
+			b.WriteString("\x1b[0m")
+		}
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// resize scales img to w x h by averaging the source pixels under each target
+// pixel, flattening transparency onto black
+func resize(img image.Image, w, h int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		y0 := bounds.Min.Y + y*srcH/h
+		y1 := max(bounds.Min.Y+(y+1)*srcH/h, y0+1)
+
+		for x := 0; x < w; x++ {
+			x0 := bounds.Min.X + x*srcW/w
+			x1 := max(bounds.Min.X+(x+1)*srcW/w, x0+1)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// RGBA returns alpha-premultiplied values, so transparent
+					// pixels already contribute black
+					pr, pg, pb, _ := img.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,133 @@
+package termimg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+)
+
+// kittyChunkSize is the largest base64 payload kitty accepts per escape
+const kittyChunkSize = 4096
+
+// encodePNG downsizes img to the pixels it will cover and encodes it as PNG
+func encodePNG(img image.Image, cols, rows int) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, resize(img, cols*cellWidth, rows*cellHeight)); err != nil {
+		return "", fmt.Errorf("failed to encode image: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// kitty draws img with the kitty graphics protocol, sending the PNG in chunks
+func kitty(img image.Image, cols, rows int) (string, error) {
+	payload, err := encodePNG(img, cols, rows)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for first := true; len(payload) > 0; first = false {
+		chunk := payload[:min(len(payload), kittyChunkSize)]
+		payload = payload[len(chunk):]
+
+		more := 0
+		if len(payload) > 0 {
+			more = 1
+		}
+
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,q=2,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// iterm draws img with the iTerm2 inline image protocol, also used by WezTerm
+func iterm(img image.Image, cols, rows int) (string, error) {
+	payload, err := encodePNG(img, cols, rows)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d;preserveAspectRatio=1:%s\a\n", cols, rows, payload), nil
+}
+
+// sixel encodes img as DEC sixel graphics using a 6x6x6 colour cube
+func sixel(img *image.RGBA) string {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bPq\"1;1;%d;%d", w, h)
+
+	// Palette registers hold percentages
+	for i := 0; i < 216; i++ {
+		r, g, bl := i/36, i/6%6, i%6
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, r*20, g*20, bl*20)
+	}
+
+	indexes := make([]int, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			indexes[y*w+x] = (int(c.R)+25)/51*36 + (int(c.G)+25)/51*6 + (int(c.B)+25)/51
+		}
+	}
+
+	// Each band covers six rows; each colour in the band is one pass over it
+	for top := 0; top < h; top += 6 {
+		used := make(map[int]bool)
+		for y := top; y < min(top+6, h); y++ {
+			for x := 0; x < w; x++ {
+				used[indexes[y*w+x]] = true
+			}
+		}
+
+		for colour := 0; colour < 216; colour++ {
+			if !used[colour] {
+				continue
+			}
+
+			fmt.Fprintf(&b, "#%d", colour)
+			row := make([]byte, w)
+			for x := 0; x < w; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && top+dy < h; dy++ {
+					if indexes[(top+dy)*w+x] == colour {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = byte(63 + bits)
+			}
+			writeSixelRun(&b, row)
+			b.WriteString("$")
+		}
+		b.WriteString("-")
+	}
+
+	b.WriteString("\x1b\\\n")
+	return b.String()
+}
+
+// writeSixelRun writes sixel characters, run-length encoding repeats
+func writeSixelRun(b *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+
+		if n := j - i; n > 3 {
+			fmt.Fprintf(b, "!%d%c", n, row[i])
+		} else {
+			b.Write(row[i:j])
+		}
+		i = j
+	}
+}
@@ -0,0 +1,156 @@
+// Package termimg draws images inline in a terminal, using the kitty graphics
+// protocol, iTerm2 inline images or sixel where the terminal supports them and
+// Unicode half blocks everywhere else.
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"strings"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// Protocol is a way of drawing images in a terminal
+type Protocol string
+
+const (
+	ProtocolAuto   Protocol = "auto"
+	ProtocolKitty  Protocol = "kitty"
+	ProtocolITerm  Protocol = "iterm"
+	ProtocolSixel  Protocol = "sixel"
+	ProtocolBlocks Protocol = "blocks"
+	ProtocolNone   Protocol = "none"
+)
+
+// Assumed cell size in pixels, used when a protocol needs pixel dimensions
+const (
+	cellWidth  = 10
+	cellHeight = 20
+)
+
+// Options controls how large an image is drawn, in terminal cells
+type Options struct {
+	Protocol  Protocol
+	MaxWidth  int
+	MaxHeight int
+}
+
+// ParseProtocol validates a protocol name from the config
+func ParseProtocol(name string) (Protocol, error) {
+	switch p := Protocol(strings.ToLower(strings.TrimSpace(name))); p {
+	case "":
+		return ProtocolAuto, nil
+	case ProtocolAuto, ProtocolKitty, ProtocolITerm, ProtocolSixel, ProtocolBlocks, ProtocolNone:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown image protocol %q (use auto, kitty, iterm, sixel, blocks or none)", name)
+}
+
+// DetectProtocol guesses the best protocol from the environment the terminal sets
+func DetectProtocol() Protocol {
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty":
+		return ProtocolKitty
+	case program == "iTerm.app" || program == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ProtocolITerm
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") || term == "mlterm" || os.Getenv("WT_SESSION") != "":
+		return ProtocolSixel
+	}
+	return ProtocolBlocks
+}
+
+// resolve replaces ProtocolAuto with the detected protocol
+func (o Options) resolve() Options {
+	if o.Protocol == "" || o.Protocol == ProtocolAuto {
+		o.Protocol = DetectProtocol()
+	}
+	if o.MaxWidth <= 0 {
+		o.MaxWidth = 40
+	}
+	if o.MaxHeight <= 0 {
+		o.MaxHeight = 20
+	}
+	return o
+}
+
+// Load decodes a JPEG, PNG or GIF file
+func Load(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %v", path, err)
+	}
+	return img, nil
+}
+
+// Render returns the escape sequences that draw img at the cursor, followed by
+// a newline. It returns an empty string for ProtocolNone.
+func Render(img image.Image, opts Options) (string, error) {
+	opts = opts.resolve()
+	cols, rows := fitCells(img.Bounds(), opts.MaxWidth, opts.MaxHeight)
+
+	switch opts.Protocol {
+	case ProtocolNone:
+		return "", nil
+	case ProtocolKitty:
+		return kitty(img, cols, rows)
+	case ProtocolITerm:
+		return iterm(img, cols, rows)
+	case ProtocolSixel:
+		return sixel(resize(img, cols*cellWidth, rows*cellHeight)), nil
+	}
+
+	return strings.Join(HalfBlocks(img, opts.MaxWidth, opts.MaxHeight, ANSI), "\n") + "\n", nil
+}
+
+// RenderFile loads and renders an image file
+func RenderFile(path string, opts Options) (string, error) {
+	img, err := Load(path)
+	if err != nil {
+		return "", err
+	}
+	return Render(img, opts)
+}
+
+// fitCells scales an image's size to fit within maxCols x maxRows cells,
+// keeping its aspect ratio given cells twice as tall as they are wide
+func fitCells(bounds image.Rectangle, maxCols, maxRows int) (int, int) {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= 0 || h <= 0 {
+		return 1, 1
+	}
+
+	cols := maxCols
+	rows := (cols*h + w) / (2 * w)
+	if rows > maxRows {
+		rows = maxRows
+		cols = (2*rows*w + h/2) / h
+	}
+	return max(cols, 1), max(rows, 1)
+}
+
+// Thumbnail scales img down so neither side exceeds maxSize pixels, keeping
+// its aspect ratio. Smaller images are returned at their own size.
+func Thumbnail(img image.Image, maxSize int) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w > maxSize || h > maxSize {
+		if w >= h {
+			w, h = maxSize, max(h*maxSize/w, 1)
+		} else {
+			w, h = max(w*maxSize/h, 1), maxSize
+		}
+	}
+	return resize(img, max(w, 1), max(h, 1))
+}
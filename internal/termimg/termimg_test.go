@@ -0,0 +1,144 @@
+package termimg
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// testImage is w x h pixels, red on top and blue on the bottom
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if y >= h/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFitCells(t *testing.T) {
+	testCases := []struct {
+		w, h, maxCols, maxRows int
+		cols, rows             int
+	}{
+		{100, 100, 40, 20, 40, 20},
+		{200, 100, 40, 20, 40, 10},
+		{100, 400, 40, 20, 10, 20},
+		{1, 1000, 40, 20, 1, 20},
+	}
+
+	for _, tc := range testCases {
+		cols, rows := fitCells(image.Rect(0, 0, tc.w, tc.h), tc.maxCols, tc.maxRows)
+		if cols != tc.cols || rows != tc.rows {
+			t.Errorf("fitCells(%dx%d, %d, %d) = %dx%d, expected %dx%d", tc.w, tc.h, tc.maxCols, tc.maxRows, cols, rows, tc.cols, tc.rows)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	testCases := []struct {
+		w, h, maxSize int
+		tw, th        int
+	}{
+		{640, 480, 320, 320, 240},
+		{480, 640, 320, 240, 320},
+		{100, 50, 320, 100, 50},
+		{1000, 1, 320, 320, 1},
+	}
+
+	for _, tc := range testCases {
+		bounds := Thumbnail(testImage(tc.w, tc.h), tc.maxSize).Bounds()
+		if bounds.Dx() != tc.tw || bounds.Dy() != tc.th {
+			t.Errorf("Thumbnail(%dx%d, %d) = %dx%d, expected %dx%d", tc.w, tc.h, tc.maxSize, bounds.Dx(), bounds.Dy(), tc.tw, tc.th)
+		}
+	}
+}
+
+func TestHalfBlocks(t *testing.T) {
+	lines := HalfBlocks(testImage(20, 20), 10, 10, Tview)
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 rows for a square image 10 cells wide, got %d", len(lines))
+	}
+
+	if strings.Count(lines[0], "▀") != 10 {
+		t.Errorf("Expected 10 cells per row, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[0], "[#ff0000:#ff0000]") {
+		t.Errorf("Expected the top row to be red, got %q", lines[0][:20])
+	}
+	if !strings.HasPrefix(lines[4], "[#0000ff:#0000ff]") || !strings.HasSuffix(lines[4], "[-:-]") {
+		t.Errorf("Expected the bottom row to be blue and reset its colours, got %q", lines[4])
+	}
+
+	ansi := HalfBlocks(testImage(20, 20), 10, 10, ANSI)
+	if !strings.HasPrefix(ansi[0], "\x1b[38;2;255;0;0m") || !strings.HasSuffix(ansi[0], "\x1b[0m") {
+		t.Errorf("Unexpected ANSI row %q", ansi[0])
+	}
+}
+
+func TestRenderProtocols(t *testing.T) {
+	img := testImage(300, 300)
+
+	kitty, err := Render(img, Options{Protocol: ProtocolKitty, MaxWidth: 20, MaxHeight: 10})
+	if err != nil {
+		t.Fatalf("kitty render failed: %v", err)
+	}
+	if !strings.HasPrefix(kitty, "\x1b_Ga=T,f=100,q=2,c=20,r=10,") || !strings.Contains(kitty, "m=0;") {
+		t.Errorf("Unexpected kitty output prefix %q", kitty[:40])
+	}
+
+	iterm, err := Render(img, Options{Protocol: ProtocolITerm, MaxWidth: 20, MaxHeight: 10})
+	if err != nil || !strings.HasPrefix(iterm, "\x1b]1337;File=inline=1;width=20;height=10;") {
+		t.Errorf("Unexpected iTerm output (%v)", err)
+	}
+
+	six, err := Render(img, Options{Protocol: ProtocolSixel, MaxWidth: 2, MaxHeight: 1})
+	if err != nil || !strings.HasPrefix(six, "\x1bPq\"1;1;20;20") || !strings.HasSuffix(six, "\x1b\\\n") {
+		t.Errorf("Unexpected sixel output %q (%v)", six, err)
+	}
+
+	none, err := Render(img, Options{Protocol: ProtocolNone})
+	if err != nil || none != "" {
+		t.Errorf("Expected no output for ProtocolNone, got %q", none)
+	}
+}
+
+func TestParseProtocol(t *testing.T) {
+	if p, err := ParseProtocol(" Kitty "); err != nil || p != ProtocolKitty {
+		t.Errorf("Expected kitty, got %q (%v)", p, err)
+	}
+	if p, err := ParseProtocol(""); err != nil || p != ProtocolAuto {
+		t.Errorf("Expected auto for an empty value, got %q (%v)", p, err)
+	}
+	if _, err := ParseProtocol("braille"); err == nil {
+		t.Error("Expected an error for an unknown protocol")
+	}
+}
+
+func TestDetectProtocol(t *testing.T) {
+	for _, key := range []string{"KITTY_WINDOW_ID", "TERM_PROGRAM", "LC_TERMINAL", "WT_SESSION"} {
+		t.Setenv(key, "")
+	}
+
+	t.Setenv("TERM", "xterm-kitty")
+	if p := DetectProtocol(); p != ProtocolKitty {
+		t.Errorf("Expected kitty, got %s", p)
+	}
+
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+	if p := DetectProtocol(); p != ProtocolITerm {
+		t.Errorf("Expected iterm, got %s", p)
+	}
+
+	t.Setenv("TERM_PROGRAM", "")
+	if p := DetectProtocol(); p != ProtocolBlocks {
+		t.Errorf("Expected blocks, got %s", p)
+	}
+}
@@ -23,6 +23,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MessageRequestAction int32
+
+const (
+	MessageRequestAction_ACCEPT  MessageRequestAction = 0
+	MessageRequestAction_DECLINE MessageRequestAction = 1
+	MessageRequestAction_BLOCK   MessageRequestAction = 2 // Blocks the sender, then declines
+)
+
+// Enum value maps for MessageRequestAction.
+var (
+	MessageRequestAction_name = map[int32]string{
+		0: "ACCEPT",
+		1: "DECLINE",
+		2: "BLOCK",
+	}
+	MessageRequestAction_value = map[string]int32{
+		"ACCEPT":  0,
+		"DECLINE": 1,
+		"BLOCK":   2,
+	}
+)
+
+func (x MessageRequestAction) Enum() *MessageRequestAction {
+	p := new(MessageRequestAction)
+	*p = x
+	return p
+}
+
+func (x MessageRequestAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageRequestAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_instagram_proto_enumTypes[0].Descriptor()
+}
+
+func (MessageRequestAction) Type() protoreflect.EnumType {
+	return &file_proto_instagram_proto_enumTypes[0]
+}
+
+func (x MessageRequestAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageRequestAction.Descriptor instead.
+func (MessageRequestAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{0}
+}
+
 type MessageUpdateType int32
 
 const (
@@ -56,11 +105,11 @@ func (x MessageUpdateType) String() string {
 }
 
 func (MessageUpdateType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_instagram_proto_enumTypes[0].Descriptor()
+	return file_proto_instagram_proto_enumTypes[1].Descriptor()
 }
 
 func (MessageUpdateType) Type() protoreflect.EnumType {
-	return &file_proto_instagram_proto_enumTypes[0]
+	return &file_proto_instagram_proto_enumTypes[1]
 }
 
 func (x MessageUpdateType) Number() protoreflect.EnumNumber {
@@ -69,7 +118,7 @@ func (x MessageUpdateType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MessageUpdateType.Descriptor instead.
 func (MessageUpdateType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{0}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{1}
 }
 
 type MessageType int32
@@ -105,11 +154,11 @@ func (x MessageType) String() string {
 }
 
 func (MessageType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_instagram_proto_enumTypes[1].Descriptor()
+	return file_proto_instagram_proto_enumTypes[2].Descriptor()
 }
 
 func (MessageType) Type() protoreflect.EnumType {
-	return &file_proto_instagram_proto_enumTypes[1]
+	return &file_proto_instagram_proto_enumTypes[2]
 }
 
 func (x MessageType) Number() protoreflect.EnumNumber {
@@ -118,7 +167,7 @@ func (x MessageType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MessageType.Descriptor instead.
 func (MessageType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{1}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{2}
 }
 
 // Authentication messages
@@ -344,6 +393,7 @@ type AuthStatusResponse struct {
 	Username             string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	UnreadCount          int32                  `protobuf:"varint,3,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
 	NotificationsRunning bool                   `protobuf:"varint,4,opt,name=notifications_running,json=notificationsRunning,proto3" json:"notifications_running,omitempty"`
+	InvisibleMode        bool                   `protobuf:"varint,5,opt,name=invisible_mode,json=invisibleMode,proto3" json:"invisible_mode,omitempty"`
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -406,6 +456,13 @@ func (x *AuthStatusResponse) GetNotificationsRunning() bool {
 	return false
 }
 
+func (x *AuthStatusResponse) GetInvisibleMode() bool {
+	if x != nil {
+		return x.InvisibleMode
+	}
+	return false
+}
+
 // Chat messages
 type GetChatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -743,27 +800,29 @@ func (x *SendMessageResponse) GetError() string {
 	return ""
 }
 
-type StartInteractiveChatRequest struct {
+type SendMediaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`         // the file contents, with
+	Filename      string                 `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"` // a name used to detect the media type
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartInteractiveChatRequest) Reset() {
-	*x = StartInteractiveChatRequest{}
+func (x *SendMediaRequest) Reset() {
+	*x = SendMediaRequest{}
 	mi := &file_proto_instagram_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartInteractiveChatRequest) String() string {
+func (x *SendMediaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartInteractiveChatRequest) ProtoMessage() {}
+func (*SendMediaRequest) ProtoMessage() {}
 
-func (x *StartInteractiveChatRequest) ProtoReflect() protoreflect.Message {
+func (x *SendMediaRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -775,40 +834,54 @@ func (x *StartInteractiveChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartInteractiveChatRequest.ProtoReflect.Descriptor instead.
-func (*StartInteractiveChatRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendMediaRequest.ProtoReflect.Descriptor instead.
+func (*SendMediaRequest) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *StartInteractiveChatRequest) GetChatId() string {
+func (x *SendMediaRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-type StartInteractiveChatResponse struct {
+func (x *SendMediaRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SendMediaRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type SendMediaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartInteractiveChatResponse) Reset() {
-	*x = StartInteractiveChatResponse{}
+func (x *SendMediaResponse) Reset() {
+	*x = SendMediaResponse{}
 	mi := &file_proto_instagram_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartInteractiveChatResponse) String() string {
+func (x *SendMediaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartInteractiveChatResponse) ProtoMessage() {}
+func (*SendMediaResponse) ProtoMessage() {}
 
-func (x *StartInteractiveChatResponse) ProtoReflect() protoreflect.Message {
+func (x *SendMediaResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -820,47 +893,49 @@ func (x *StartInteractiveChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartInteractiveChatResponse.ProtoReflect.Descriptor instead.
-func (*StartInteractiveChatResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendMediaResponse.ProtoReflect.Descriptor instead.
+func (*SendMediaResponse) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *StartInteractiveChatResponse) GetSuccess() bool {
+func (x *SendMediaResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *StartInteractiveChatResponse) GetMessage() string {
+func (x *SendMediaResponse) GetError() string {
 	if x != nil {
-		return x.Message
+		return x.Error
 	}
 	return ""
 }
 
-// Streaming messages
-type StreamMessagesRequest struct {
+type ReactToMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`    // Defaults to ❤️
+	Remove        bool                   `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"` // Remove your reaction instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamMessagesRequest) Reset() {
-	*x = StreamMessagesRequest{}
+func (x *ReactToMessageRequest) Reset() {
+	*x = ReactToMessageRequest{}
 	mi := &file_proto_instagram_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamMessagesRequest) String() string {
+func (x *ReactToMessageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamMessagesRequest) ProtoMessage() {}
+func (*ReactToMessageRequest) ProtoMessage() {}
 
-func (x *StreamMessagesRequest) ProtoReflect() protoreflect.Message {
+func (x *ReactToMessageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -872,41 +947,61 @@ func (x *StreamMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamMessagesRequest.ProtoReflect.Descriptor instead.
-func (*StreamMessagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReactToMessageRequest.ProtoReflect.Descriptor instead.
+func (*ReactToMessageRequest) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *StreamMessagesRequest) GetChatId() string {
+func (x *ReactToMessageRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-type MessageUpdate struct {
+func (x *ReactToMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactToMessageRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactToMessageRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ReactToMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Message       *Message               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Type          MessageUpdateType      `protobuf:"varint,3,opt,name=type,proto3,enum=instagram.MessageUpdateType" json:"type,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageUpdate) Reset() {
-	*x = MessageUpdate{}
+func (x *ReactToMessageResponse) Reset() {
+	*x = ReactToMessageResponse{}
 	mi := &file_proto_instagram_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageUpdate) String() string {
+func (x *ReactToMessageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageUpdate) ProtoMessage() {}
+func (*ReactToMessageResponse) ProtoMessage() {}
 
-func (x *MessageUpdate) ProtoReflect() protoreflect.Message {
+func (x *ReactToMessageResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -918,58 +1013,49 @@ func (x *MessageUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageUpdate.ProtoReflect.Descriptor instead.
-func (*MessageUpdate) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReactToMessageResponse.ProtoReflect.Descriptor instead.
+func (*ReactToMessageResponse) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *MessageUpdate) GetChatId() string {
-	if x != nil {
-		return x.ChatId
-	}
-	return ""
-}
-
-func (x *MessageUpdate) GetMessage() *Message {
+func (x *ReactToMessageResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Message
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *MessageUpdate) GetType() MessageUpdateType {
+func (x *ReactToMessageResponse) GetError() string {
 	if x != nil {
-		return x.Type
+		return x.Error
 	}
-	return MessageUpdateType_MESSAGE_ADDED
+	return ""
 }
 
-type NotificationUpdate struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ChatId         string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	ChatTitle      string                 `protobuf:"bytes,2,opt,name=chat_title,json=chatTitle,proto3" json:"chat_title,omitempty"`
-	Sender         string                 `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
-	MessagePreview string                 `protobuf:"bytes,4,opt,name=message_preview,json=messagePreview,proto3" json:"message_preview,omitempty"`
-	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	UnreadCount    int32                  `protobuf:"varint,6,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ScheduleMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	SendAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"` // When to send, or
+	When          string                 `protobuf:"bytes,4,opt,name=when,proto3" json:"when,omitempty"`                   // "+30m", "18:00" or "2006-01-02T15:04"; defaults to scheduling.default_schedule_duration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotificationUpdate) Reset() {
-	*x = NotificationUpdate{}
+func (x *ScheduleMessageRequest) Reset() {
+	*x = ScheduleMessageRequest{}
 	mi := &file_proto_instagram_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotificationUpdate) String() string {
+func (x *ScheduleMessageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotificationUpdate) ProtoMessage() {}
+func (*ScheduleMessageRequest) ProtoMessage() {}
 
-func (x *NotificationUpdate) ProtoReflect() protoreflect.Message {
+func (x *ScheduleMessageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -981,75 +1067,62 @@ func (x *NotificationUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotificationUpdate.ProtoReflect.Descriptor instead.
-func (*NotificationUpdate) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScheduleMessageRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageRequest) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *NotificationUpdate) GetChatId() string {
+func (x *ScheduleMessageRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-func (x *NotificationUpdate) GetChatTitle() string {
-	if x != nil {
-		return x.ChatTitle
-	}
-	return ""
-}
-
-func (x *NotificationUpdate) GetSender() string {
-	if x != nil {
-		return x.Sender
-	}
-	return ""
-}
-
-func (x *NotificationUpdate) GetMessagePreview() string {
+func (x *ScheduleMessageRequest) GetText() string {
 	if x != nil {
-		return x.MessagePreview
+		return x.Text
 	}
 	return ""
 }
 
-func (x *NotificationUpdate) GetTimestamp() *timestamppb.Timestamp {
+func (x *ScheduleMessageRequest) GetSendAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Timestamp
+		return x.SendAt
 	}
 	return nil
 }
 
-func (x *NotificationUpdate) GetUnreadCount() int32 {
+func (x *ScheduleMessageRequest) GetWhen() string {
 	if x != nil {
-		return x.UnreadCount
+		return x.When
 	}
-	return 0
+	return ""
 }
 
-// Configuration messages
-type GetConfigRequest struct {
+type ScheduleMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Scheduled     *ScheduledMessage      `protobuf:"bytes,3,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigRequest) Reset() {
-	*x = GetConfigRequest{}
+func (x *ScheduleMessageResponse) Reset() {
+	*x = ScheduleMessageResponse{}
 	mi := &file_proto_instagram_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigRequest) String() string {
+func (x *ScheduleMessageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigRequest) ProtoMessage() {}
+func (*ScheduleMessageResponse) ProtoMessage() {}
 
-func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *ScheduleMessageResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1061,41 +1134,53 @@ func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScheduleMessageResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageResponse) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetConfigRequest) GetKey() string {
+func (x *ScheduleMessageResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Key
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScheduleMessageResponse) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-type GetConfigResponse struct {
+func (x *ScheduleMessageResponse) GetScheduled() *ScheduledMessage {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type ListScheduledRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Found         bool                   `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	IncludeDone   bool                   `protobuf:"varint,1,opt,name=include_done,json=includeDone,proto3" json:"include_done,omitempty"` // Include sent and cancelled messages
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigResponse) Reset() {
-	*x = GetConfigResponse{}
+func (x *ListScheduledRequest) Reset() {
+	*x = ListScheduledRequest{}
 	mi := &file_proto_instagram_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigResponse) String() string {
+func (x *ListScheduledRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigResponse) ProtoMessage() {}
+func (*ListScheduledRequest) ProtoMessage() {}
 
-func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *ListScheduledRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1107,54 +1192,39 @@ func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListScheduledRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledRequest) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetConfigResponse) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
-}
-
-func (x *GetConfigResponse) GetValue() string {
-	if x != nil {
-		return x.Value
-	}
-	return ""
-}
-
-func (x *GetConfigResponse) GetFound() bool {
+func (x *ListScheduledRequest) GetIncludeDone() bool {
 	if x != nil {
-		return x.Found
+		return x.IncludeDone
 	}
 	return false
 }
 
-type SetConfigRequest struct {
+type ListScheduledResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Messages      []*ScheduledMessage    `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetConfigRequest) Reset() {
-	*x = SetConfigRequest{}
+func (x *ListScheduledResponse) Reset() {
+	*x = ListScheduledResponse{}
 	mi := &file_proto_instagram_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetConfigRequest) String() string {
+func (x *ListScheduledResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetConfigRequest) ProtoMessage() {}
+func (*ListScheduledResponse) ProtoMessage() {}
 
-func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *ListScheduledResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_instagram_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1166,36 +1236,1785 @@ func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetConfigRequest.ProtoReflect.Descriptor instead.
-func (*SetConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListScheduledResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledResponse) Descriptor() ([]byte, []int) {
 	return file_proto_instagram_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SetConfigRequest) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
-}
-
-func (x *SetConfigRequest) GetValue() string {
+func (x *ListScheduledResponse) GetMessages() []*ScheduledMessage {
 	if x != nil {
-		return x.Value
+		return x.Messages
 	}
-	return ""
+	return nil
 }
 
-type SetConfigResponse struct {
+type CancelScheduledRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetConfigResponse) Reset() {
+func (x *CancelScheduledRequest) Reset() {
+	*x = CancelScheduledRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledRequest) ProtoMessage() {}
+
+func (x *CancelScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelScheduledRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelScheduledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledResponse) Reset() {
+	*x = CancelScheduledResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledResponse) ProtoMessage() {}
+
+func (x *CancelScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelScheduledResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelScheduledResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ScheduledMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	ChatTitle     string                 `protobuf:"bytes,3,opt,name=chat_title,json=chatTitle,proto3" json:"chat_title,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	SendAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, sending, sent, failed, missed or cancelled
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledMessage) Reset() {
+	*x = ScheduledMessage{}
+	mi := &file_proto_instagram_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessage) ProtoMessage() {}
+
+func (x *ScheduledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledMessage) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ScheduledMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetChatTitle() string {
+	if x != nil {
+		return x.ChatTitle
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SendAt
+	}
+	return nil
+}
+
+func (x *ScheduledMessage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StartConversationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // Required unless you have talked before
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartConversationRequest) Reset() {
+	*x = StartConversationRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartConversationRequest) ProtoMessage() {}
+
+func (x *StartConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartConversationRequest.ProtoReflect.Descriptor instead.
+func (*StartConversationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StartConversationRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StartConversationRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StartConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Also set when the chat exists but the message was queued
+	Chat          *Chat                  `protobuf:"bytes,3,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartConversationResponse) Reset() {
+	*x = StartConversationResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartConversationResponse) ProtoMessage() {}
+
+func (x *StartConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartConversationResponse.ProtoReflect.Descriptor instead.
+func (*StartConversationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StartConversationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartConversationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StartConversationResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type BroadcastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatIds       []string               `protobuf:"bytes,1,rep,name=chat_ids,json=chatIds,proto3" json:"chat_ids,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"` // Sends to every chat with this label instead of chat_ids
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastRequest) Reset() {
+	*x = BroadcastRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRequest) ProtoMessage() {}
+
+func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BroadcastRequest) GetChatIds() []string {
+	if x != nil {
+		return x.ChatIds
+	}
+	return nil
+}
+
+func (x *BroadcastRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// One per chat, in the order the chats are sent to
+type BroadcastProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	ChatTitle     string                 `protobuf:"bytes,2,opt,name=chat_title,json=chatTitle,proto3" json:"chat_title,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Done          int32                  `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`
+	Total         int32                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastProgress) Reset() {
+	*x = BroadcastProgress{}
+	mi := &file_proto_instagram_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastProgress) ProtoMessage() {}
+
+func (x *BroadcastProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastProgress.ProtoReflect.Descriptor instead.
+func (*BroadcastProgress) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BroadcastProgress) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *BroadcastProgress) GetChatTitle() string {
+	if x != nil {
+		return x.ChatTitle
+	}
+	return ""
+}
+
+func (x *BroadcastProgress) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BroadcastProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BroadcastProgress) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Searches the history stored locally, which grows as chats are read
+type SearchMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // Every word has to match the start of a word in the message
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Sender        string                 `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"` // Part of the sender's name or username
+	Since         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
+	HasMedia      bool                   `protobuf:"varint,6,opt,name=has_media,json=hasMedia,proto3" json:"has_media,omitempty"`
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetHasMedia() bool {
+	if x != nil {
+		return x.HasMedia
+	}
+	return false
+}
+
+func (x *SearchMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	ChatTitle     string                 `protobuf:"bytes,2,opt,name=chat_title,json=chatTitle,proto3" json:"chat_title,omitempty"`
+	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Sender        string                 `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Kind          string                 `protobuf:"bytes,6,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text          string                 `protobuf:"bytes,7,opt,name=text,proto3" json:"text,omitempty"`
+	Snippet       string                 `protobuf:"bytes,8,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_instagram_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SearchResult) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetChatTitle() string {
+	if x != nil {
+		return x.ChatTitle
+	}
+	return ""
+}
+
+func (x *SearchResult) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SearchResult) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SearchResult) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"` // Optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateGroupRequest) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *CreateGroupRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type CreateGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Chat          *Chat                  `protobuf:"bytes,3,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupResponse) Reset() {
+	*x = CreateGroupResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupResponse) ProtoMessage() {}
+
+func (x *CreateGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateGroupResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateGroupResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type RenameGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameGroupRequest) Reset() {
+	*x = RenameGroupRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameGroupRequest) ProtoMessage() {}
+
+func (x *RenameGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameGroupRequest.ProtoReflect.Descriptor instead.
+func (*RenameGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RenameGroupRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RenameGroupRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type AddGroupMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Usernames     []string               `protobuf:"bytes,2,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupMembersRequest) Reset() {
+	*x = AddGroupMembersRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupMembersRequest) ProtoMessage() {}
+
+func (x *AddGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*AddGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AddGroupMembersRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *AddGroupMembersRequest) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+type RemoveGroupMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupMemberRequest) Reset() {
+	*x = RemoveGroupMemberRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupMemberRequest) ProtoMessage() {}
+
+func (x *RemoveGroupMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveGroupMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveGroupMemberRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RemoveGroupMemberRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type LeaveGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveGroupRequest) Reset() {
+	*x = LeaveGroupRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGroupRequest) ProtoMessage() {}
+
+func (x *LeaveGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGroupRequest.ProtoReflect.Descriptor instead.
+func (*LeaveGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LeaveGroupRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type MuteChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Muted         bool                   `protobuf:"varint,2,opt,name=muted,proto3" json:"muted,omitempty"` // false unmutes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteChatRequest) Reset() {
+	*x = MuteChatRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteChatRequest) ProtoMessage() {}
+
+func (x *MuteChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteChatRequest.ProtoReflect.Descriptor instead.
+func (*MuteChatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MuteChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MuteChatRequest) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+type GroupActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupActionResponse) Reset() {
+	*x = GroupActionResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupActionResponse) ProtoMessage() {}
+
+func (x *GroupActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupActionResponse.ProtoReflect.Descriptor instead.
+func (*GroupActionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GroupActionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GroupActionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListMessageRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*Chat                `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessageRequestsResponse) Reset() {
+	*x = ListMessageRequestsResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessageRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessageRequestsResponse) ProtoMessage() {}
+
+func (x *ListMessageRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessageRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListMessageRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListMessageRequestsResponse) GetRequests() []*Chat {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type PreviewMessageRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewMessageRequestRequest) Reset() {
+	*x = PreviewMessageRequestRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewMessageRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewMessageRequestRequest) ProtoMessage() {}
+
+func (x *PreviewMessageRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewMessageRequestRequest.ProtoReflect.Descriptor instead.
+func (*PreviewMessageRequestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PreviewMessageRequestRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *PreviewMessageRequestRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RespondToMessageRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Action        MessageRequestAction   `protobuf:"varint,2,opt,name=action,proto3,enum=instagram.MessageRequestAction" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToMessageRequestRequest) Reset() {
+	*x = RespondToMessageRequestRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToMessageRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToMessageRequestRequest) ProtoMessage() {}
+
+func (x *RespondToMessageRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToMessageRequestRequest.ProtoReflect.Descriptor instead.
+func (*RespondToMessageRequestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RespondToMessageRequestRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RespondToMessageRequestRequest) GetAction() MessageRequestAction {
+	if x != nil {
+		return x.Action
+	}
+	return MessageRequestAction_ACCEPT
+}
+
+type RespondToMessageRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Chat          *Chat                  `protobuf:"bytes,3,opt,name=chat,proto3" json:"chat,omitempty"` // Set when accepted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToMessageRequestResponse) Reset() {
+	*x = RespondToMessageRequestResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToMessageRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToMessageRequestResponse) ProtoMessage() {}
+
+func (x *RespondToMessageRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToMessageRequestResponse.ProtoReflect.Descriptor instead.
+func (*RespondToMessageRequestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RespondToMessageRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RespondToMessageRequestResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RespondToMessageRequestResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type StartInteractiveChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartInteractiveChatRequest) Reset() {
+	*x = StartInteractiveChatRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartInteractiveChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartInteractiveChatRequest) ProtoMessage() {}
+
+func (x *StartInteractiveChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartInteractiveChatRequest.ProtoReflect.Descriptor instead.
+func (*StartInteractiveChatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StartInteractiveChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type StartInteractiveChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartInteractiveChatResponse) Reset() {
+	*x = StartInteractiveChatResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartInteractiveChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartInteractiveChatResponse) ProtoMessage() {}
+
+func (x *StartInteractiveChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartInteractiveChatResponse.ProtoReflect.Descriptor instead.
+func (*StartInteractiveChatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *StartInteractiveChatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartInteractiveChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Streaming messages
+type StreamMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMessagesRequest) Reset() {
+	*x = StreamMessagesRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMessagesRequest) ProtoMessage() {}
+
+func (x *StreamMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMessagesRequest.ProtoReflect.Descriptor instead.
+func (*StreamMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *StreamMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type MessageUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Message       *Message               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Type          MessageUpdateType      `protobuf:"varint,3,opt,name=type,proto3,enum=instagram.MessageUpdateType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageUpdate) Reset() {
+	*x = MessageUpdate{}
+	mi := &file_proto_instagram_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageUpdate) ProtoMessage() {}
+
+func (x *MessageUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageUpdate.ProtoReflect.Descriptor instead.
+func (*MessageUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MessageUpdate) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MessageUpdate) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *MessageUpdate) GetType() MessageUpdateType {
+	if x != nil {
+		return x.Type
+	}
+	return MessageUpdateType_MESSAGE_ADDED
+}
+
+type NotificationUpdate struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChatId         string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	ChatTitle      string                 `protobuf:"bytes,2,opt,name=chat_title,json=chatTitle,proto3" json:"chat_title,omitempty"`
+	Sender         string                 `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	MessagePreview string                 `protobuf:"bytes,4,opt,name=message_preview,json=messagePreview,proto3" json:"message_preview,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	UnreadCount    int32                  `protobuf:"varint,6,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NotificationUpdate) Reset() {
+	*x = NotificationUpdate{}
+	mi := &file_proto_instagram_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationUpdate) ProtoMessage() {}
+
+func (x *NotificationUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationUpdate.ProtoReflect.Descriptor instead.
+func (*NotificationUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *NotificationUpdate) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *NotificationUpdate) GetChatTitle() string {
+	if x != nil {
+		return x.ChatTitle
+	}
+	return ""
+}
+
+func (x *NotificationUpdate) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *NotificationUpdate) GetMessagePreview() string {
+	if x != nil {
+		return x.MessagePreview
+	}
+	return ""
+}
+
+func (x *NotificationUpdate) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *NotificationUpdate) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+// Configuration messages
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetConfigRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Found         bool                   `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_proto_instagram_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetConfigResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type SetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConfigRequest) Reset() {
+	*x = SetConfigRequest{}
+	mi := &file_proto_instagram_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigRequest) ProtoMessage() {}
+
+func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetConfigRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetConfigRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConfigResponse) Reset() {
 	*x = SetConfigResponse{}
-	mi := &file_proto_instagram_proto_msgTypes[19]
+	mi := &file_proto_instagram_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1207,7 +3026,7 @@ func (x *SetConfigResponse) String() string {
 func (*SetConfigResponse) ProtoMessage() {}
 
 func (x *SetConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_instagram_proto_msgTypes[19]
+	mi := &file_proto_instagram_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +3039,7 @@ func (x *SetConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetConfigResponse.ProtoReflect.Descriptor instead.
 func (*SetConfigResponse) Descriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{19}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *SetConfigResponse) GetSuccess() bool {
@@ -1246,7 +3065,7 @@ type ListConfigResponse struct {
 
 func (x *ListConfigResponse) Reset() {
 	*x = ListConfigResponse{}
-	mi := &file_proto_instagram_proto_msgTypes[20]
+	mi := &file_proto_instagram_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1258,7 +3077,7 @@ func (x *ListConfigResponse) String() string {
 func (*ListConfigResponse) ProtoMessage() {}
 
 func (x *ListConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_instagram_proto_msgTypes[20]
+	mi := &file_proto_instagram_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,7 +3090,7 @@ func (x *ListConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConfigResponse.ProtoReflect.Descriptor instead.
 func (*ListConfigResponse) Descriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{20}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *ListConfigResponse) GetConfigs() []*ConfigKeyValue {
@@ -1291,7 +3110,7 @@ type ConfigKeyValue struct {
 
 func (x *ConfigKeyValue) Reset() {
 	*x = ConfigKeyValue{}
-	mi := &file_proto_instagram_proto_msgTypes[21]
+	mi := &file_proto_instagram_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1303,7 +3122,7 @@ func (x *ConfigKeyValue) String() string {
 func (*ConfigKeyValue) ProtoMessage() {}
 
 func (x *ConfigKeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_instagram_proto_msgTypes[21]
+	mi := &file_proto_instagram_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1316,7 +3135,7 @@ func (x *ConfigKeyValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigKeyValue.ProtoReflect.Descriptor instead.
 func (*ConfigKeyValue) Descriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{21}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *ConfigKeyValue) GetKey() string {
@@ -1335,22 +3154,29 @@ func (x *ConfigKeyValue) GetValue() string {
 
 // Data models
 type Chat struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	InternalId    string                 `protobuf:"bytes,2,opt,name=internal_id,json=internalId,proto3" json:"internal_id,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Users         []*User                `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
-	LastMessage   string                 `protobuf:"bytes,5,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
-	LastActivity  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
-	UnreadCount   int32                  `protobuf:"varint,7,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
-	IsGroup       bool                   `protobuf:"varint,8,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InternalId   string                 `protobuf:"bytes,2,opt,name=internal_id,json=internalId,proto3" json:"internal_id,omitempty"`
+	Title        string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Users        []*User                `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
+	LastMessage  string                 `protobuf:"bytes,5,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	LastActivity *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	UnreadCount  int32                  `protobuf:"varint,7,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	IsGroup      bool                   `protobuf:"varint,8,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`
+	Muted        bool                   `protobuf:"varint,9,opt,name=muted,proto3" json:"muted,omitempty"`
+	Pending      bool                   `protobuf:"varint,10,opt,name=pending,proto3" json:"pending,omitempty"` // A message request that hasn't been accepted
+	// Kept on the server's computer only; muted is the setting on Instagram
+	Pinned        bool     `protobuf:"varint,11,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	MutedLocally  bool     `protobuf:"varint,12,opt,name=muted_locally,json=mutedLocally,proto3" json:"muted_locally,omitempty"`
+	Archived      bool     `protobuf:"varint,13,opt,name=archived,proto3" json:"archived,omitempty"`
+	Labels        []string `protobuf:"bytes,14,rep,name=labels,proto3" json:"labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Chat) Reset() {
 	*x = Chat{}
-	mi := &file_proto_instagram_proto_msgTypes[22]
+	mi := &file_proto_instagram_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1362,7 +3188,7 @@ func (x *Chat) String() string {
 func (*Chat) ProtoMessage() {}
 
 func (x *Chat) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_instagram_proto_msgTypes[22]
+	mi := &file_proto_instagram_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1375,7 +3201,7 @@ func (x *Chat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Chat.ProtoReflect.Descriptor instead.
 func (*Chat) Descriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{22}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *Chat) GetId() string {
@@ -1434,6 +3260,48 @@ func (x *Chat) GetIsGroup() bool {
 	return false
 }
 
+func (x *Chat) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *Chat) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *Chat) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Chat) GetMutedLocally() bool {
+	if x != nil {
+		return x.MutedLocally
+	}
+	return false
+}
+
+func (x *Chat) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Chat) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type Message struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1442,13 +3310,16 @@ type Message struct {
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Type          MessageType            `protobuf:"varint,5,opt,name=type,proto3,enum=instagram.MessageType" json:"type,omitempty"`
 	ChatId        string                 `protobuf:"bytes,6,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`             // text, photo, video, voice, reel_share, post_share, story_reply, link, like, animated, system
+	Attachment    *Attachment            `protobuf:"bytes,8,opt,name=attachment,proto3" json:"attachment,omitempty"` // Set for media, shares and links
+	Reactions     []*Reaction            `protobuf:"bytes,9,rep,name=reactions,proto3" json:"reactions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_proto_instagram_proto_msgTypes[23]
+	mi := &file_proto_instagram_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1460,7 +3331,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_instagram_proto_msgTypes[23]
+	mi := &file_proto_instagram_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1473,7 +3344,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{23}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *Message) GetId() string {
@@ -1518,6 +3389,227 @@ func (x *Message) GetChatId() string {
 	return ""
 }
 
+func (x *Message) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Message) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *Message) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type Reaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	SenderId      string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Sender        string                 `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
+	mi := &file_proto_instagram_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reaction) ProtoMessage() {}
+
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Reaction) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *Reaction) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *Reaction) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *Reaction) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // photo, video, audio or link
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	PreviewUrl    string                 `protobuf:"bytes,3,opt,name=preview_url,json=previewUrl,proto3" json:"preview_url,omitempty"`
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	MediaId       string                 `protobuf:"bytes,7,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	Code          string                 `protobuf:"bytes,8,opt,name=code,proto3" json:"code,omitempty"`
+	Owner         string                 `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	Caption       string                 `protobuf:"bytes,10,opt,name=caption,proto3" json:"caption,omitempty"`
+	Title         string                 `protobuf:"bytes,11,opt,name=title,proto3" json:"title,omitempty"`
+	LocalPath     string                 `protobuf:"bytes,12,opt,name=local_path,json=localPath,proto3" json:"local_path,omitempty"` // Set once the media has been downloaded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_proto_instagram_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_instagram_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_proto_instagram_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Attachment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Attachment) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Attachment) GetPreviewUrl() string {
+	if x != nil {
+		return x.PreviewUrl
+	}
+	return ""
+}
+
+func (x *Attachment) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Attachment) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Attachment) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *Attachment) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *Attachment) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Attachment) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Attachment) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+func (x *Attachment) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Attachment) GetLocalPath() string {
+	if x != nil {
+		return x.LocalPath
+	}
+	return ""
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1531,7 +3623,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_proto_instagram_proto_msgTypes[24]
+	mi := &file_proto_instagram_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1543,7 +3635,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_instagram_proto_msgTypes[24]
+	mi := &file_proto_instagram_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1556,7 +3648,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_proto_instagram_proto_rawDescGZIP(), []int{24}
+	return file_proto_instagram_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *User) GetId() string {
@@ -1611,13 +3703,14 @@ const file_proto_instagram_proto_rawDesc = "" +
 	"\busername\x18\x01 \x01(\tR\busername\"D\n" +
 	"\x0eLogoutResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xaa\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd1\x01\n" +
 	"\x12AuthStatusResponse\x12 \n" +
 	"\fis_logged_in\x18\x01 \x01(\bR\n" +
 	"isLoggedIn\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12!\n" +
 	"\funread_count\x18\x03 \x01(\x05R\vunreadCount\x123\n" +
-	"\x15notifications_running\x18\x04 \x01(\bR\x14notificationsRunning\"'\n" +
+	"\x15notifications_running\x18\x04 \x01(\bR\x14notificationsRunning\x12%\n" +
+	"\x0einvisible_mode\x18\x05 \x01(\bR\rinvisibleMode\"'\n" +
 	"\x0fGetChatsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\"Z\n" +
 	"\x10GetChatsResponse\x12%\n" +
@@ -1641,7 +3734,127 @@ const file_proto_instagram_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"6\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"g\n" +
+	"\x10SendMediaRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1a\n" +
+	"\bfilename\x18\x04 \x01(\tR\bfilenameJ\x04\b\x02\x10\x03R\x04path\"C\n" +
+	"\x11SendMediaResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"}\n" +
+	"\x15ReactToMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x03 \x01(\tR\x05emoji\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\bR\x06remove\"H\n" +
+	"\x16ReactToMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x8e\x01\n" +
+	"\x16ScheduleMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x123\n" +
+	"\asend_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\x12\x12\n" +
+	"\x04when\x18\x04 \x01(\tR\x04when\"\x84\x01\n" +
+	"\x17ScheduleMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x129\n" +
+	"\tscheduled\x18\x03 \x01(\v2\x1b.instagram.ScheduledMessageR\tscheduled\"9\n" +
+	"\x14ListScheduledRequest\x12!\n" +
+	"\finclude_done\x18\x01 \x01(\bR\vincludeDone\"P\n" +
+	"\x15ListScheduledResponse\x127\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1b.instagram.ScheduledMessageR\bmessages\"(\n" +
+	"\x16CancelScheduledRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x17CancelScheduledResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xd1\x01\n" +
+	"\x10ScheduledMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"chat_title\x18\x03 \x01(\tR\tchatTitle\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x123\n" +
+	"\asend_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"P\n" +
+	"\x18StartConversationRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"p\n" +
+	"\x19StartConversationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12#\n" +
+	"\x04chat\x18\x03 \x01(\v2\x0f.instagram.ChatR\x04chat\"]\n" +
+	"\x10BroadcastRequest\x12\x19\n" +
+	"\bchat_ids\x18\x01 \x03(\tR\achatIds\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xc1\x01\n" +
+	"\x11BroadcastProgress\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"chat_title\x18\x02 \x01(\tR\tchatTitle\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x12\n" +
+	"\x04done\x18\x06 \x01(\x05R\x04done\x12\x14\n" +
+	"\x05total\x18\a \x01(\x05R\x05total\"\xf5\x01\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x16\n" +
+	"\x06sender\x18\x03 \x01(\tR\x06sender\x120\n" +
+	"\x05since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\thas_media\x18\x06 \x01(\bR\bhasMedia\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"K\n" +
+	"\x16SearchMessagesResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.instagram.SearchResultR\aresults\"\xf9\x01\n" +
+	"\fSearchResult\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"chat_title\x18\x02 \x01(\tR\tchatTitle\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\x12\x16\n" +
+	"\x06sender\x18\x04 \x01(\tR\x06sender\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04kind\x18\x06 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\a \x01(\tR\x04text\x12\x18\n" +
+	"\asnippet\x18\b \x01(\tR\asnippet\"H\n" +
+	"\x12CreateGroupRequest\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"j\n" +
+	"\x13CreateGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12#\n" +
+	"\x04chat\x18\x03 \x01(\v2\x0f.instagram.ChatR\x04chat\"C\n" +
+	"\x12RenameGroupRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"O\n" +
+	"\x16AddGroupMembersRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1c\n" +
+	"\tusernames\x18\x02 \x03(\tR\tusernames\"O\n" +
+	"\x18RemoveGroupMemberRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\",\n" +
+	"\x11LeaveGroupRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"@\n" +
+	"\x0fMuteChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
+	"\x05muted\x18\x02 \x01(\bR\x05muted\"E\n" +
+	"\x13GroupActionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"J\n" +
+	"\x1bListMessageRequestsResponse\x12+\n" +
+	"\brequests\x18\x01 \x03(\v2\x0f.instagram.ChatR\brequests\"M\n" +
+	"\x1cPreviewMessageRequestRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"r\n" +
+	"\x1eRespondToMessageRequestRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x127\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x1f.instagram.MessageRequestActionR\x06action\"v\n" +
+	"\x1fRespondToMessageRequestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12#\n" +
+	"\x04chat\x18\x03 \x01(\v2\x0f.instagram.ChatR\x04chat\"6\n" +
 	"\x1bStartInteractiveChatRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\"R\n" +
 	"\x1cStartInteractiveChatResponse\x12\x18\n" +
@@ -1677,7 +3890,7 @@ const file_proto_instagram_proto_rawDesc = "" +
 	"\aconfigs\x18\x01 \x03(\v2\x19.instagram.ConfigKeyValueR\aconfigs\"8\n" +
 	"\x0eConfigKeyValue\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\"\x96\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xb7\x03\n" +
 	"\x04Chat\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vinternal_id\x18\x02 \x01(\tR\n" +
@@ -1687,21 +3900,61 @@ const file_proto_instagram_proto_rawDesc = "" +
 	"\flast_message\x18\x05 \x01(\tR\vlastMessage\x12?\n" +
 	"\rlast_activity\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x12!\n" +
 	"\funread_count\x18\a \x01(\x05R\vunreadCount\x12\x19\n" +
-	"\bis_group\x18\b \x01(\bR\aisGroup\"\xc4\x01\n" +
+	"\bis_group\x18\b \x01(\bR\aisGroup\x12\x14\n" +
+	"\x05muted\x18\t \x01(\bR\x05muted\x12\x18\n" +
+	"\apending\x18\n" +
+	" \x01(\bR\apending\x12\x16\n" +
+	"\x06pinned\x18\v \x01(\bR\x06pinned\x12#\n" +
+	"\rmuted_locally\x18\f \x01(\bR\fmutedLocally\x12\x1a\n" +
+	"\barchived\x18\r \x01(\bR\barchived\x12\x16\n" +
+	"\x06labels\x18\x0e \x03(\tR\x06labels\"\xc2\x02\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04text\x18\x02 \x01(\tR\x04text\x12\x16\n" +
 	"\x06sender\x18\x03 \x01(\tR\x06sender\x128\n" +
 	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12*\n" +
 	"\x04type\x18\x05 \x01(\x0e2\x16.instagram.MessageTypeR\x04type\x12\x17\n" +
-	"\achat_id\x18\x06 \x01(\tR\x06chatId\"\x98\x01\n" +
+	"\achat_id\x18\x06 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04kind\x18\a \x01(\tR\x04kind\x125\n" +
+	"\n" +
+	"attachment\x18\b \x01(\v2\x15.instagram.AttachmentR\n" +
+	"attachment\x121\n" +
+	"\treactions\x18\t \x03(\v2\x13.instagram.ReactionR\treactions\"\x8f\x01\n" +
+	"\bReaction\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x16\n" +
+	"\x06sender\x18\x03 \x01(\tR\x06sender\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xb6\x02\n" +
+	"\n" +
+	"Attachment\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vpreview_url\x18\x03 \x01(\tR\n" +
+	"previewUrl\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\x12\x19\n" +
+	"\bmedia_id\x18\a \x01(\tR\amediaId\x12\x12\n" +
+	"\x04code\x18\b \x01(\tR\x04code\x12\x14\n" +
+	"\x05owner\x18\t \x01(\tR\x05owner\x12\x18\n" +
+	"\acaption\x18\n" +
+	" \x01(\tR\acaption\x12\x14\n" +
+	"\x05title\x18\v \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"local_path\x18\f \x01(\tR\tlocalPath\"\x98\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
 	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12&\n" +
 	"\x0fprofile_pic_url\x18\x04 \x01(\tR\rprofilePicUrl\x12\x1f\n" +
 	"\vis_verified\x18\x05 \x01(\bR\n" +
-	"isVerified*P\n" +
+	"isVerified*:\n" +
+	"\x14MessageRequestAction\x12\n" +
+	"\n" +
+	"\x06ACCEPT\x10\x00\x12\v\n" +
+	"\aDECLINE\x10\x01\x12\t\n" +
+	"\x05BLOCK\x10\x02*P\n" +
 	"\x11MessageUpdateType\x12\x11\n" +
 	"\rMESSAGE_ADDED\x10\x00\x12\x13\n" +
 	"\x0fMESSAGE_UPDATED\x10\x01\x12\x13\n" +
@@ -1710,15 +3963,33 @@ const file_proto_instagram_proto_rawDesc = "" +
 	"\x04TEXT\x10\x00\x12\t\n" +
 	"\x05MEDIA\x10\x01\x12\n" +
 	"\n" +
-	"\x06SYSTEM\x10\x022\x94\a\n" +
+	"\x06SYSTEM\x10\x022\xc7\x12\n" +
 	"\x10InstagramService\x12:\n" +
 	"\x05Login\x12\x17.instagram.LoginRequest\x1a\x18.instagram.LoginResponse\x12=\n" +
 	"\x06Logout\x12\x18.instagram.LogoutRequest\x1a\x19.instagram.LogoutResponse\x12F\n" +
 	"\rGetAuthStatus\x12\x16.google.protobuf.Empty\x1a\x1d.instagram.AuthStatusResponse\x12C\n" +
 	"\bGetChats\x12\x1a.instagram.GetChatsRequest\x1a\x1b.instagram.GetChatsResponse\x12L\n" +
 	"\vGetMessages\x12\x1d.instagram.GetMessagesRequest\x1a\x1e.instagram.GetMessagesResponse\x12L\n" +
-	"\vSendMessage\x12\x1d.instagram.SendMessageRequest\x1a\x1e.instagram.SendMessageResponse\x12g\n" +
-	"\x14StartInteractiveChat\x12&.instagram.StartInteractiveChatRequest\x1a'.instagram.StartInteractiveChatResponse\x12N\n" +
+	"\vSendMessage\x12\x1d.instagram.SendMessageRequest\x1a\x1e.instagram.SendMessageResponse\x12F\n" +
+	"\tSendMedia\x12\x1b.instagram.SendMediaRequest\x1a\x1c.instagram.SendMediaResponse\x12U\n" +
+	"\x0eReactToMessage\x12 .instagram.ReactToMessageRequest\x1a!.instagram.ReactToMessageResponse\x12g\n" +
+	"\x14StartInteractiveChat\x12&.instagram.StartInteractiveChatRequest\x1a'.instagram.StartInteractiveChatResponse\x12^\n" +
+	"\x11StartConversation\x12#.instagram.StartConversationRequest\x1a$.instagram.StartConversationResponse\x12H\n" +
+	"\tBroadcast\x12\x1b.instagram.BroadcastRequest\x1a\x1c.instagram.BroadcastProgress0\x01\x12U\n" +
+	"\x0eSearchMessages\x12 .instagram.SearchMessagesRequest\x1a!.instagram.SearchMessagesResponse\x12X\n" +
+	"\x0fScheduleMessage\x12!.instagram.ScheduleMessageRequest\x1a\".instagram.ScheduleMessageResponse\x12R\n" +
+	"\rListScheduled\x12\x1f.instagram.ListScheduledRequest\x1a .instagram.ListScheduledResponse\x12X\n" +
+	"\x0fCancelScheduled\x12!.instagram.CancelScheduledRequest\x1a\".instagram.CancelScheduledResponse\x12L\n" +
+	"\vCreateGroup\x12\x1d.instagram.CreateGroupRequest\x1a\x1e.instagram.CreateGroupResponse\x12L\n" +
+	"\vRenameGroup\x12\x1d.instagram.RenameGroupRequest\x1a\x1e.instagram.GroupActionResponse\x12T\n" +
+	"\x0fAddGroupMembers\x12!.instagram.AddGroupMembersRequest\x1a\x1e.instagram.GroupActionResponse\x12X\n" +
+	"\x11RemoveGroupMember\x12#.instagram.RemoveGroupMemberRequest\x1a\x1e.instagram.GroupActionResponse\x12J\n" +
+	"\n" +
+	"LeaveGroup\x12\x1c.instagram.LeaveGroupRequest\x1a\x1e.instagram.GroupActionResponse\x12F\n" +
+	"\bMuteChat\x12\x1a.instagram.MuteChatRequest\x1a\x1e.instagram.GroupActionResponse\x12U\n" +
+	"\x13ListMessageRequests\x12\x16.google.protobuf.Empty\x1a&.instagram.ListMessageRequestsResponse\x12`\n" +
+	"\x15PreviewMessageRequest\x12'.instagram.PreviewMessageRequestRequest\x1a\x1e.instagram.GetMessagesResponse\x12p\n" +
+	"\x17RespondToMessageRequest\x12).instagram.RespondToMessageRequestRequest\x1a*.instagram.RespondToMessageRequestResponse\x12N\n" +
 	"\x0eStreamMessages\x12 .instagram.StreamMessagesRequest\x1a\x18.instagram.MessageUpdate0\x01\x12N\n" +
 	"\x13StreamNotifications\x12\x16.google.protobuf.Empty\x1a\x1d.instagram.NotificationUpdate0\x01\x12F\n" +
 	"\tGetConfig\x12\x1b.instagram.GetConfigRequest\x1a\x1c.instagram.GetConfigResponse\x12F\n" +
@@ -1738,79 +4009,162 @@ func file_proto_instagram_proto_rawDescGZIP() []byte {
 	return file_proto_instagram_proto_rawDescData
 }
 
-var file_proto_instagram_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_instagram_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_proto_instagram_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_instagram_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_proto_instagram_proto_goTypes = []any{
-	(MessageUpdateType)(0),               // 0: instagram.MessageUpdateType
-	(MessageType)(0),                     // 1: instagram.MessageType
-	(*LoginRequest)(nil),                 // 2: instagram.LoginRequest
-	(*LoginResponse)(nil),                // 3: instagram.LoginResponse
-	(*LogoutRequest)(nil),                // 4: instagram.LogoutRequest
-	(*LogoutResponse)(nil),               // 5: instagram.LogoutResponse
-	(*AuthStatusResponse)(nil),           // 6: instagram.AuthStatusResponse
-	(*GetChatsRequest)(nil),              // 7: instagram.GetChatsRequest
-	(*GetChatsResponse)(nil),             // 8: instagram.GetChatsResponse
-	(*GetMessagesRequest)(nil),           // 9: instagram.GetMessagesRequest
-	(*GetMessagesResponse)(nil),          // 10: instagram.GetMessagesResponse
-	(*SendMessageRequest)(nil),           // 11: instagram.SendMessageRequest
-	(*SendMessageResponse)(nil),          // 12: instagram.SendMessageResponse
-	(*StartInteractiveChatRequest)(nil),  // 13: instagram.StartInteractiveChatRequest
-	(*StartInteractiveChatResponse)(nil), // 14: instagram.StartInteractiveChatResponse
-	(*StreamMessagesRequest)(nil),        // 15: instagram.StreamMessagesRequest
-	(*MessageUpdate)(nil),                // 16: instagram.MessageUpdate
-	(*NotificationUpdate)(nil),           // 17: instagram.NotificationUpdate
-	(*GetConfigRequest)(nil),             // 18: instagram.GetConfigRequest
-	(*GetConfigResponse)(nil),            // 19: instagram.GetConfigResponse
-	(*SetConfigRequest)(nil),             // 20: instagram.SetConfigRequest
-	(*SetConfigResponse)(nil),            // 21: instagram.SetConfigResponse
-	(*ListConfigResponse)(nil),           // 22: instagram.ListConfigResponse
-	(*ConfigKeyValue)(nil),               // 23: instagram.ConfigKeyValue
-	(*Chat)(nil),                         // 24: instagram.Chat
-	(*Message)(nil),                      // 25: instagram.Message
-	(*User)(nil),                         // 26: instagram.User
-	(*timestamppb.Timestamp)(nil),        // 27: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                // 28: google.protobuf.Empty
+	(MessageRequestAction)(0),               // 0: instagram.MessageRequestAction
+	(MessageUpdateType)(0),                  // 1: instagram.MessageUpdateType
+	(MessageType)(0),                        // 2: instagram.MessageType
+	(*LoginRequest)(nil),                    // 3: instagram.LoginRequest
+	(*LoginResponse)(nil),                   // 4: instagram.LoginResponse
+	(*LogoutRequest)(nil),                   // 5: instagram.LogoutRequest
+	(*LogoutResponse)(nil),                  // 6: instagram.LogoutResponse
+	(*AuthStatusResponse)(nil),              // 7: instagram.AuthStatusResponse
+	(*GetChatsRequest)(nil),                 // 8: instagram.GetChatsRequest
+	(*GetChatsResponse)(nil),                // 9: instagram.GetChatsResponse
+	(*GetMessagesRequest)(nil),              // 10: instagram.GetMessagesRequest
+	(*GetMessagesResponse)(nil),             // 11: instagram.GetMessagesResponse
+	(*SendMessageRequest)(nil),              // 12: instagram.SendMessageRequest
+	(*SendMessageResponse)(nil),             // 13: instagram.SendMessageResponse
+	(*SendMediaRequest)(nil),                // 14: instagram.SendMediaRequest
+	(*SendMediaResponse)(nil),               // 15: instagram.SendMediaResponse
+	(*ReactToMessageRequest)(nil),           // 16: instagram.ReactToMessageRequest
+	(*ReactToMessageResponse)(nil),          // 17: instagram.ReactToMessageResponse
+	(*ScheduleMessageRequest)(nil),          // 18: instagram.ScheduleMessageRequest
+	(*ScheduleMessageResponse)(nil),         // 19: instagram.ScheduleMessageResponse
+	(*ListScheduledRequest)(nil),            // 20: instagram.ListScheduledRequest
+	(*ListScheduledResponse)(nil),           // 21: instagram.ListScheduledResponse
+	(*CancelScheduledRequest)(nil),          // 22: instagram.CancelScheduledRequest
+	(*CancelScheduledResponse)(nil),         // 23: instagram.CancelScheduledResponse
+	(*ScheduledMessage)(nil),                // 24: instagram.ScheduledMessage
+	(*StartConversationRequest)(nil),        // 25: instagram.StartConversationRequest
+	(*StartConversationResponse)(nil),       // 26: instagram.StartConversationResponse
+	(*BroadcastRequest)(nil),                // 27: instagram.BroadcastRequest
+	(*BroadcastProgress)(nil),               // 28: instagram.BroadcastProgress
+	(*SearchMessagesRequest)(nil),           // 29: instagram.SearchMessagesRequest
+	(*SearchMessagesResponse)(nil),          // 30: instagram.SearchMessagesResponse
+	(*SearchResult)(nil),                    // 31: instagram.SearchResult
+	(*CreateGroupRequest)(nil),              // 32: instagram.CreateGroupRequest
+	(*CreateGroupResponse)(nil),             // 33: instagram.CreateGroupResponse
+	(*RenameGroupRequest)(nil),              // 34: instagram.RenameGroupRequest
+	(*AddGroupMembersRequest)(nil),          // 35: instagram.AddGroupMembersRequest
+	(*RemoveGroupMemberRequest)(nil),        // 36: instagram.RemoveGroupMemberRequest
+	(*LeaveGroupRequest)(nil),               // 37: instagram.LeaveGroupRequest
+	(*MuteChatRequest)(nil),                 // 38: instagram.MuteChatRequest
+	(*GroupActionResponse)(nil),             // 39: instagram.GroupActionResponse
+	(*ListMessageRequestsResponse)(nil),     // 40: instagram.ListMessageRequestsResponse
+	(*PreviewMessageRequestRequest)(nil),    // 41: instagram.PreviewMessageRequestRequest
+	(*RespondToMessageRequestRequest)(nil),  // 42: instagram.RespondToMessageRequestRequest
+	(*RespondToMessageRequestResponse)(nil), // 43: instagram.RespondToMessageRequestResponse
+	(*StartInteractiveChatRequest)(nil),     // 44: instagram.StartInteractiveChatRequest
+	(*StartInteractiveChatResponse)(nil),    // 45: instagram.StartInteractiveChatResponse
+	(*StreamMessagesRequest)(nil),           // 46: instagram.StreamMessagesRequest
+	(*MessageUpdate)(nil),                   // 47: instagram.MessageUpdate
+	(*NotificationUpdate)(nil),              // 48: instagram.NotificationUpdate
+	(*GetConfigRequest)(nil),                // 49: instagram.GetConfigRequest
+	(*GetConfigResponse)(nil),               // 50: instagram.GetConfigResponse
+	(*SetConfigRequest)(nil),                // 51: instagram.SetConfigRequest
+	(*SetConfigResponse)(nil),               // 52: instagram.SetConfigResponse
+	(*ListConfigResponse)(nil),              // 53: instagram.ListConfigResponse
+	(*ConfigKeyValue)(nil),                  // 54: instagram.ConfigKeyValue
+	(*Chat)(nil),                            // 55: instagram.Chat
+	(*Message)(nil),                         // 56: instagram.Message
+	(*Reaction)(nil),                        // 57: instagram.Reaction
+	(*Attachment)(nil),                      // 58: instagram.Attachment
+	(*User)(nil),                            // 59: instagram.User
+	(*timestamppb.Timestamp)(nil),           // 60: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                   // 61: google.protobuf.Empty
 }
 var file_proto_instagram_proto_depIdxs = []int32{
-	24, // 0: instagram.GetChatsResponse.chats:type_name -> instagram.Chat
-	25, // 1: instagram.GetMessagesResponse.messages:type_name -> instagram.Message
-	25, // 2: instagram.MessageUpdate.message:type_name -> instagram.Message
-	0,  // 3: instagram.MessageUpdate.type:type_name -> instagram.MessageUpdateType
-	27, // 4: instagram.NotificationUpdate.timestamp:type_name -> google.protobuf.Timestamp
-	23, // 5: instagram.ListConfigResponse.configs:type_name -> instagram.ConfigKeyValue
-	26, // 6: instagram.Chat.users:type_name -> instagram.User
-	27, // 7: instagram.Chat.last_activity:type_name -> google.protobuf.Timestamp
-	27, // 8: instagram.Message.timestamp:type_name -> google.protobuf.Timestamp
-	1,  // 9: instagram.Message.type:type_name -> instagram.MessageType
-	2,  // 10: instagram.InstagramService.Login:input_type -> instagram.LoginRequest
-	4,  // 11: instagram.InstagramService.Logout:input_type -> instagram.LogoutRequest
-	28, // 12: instagram.InstagramService.GetAuthStatus:input_type -> google.protobuf.Empty
-	7,  // 13: instagram.InstagramService.GetChats:input_type -> instagram.GetChatsRequest
-	9,  // 14: instagram.InstagramService.GetMessages:input_type -> instagram.GetMessagesRequest
-	11, // 15: instagram.InstagramService.SendMessage:input_type -> instagram.SendMessageRequest
-	13, // 16: instagram.InstagramService.StartInteractiveChat:input_type -> instagram.StartInteractiveChatRequest
-	15, // 17: instagram.InstagramService.StreamMessages:input_type -> instagram.StreamMessagesRequest
-	28, // 18: instagram.InstagramService.StreamNotifications:input_type -> google.protobuf.Empty
-	18, // 19: instagram.InstagramService.GetConfig:input_type -> instagram.GetConfigRequest
-	20, // 20: instagram.InstagramService.SetConfig:input_type -> instagram.SetConfigRequest
-	28, // 21: instagram.InstagramService.ListConfig:input_type -> google.protobuf.Empty
-	3,  // 22: instagram.InstagramService.Login:output_type -> instagram.LoginResponse
-	5,  // 23: instagram.InstagramService.Logout:output_type -> instagram.LogoutResponse
-	6,  // 24: instagram.InstagramService.GetAuthStatus:output_type -> instagram.AuthStatusResponse
-	8,  // 25: instagram.InstagramService.GetChats:output_type -> instagram.GetChatsResponse
-	10, // 26: instagram.InstagramService.GetMessages:output_type -> instagram.GetMessagesResponse
-	12, // 27: instagram.InstagramService.SendMessage:output_type -> instagram.SendMessageResponse
-	14, // 28: instagram.InstagramService.StartInteractiveChat:output_type -> instagram.StartInteractiveChatResponse
-	16, // 29: instagram.InstagramService.StreamMessages:output_type -> instagram.MessageUpdate
-	17, // 30: instagram.InstagramService.StreamNotifications:output_type -> instagram.NotificationUpdate
-	19, // 31: instagram.InstagramService.GetConfig:output_type -> instagram.GetConfigResponse
-	21, // 32: instagram.InstagramService.SetConfig:output_type -> instagram.SetConfigResponse
-	22, // 33: instagram.InstagramService.ListConfig:output_type -> instagram.ListConfigResponse
-	22, // [22:34] is the sub-list for method output_type
-	10, // [10:22] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	55, // 0: instagram.GetChatsResponse.chats:type_name -> instagram.Chat
+	56, // 1: instagram.GetMessagesResponse.messages:type_name -> instagram.Message
+	60, // 2: instagram.ScheduleMessageRequest.send_at:type_name -> google.protobuf.Timestamp
+	24, // 3: instagram.ScheduleMessageResponse.scheduled:type_name -> instagram.ScheduledMessage
+	24, // 4: instagram.ListScheduledResponse.messages:type_name -> instagram.ScheduledMessage
+	60, // 5: instagram.ScheduledMessage.send_at:type_name -> google.protobuf.Timestamp
+	55, // 6: instagram.StartConversationResponse.chat:type_name -> instagram.Chat
+	60, // 7: instagram.SearchMessagesRequest.since:type_name -> google.protobuf.Timestamp
+	60, // 8: instagram.SearchMessagesRequest.until:type_name -> google.protobuf.Timestamp
+	31, // 9: instagram.SearchMessagesResponse.results:type_name -> instagram.SearchResult
+	60, // 10: instagram.SearchResult.timestamp:type_name -> google.protobuf.Timestamp
+	55, // 11: instagram.CreateGroupResponse.chat:type_name -> instagram.Chat
+	55, // 12: instagram.ListMessageRequestsResponse.requests:type_name -> instagram.Chat
+	0,  // 13: instagram.RespondToMessageRequestRequest.action:type_name -> instagram.MessageRequestAction
+	55, // 14: instagram.RespondToMessageRequestResponse.chat:type_name -> instagram.Chat
+	56, // 15: instagram.MessageUpdate.message:type_name -> instagram.Message
+	1,  // 16: instagram.MessageUpdate.type:type_name -> instagram.MessageUpdateType
+	60, // 17: instagram.NotificationUpdate.timestamp:type_name -> google.protobuf.Timestamp
+	54, // 18: instagram.ListConfigResponse.configs:type_name -> instagram.ConfigKeyValue
+	59, // 19: instagram.Chat.users:type_name -> instagram.User
+	60, // 20: instagram.Chat.last_activity:type_name -> google.protobuf.Timestamp
+	60, // 21: instagram.Message.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 22: instagram.Message.type:type_name -> instagram.MessageType
+	58, // 23: instagram.Message.attachment:type_name -> instagram.Attachment
+	57, // 24: instagram.Message.reactions:type_name -> instagram.Reaction
+	60, // 25: instagram.Reaction.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 26: instagram.InstagramService.Login:input_type -> instagram.LoginRequest
+	5,  // 27: instagram.InstagramService.Logout:input_type -> instagram.LogoutRequest
+	61, // 28: instagram.InstagramService.GetAuthStatus:input_type -> google.protobuf.Empty
+	8,  // 29: instagram.InstagramService.GetChats:input_type -> instagram.GetChatsRequest
+	10, // 30: instagram.InstagramService.GetMessages:input_type -> instagram.GetMessagesRequest
+	12, // 31: instagram.InstagramService.SendMessage:input_type -> instagram.SendMessageRequest
+	14, // 32: instagram.InstagramService.SendMedia:input_type -> instagram.SendMediaRequest
+	16, // 33: instagram.InstagramService.ReactToMessage:input_type -> instagram.ReactToMessageRequest
+	44, // 34: instagram.InstagramService.StartInteractiveChat:input_type -> instagram.StartInteractiveChatRequest
+	25, // 35: instagram.InstagramService.StartConversation:input_type -> instagram.StartConversationRequest
+	27, // 36: instagram.InstagramService.Broadcast:input_type -> instagram.BroadcastRequest
+	29, // 37: instagram.InstagramService.SearchMessages:input_type -> instagram.SearchMessagesRequest
+	18, // 38: instagram.InstagramService.ScheduleMessage:input_type -> instagram.ScheduleMessageRequest
+	20, // 39: instagram.InstagramService.ListScheduled:input_type -> instagram.ListScheduledRequest
+	22, // 40: instagram.InstagramService.CancelScheduled:input_type -> instagram.CancelScheduledRequest
+	32, // 41: instagram.InstagramService.CreateGroup:input_type -> instagram.CreateGroupRequest
+	34, // 42: instagram.InstagramService.RenameGroup:input_type -> instagram.RenameGroupRequest
+	35, // 43: instagram.InstagramService.AddGroupMembers:input_type -> instagram.AddGroupMembersRequest
+	36, // 44: instagram.InstagramService.RemoveGroupMember:input_type -> instagram.RemoveGroupMemberRequest
+	37, // 45: instagram.InstagramService.LeaveGroup:input_type -> instagram.LeaveGroupRequest
+	38, // 46: instagram.InstagramService.MuteChat:input_type -> instagram.MuteChatRequest
+	61, // 47: instagram.InstagramService.ListMessageRequests:input_type -> google.protobuf.Empty
+	41, // 48: instagram.InstagramService.PreviewMessageRequest:input_type -> instagram.PreviewMessageRequestRequest
+	42, // 49: instagram.InstagramService.RespondToMessageRequest:input_type -> instagram.RespondToMessageRequestRequest
+	46, // 50: instagram.InstagramService.StreamMessages:input_type -> instagram.StreamMessagesRequest
+	61, // 51: instagram.InstagramService.StreamNotifications:input_type -> google.protobuf.Empty
+	49, // 52: instagram.InstagramService.GetConfig:input_type -> instagram.GetConfigRequest
+	51, // 53: instagram.InstagramService.SetConfig:input_type -> instagram.SetConfigRequest
+	61, // 54: instagram.InstagramService.ListConfig:input_type -> google.protobuf.Empty
+	4,  // 55: instagram.InstagramService.Login:output_type -> instagram.LoginResponse
+	6,  // 56: instagram.InstagramService.Logout:output_type -> instagram.LogoutResponse
+	7,  // 57: instagram.InstagramService.GetAuthStatus:output_type -> instagram.AuthStatusResponse
+	9,  // 58: instagram.InstagramService.GetChats:output_type -> instagram.GetChatsResponse
+	11, // 59: instagram.InstagramService.GetMessages:output_type -> instagram.GetMessagesResponse
+	13, // 60: instagram.InstagramService.SendMessage:output_type -> instagram.SendMessageResponse
+	15, // 61: instagram.InstagramService.SendMedia:output_type -> instagram.SendMediaResponse
+	17, // 62: instagram.InstagramService.ReactToMessage:output_type -> instagram.ReactToMessageResponse
+	45, // 63: instagram.InstagramService.StartInteractiveChat:output_type -> instagram.StartInteractiveChatResponse
+	26, // 64: instagram.InstagramService.StartConversation:output_type -> instagram.StartConversationResponse
+	28, // 65: instagram.InstagramService.Broadcast:output_type -> instagram.BroadcastProgress
+	30, // 66: instagram.InstagramService.SearchMessages:output_type -> instagram.SearchMessagesResponse
+	19, // 67: instagram.InstagramService.ScheduleMessage:output_type -> instagram.ScheduleMessageResponse
+	21, // 68: instagram.InstagramService.ListScheduled:output_type -> instagram.ListScheduledResponse
+	23, // 69: instagram.InstagramService.CancelScheduled:output_type -> instagram.CancelScheduledResponse
+	33, // 70: instagram.InstagramService.CreateGroup:output_type -> instagram.CreateGroupResponse
+	39, // 71: instagram.InstagramService.RenameGroup:output_type -> instagram.GroupActionResponse
+	39, // 72: instagram.InstagramService.AddGroupMembers:output_type -> instagram.GroupActionResponse
+	39, // 73: instagram.InstagramService.RemoveGroupMember:output_type -> instagram.GroupActionResponse
+	39, // 74: instagram.InstagramService.LeaveGroup:output_type -> instagram.GroupActionResponse
+	39, // 75: instagram.InstagramService.MuteChat:output_type -> instagram.GroupActionResponse
+	40, // 76: instagram.InstagramService.ListMessageRequests:output_type -> instagram.ListMessageRequestsResponse
+	11, // 77: instagram.InstagramService.PreviewMessageRequest:output_type -> instagram.GetMessagesResponse
+	43, // 78: instagram.InstagramService.RespondToMessageRequest:output_type -> instagram.RespondToMessageRequestResponse
+	47, // 79: instagram.InstagramService.StreamMessages:output_type -> instagram.MessageUpdate
+	48, // 80: instagram.InstagramService.StreamNotifications:output_type -> instagram.NotificationUpdate
+	50, // 81: instagram.InstagramService.GetConfig:output_type -> instagram.GetConfigResponse
+	52, // 82: instagram.InstagramService.SetConfig:output_type -> instagram.SetConfigResponse
+	53, // 83: instagram.InstagramService.ListConfig:output_type -> instagram.ListConfigResponse
+	55, // [55:84] is the sub-list for method output_type
+	26, // [26:55] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_instagram_proto_init() }
@@ -1823,8 +4177,8 @@ func file_proto_instagram_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_instagram_proto_rawDesc), len(file_proto_instagram_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   25,
+			NumEnums:      3,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,18 +20,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InstagramService_Login_FullMethodName                = "/instagram.InstagramService/Login"
-	InstagramService_Logout_FullMethodName               = "/instagram.InstagramService/Logout"
-	InstagramService_GetAuthStatus_FullMethodName        = "/instagram.InstagramService/GetAuthStatus"
-	InstagramService_GetChats_FullMethodName             = "/instagram.InstagramService/GetChats"
-	InstagramService_GetMessages_FullMethodName          = "/instagram.InstagramService/GetMessages"
-	InstagramService_SendMessage_FullMethodName          = "/instagram.InstagramService/SendMessage"
-	InstagramService_StartInteractiveChat_FullMethodName = "/instagram.InstagramService/StartInteractiveChat"
-	InstagramService_StreamMessages_FullMethodName       = "/instagram.InstagramService/StreamMessages"
-	InstagramService_StreamNotifications_FullMethodName  = "/instagram.InstagramService/StreamNotifications"
-	InstagramService_GetConfig_FullMethodName            = "/instagram.InstagramService/GetConfig"
-	InstagramService_SetConfig_FullMethodName            = "/instagram.InstagramService/SetConfig"
-	InstagramService_ListConfig_FullMethodName           = "/instagram.InstagramService/ListConfig"
+	InstagramService_Login_FullMethodName                   = "/instagram.InstagramService/Login"
+	InstagramService_Logout_FullMethodName                  = "/instagram.InstagramService/Logout"
+	InstagramService_GetAuthStatus_FullMethodName           = "/instagram.InstagramService/GetAuthStatus"
+	InstagramService_GetChats_FullMethodName                = "/instagram.InstagramService/GetChats"
+	InstagramService_GetMessages_FullMethodName             = "/instagram.InstagramService/GetMessages"
+	InstagramService_SendMessage_FullMethodName             = "/instagram.InstagramService/SendMessage"
+	InstagramService_SendMedia_FullMethodName               = "/instagram.InstagramService/SendMedia"
+	InstagramService_ReactToMessage_FullMethodName          = "/instagram.InstagramService/ReactToMessage"
+	InstagramService_StartInteractiveChat_FullMethodName    = "/instagram.InstagramService/StartInteractiveChat"
+	InstagramService_StartConversation_FullMethodName       = "/instagram.InstagramService/StartConversation"
+	InstagramService_Broadcast_FullMethodName               = "/instagram.InstagramService/Broadcast"
+	InstagramService_SearchMessages_FullMethodName          = "/instagram.InstagramService/SearchMessages"
+	InstagramService_ScheduleMessage_FullMethodName         = "/instagram.InstagramService/ScheduleMessage"
+	InstagramService_ListScheduled_FullMethodName           = "/instagram.InstagramService/ListScheduled"
+	InstagramService_CancelScheduled_FullMethodName         = "/instagram.InstagramService/CancelScheduled"
+	InstagramService_CreateGroup_FullMethodName             = "/instagram.InstagramService/CreateGroup"
+	InstagramService_RenameGroup_FullMethodName             = "/instagram.InstagramService/RenameGroup"
+	InstagramService_AddGroupMembers_FullMethodName         = "/instagram.InstagramService/AddGroupMembers"
+	InstagramService_RemoveGroupMember_FullMethodName       = "/instagram.InstagramService/RemoveGroupMember"
+	InstagramService_LeaveGroup_FullMethodName              = "/instagram.InstagramService/LeaveGroup"
+	InstagramService_MuteChat_FullMethodName                = "/instagram.InstagramService/MuteChat"
+	InstagramService_ListMessageRequests_FullMethodName     = "/instagram.InstagramService/ListMessageRequests"
+	InstagramService_PreviewMessageRequest_FullMethodName   = "/instagram.InstagramService/PreviewMessageRequest"
+	InstagramService_RespondToMessageRequest_FullMethodName = "/instagram.InstagramService/RespondToMessageRequest"
+	InstagramService_StreamMessages_FullMethodName          = "/instagram.InstagramService/StreamMessages"
+	InstagramService_StreamNotifications_FullMethodName     = "/instagram.InstagramService/StreamNotifications"
+	InstagramService_GetConfig_FullMethodName               = "/instagram.InstagramService/GetConfig"
+	InstagramService_SetConfig_FullMethodName               = "/instagram.InstagramService/SetConfig"
+	InstagramService_ListConfig_FullMethodName              = "/instagram.InstagramService/ListConfig"
 )
 
 // InstagramServiceClient is the client API for InstagramService service.
@@ -48,7 +65,27 @@ type InstagramServiceClient interface {
 	GetChats(ctx context.Context, in *GetChatsRequest, opts ...grpc.CallOption) (*GetChatsResponse, error)
 	GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error)
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
+	SendMedia(ctx context.Context, in *SendMediaRequest, opts ...grpc.CallOption) (*SendMediaResponse, error)
+	ReactToMessage(ctx context.Context, in *ReactToMessageRequest, opts ...grpc.CallOption) (*ReactToMessageResponse, error)
 	StartInteractiveChat(ctx context.Context, in *StartInteractiveChatRequest, opts ...grpc.CallOption) (*StartInteractiveChatResponse, error)
+	StartConversation(ctx context.Context, in *StartConversationRequest, opts ...grpc.CallOption) (*StartConversationResponse, error)
+	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BroadcastProgress], error)
+	SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error)
+	// Scheduled messages
+	ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ScheduleMessageResponse, error)
+	ListScheduled(ctx context.Context, in *ListScheduledRequest, opts ...grpc.CallOption) (*ListScheduledResponse, error)
+	CancelScheduled(ctx context.Context, in *CancelScheduledRequest, opts ...grpc.CallOption) (*CancelScheduledResponse, error)
+	// Group chats
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	RenameGroup(ctx context.Context, in *RenameGroupRequest, opts ...grpc.CallOption) (*GroupActionResponse, error)
+	AddGroupMembers(ctx context.Context, in *AddGroupMembersRequest, opts ...grpc.CallOption) (*GroupActionResponse, error)
+	RemoveGroupMember(ctx context.Context, in *RemoveGroupMemberRequest, opts ...grpc.CallOption) (*GroupActionResponse, error)
+	LeaveGroup(ctx context.Context, in *LeaveGroupRequest, opts ...grpc.CallOption) (*GroupActionResponse, error)
+	MuteChat(ctx context.Context, in *MuteChatRequest, opts ...grpc.CallOption) (*GroupActionResponse, error)
+	// Message requests
+	ListMessageRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListMessageRequestsResponse, error)
+	PreviewMessageRequest(ctx context.Context, in *PreviewMessageRequestRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error)
+	RespondToMessageRequest(ctx context.Context, in *RespondToMessageRequestRequest, opts ...grpc.CallOption) (*RespondToMessageRequestResponse, error)
 	// Streaming methods
 	StreamMessages(ctx context.Context, in *StreamMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageUpdate], error)
 	StreamNotifications(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NotificationUpdate], error)
@@ -126,6 +163,26 @@ func (c *instagramServiceClient) SendMessage(ctx context.Context, in *SendMessag
 	return out, nil
 }
 
+func (c *instagramServiceClient) SendMedia(ctx context.Context, in *SendMediaRequest, opts ...grpc.CallOption) (*SendMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMediaResponse)
+	err := c.cc.Invoke(ctx, InstagramService_SendMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) ReactToMessage(ctx context.Context, in *ReactToMessageRequest, opts ...grpc.CallOption) (*ReactToMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactToMessageResponse)
+	err := c.cc.Invoke(ctx, InstagramService_ReactToMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *instagramServiceClient) StartInteractiveChat(ctx context.Context, in *StartInteractiveChatRequest, opts ...grpc.CallOption) (*StartInteractiveChatResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartInteractiveChatResponse)
@@ -136,9 +193,168 @@ func (c *instagramServiceClient) StartInteractiveChat(ctx context.Context, in *S
 	return out, nil
 }
 
+func (c *instagramServiceClient) StartConversation(ctx context.Context, in *StartConversationRequest, opts ...grpc.CallOption) (*StartConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartConversationResponse)
+	err := c.cc.Invoke(ctx, InstagramService_StartConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BroadcastProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InstagramService_ServiceDesc.Streams[0], InstagramService_Broadcast_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BroadcastRequest, BroadcastProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InstagramService_BroadcastClient = grpc.ServerStreamingClient[BroadcastProgress]
+
+func (c *instagramServiceClient) SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMessagesResponse)
+	err := c.cc.Invoke(ctx, InstagramService_SearchMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ScheduleMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleMessageResponse)
+	err := c.cc.Invoke(ctx, InstagramService_ScheduleMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) ListScheduled(ctx context.Context, in *ListScheduledRequest, opts ...grpc.CallOption) (*ListScheduledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledResponse)
+	err := c.cc.Invoke(ctx, InstagramService_ListScheduled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) CancelScheduled(ctx context.Context, in *CancelScheduledRequest, opts ...grpc.CallOption) (*CancelScheduledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledResponse)
+	err := c.cc.Invoke(ctx, InstagramService_CancelScheduled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, InstagramService_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) RenameGroup(ctx context.Context, in *RenameGroupRequest, opts ...grpc.CallOption) (*GroupActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupActionResponse)
+	err := c.cc.Invoke(ctx, InstagramService_RenameGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) AddGroupMembers(ctx context.Context, in *AddGroupMembersRequest, opts ...grpc.CallOption) (*GroupActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupActionResponse)
+	err := c.cc.Invoke(ctx, InstagramService_AddGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) RemoveGroupMember(ctx context.Context, in *RemoveGroupMemberRequest, opts ...grpc.CallOption) (*GroupActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupActionResponse)
+	err := c.cc.Invoke(ctx, InstagramService_RemoveGroupMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) LeaveGroup(ctx context.Context, in *LeaveGroupRequest, opts ...grpc.CallOption) (*GroupActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupActionResponse)
+	err := c.cc.Invoke(ctx, InstagramService_LeaveGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) MuteChat(ctx context.Context, in *MuteChatRequest, opts ...grpc.CallOption) (*GroupActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupActionResponse)
+	err := c.cc.Invoke(ctx, InstagramService_MuteChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) ListMessageRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListMessageRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMessageRequestsResponse)
+	err := c.cc.Invoke(ctx, InstagramService_ListMessageRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) PreviewMessageRequest(ctx context.Context, in *PreviewMessageRequestRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMessagesResponse)
+	err := c.cc.Invoke(ctx, InstagramService_PreviewMessageRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instagramServiceClient) RespondToMessageRequest(ctx context.Context, in *RespondToMessageRequestRequest, opts ...grpc.CallOption) (*RespondToMessageRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespondToMessageRequestResponse)
+	err := c.cc.Invoke(ctx, InstagramService_RespondToMessageRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *instagramServiceClient) StreamMessages(ctx context.Context, in *StreamMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageUpdate], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &InstagramService_ServiceDesc.Streams[0], InstagramService_StreamMessages_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &InstagramService_ServiceDesc.Streams[1], InstagramService_StreamMessages_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +373,7 @@ type InstagramService_StreamMessagesClient = grpc.ServerStreamingClient[MessageU
 
 func (c *instagramServiceClient) StreamNotifications(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NotificationUpdate], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &InstagramService_ServiceDesc.Streams[1], InstagramService_StreamNotifications_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &InstagramService_ServiceDesc.Streams[2], InstagramService_StreamNotifications_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +434,27 @@ type InstagramServiceServer interface {
 	GetChats(context.Context, *GetChatsRequest) (*GetChatsResponse, error)
 	GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error)
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
+	SendMedia(context.Context, *SendMediaRequest) (*SendMediaResponse, error)
+	ReactToMessage(context.Context, *ReactToMessageRequest) (*ReactToMessageResponse, error)
 	StartInteractiveChat(context.Context, *StartInteractiveChatRequest) (*StartInteractiveChatResponse, error)
+	StartConversation(context.Context, *StartConversationRequest) (*StartConversationResponse, error)
+	Broadcast(*BroadcastRequest, grpc.ServerStreamingServer[BroadcastProgress]) error
+	SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error)
+	// Scheduled messages
+	ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduleMessageResponse, error)
+	ListScheduled(context.Context, *ListScheduledRequest) (*ListScheduledResponse, error)
+	CancelScheduled(context.Context, *CancelScheduledRequest) (*CancelScheduledResponse, error)
+	// Group chats
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	RenameGroup(context.Context, *RenameGroupRequest) (*GroupActionResponse, error)
+	AddGroupMembers(context.Context, *AddGroupMembersRequest) (*GroupActionResponse, error)
+	RemoveGroupMember(context.Context, *RemoveGroupMemberRequest) (*GroupActionResponse, error)
+	LeaveGroup(context.Context, *LeaveGroupRequest) (*GroupActionResponse, error)
+	MuteChat(context.Context, *MuteChatRequest) (*GroupActionResponse, error)
+	// Message requests
+	ListMessageRequests(context.Context, *emptypb.Empty) (*ListMessageRequestsResponse, error)
+	PreviewMessageRequest(context.Context, *PreviewMessageRequestRequest) (*GetMessagesResponse, error)
+	RespondToMessageRequest(context.Context, *RespondToMessageRequestRequest) (*RespondToMessageRequestResponse, error)
 	// Streaming methods
 	StreamMessages(*StreamMessagesRequest, grpc.ServerStreamingServer[MessageUpdate]) error
 	StreamNotifications(*emptypb.Empty, grpc.ServerStreamingServer[NotificationUpdate]) error
@@ -254,9 +490,60 @@ func (UnimplementedInstagramServiceServer) GetMessages(context.Context, *GetMess
 func (UnimplementedInstagramServiceServer) SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
 }
+func (UnimplementedInstagramServiceServer) SendMedia(context.Context, *SendMediaRequest) (*SendMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMedia not implemented")
+}
+func (UnimplementedInstagramServiceServer) ReactToMessage(context.Context, *ReactToMessageRequest) (*ReactToMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactToMessage not implemented")
+}
 func (UnimplementedInstagramServiceServer) StartInteractiveChat(context.Context, *StartInteractiveChatRequest) (*StartInteractiveChatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartInteractiveChat not implemented")
 }
+func (UnimplementedInstagramServiceServer) StartConversation(context.Context, *StartConversationRequest) (*StartConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartConversation not implemented")
+}
+func (UnimplementedInstagramServiceServer) Broadcast(*BroadcastRequest, grpc.ServerStreamingServer[BroadcastProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedInstagramServiceServer) SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMessages not implemented")
+}
+func (UnimplementedInstagramServiceServer) ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduleMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleMessage not implemented")
+}
+func (UnimplementedInstagramServiceServer) ListScheduled(context.Context, *ListScheduledRequest) (*ListScheduledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduled not implemented")
+}
+func (UnimplementedInstagramServiceServer) CancelScheduled(context.Context, *CancelScheduledRequest) (*CancelScheduledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduled not implemented")
+}
+func (UnimplementedInstagramServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedInstagramServiceServer) RenameGroup(context.Context, *RenameGroupRequest) (*GroupActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameGroup not implemented")
+}
+func (UnimplementedInstagramServiceServer) AddGroupMembers(context.Context, *AddGroupMembersRequest) (*GroupActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupMembers not implemented")
+}
+func (UnimplementedInstagramServiceServer) RemoveGroupMember(context.Context, *RemoveGroupMemberRequest) (*GroupActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMember not implemented")
+}
+func (UnimplementedInstagramServiceServer) LeaveGroup(context.Context, *LeaveGroupRequest) (*GroupActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveGroup not implemented")
+}
+func (UnimplementedInstagramServiceServer) MuteChat(context.Context, *MuteChatRequest) (*GroupActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteChat not implemented")
+}
+func (UnimplementedInstagramServiceServer) ListMessageRequests(context.Context, *emptypb.Empty) (*ListMessageRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMessageRequests not implemented")
+}
+func (UnimplementedInstagramServiceServer) PreviewMessageRequest(context.Context, *PreviewMessageRequestRequest) (*GetMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewMessageRequest not implemented")
+}
+func (UnimplementedInstagramServiceServer) RespondToMessageRequest(context.Context, *RespondToMessageRequestRequest) (*RespondToMessageRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToMessageRequest not implemented")
+}
 func (UnimplementedInstagramServiceServer) StreamMessages(*StreamMessagesRequest, grpc.ServerStreamingServer[MessageUpdate]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamMessages not implemented")
 }
@@ -401,6 +688,42 @@ func _InstagramService_SendMessage_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InstagramService_SendMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).SendMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_SendMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).SendMedia(ctx, req.(*SendMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_ReactToMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactToMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).ReactToMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_ReactToMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).ReactToMessage(ctx, req.(*ReactToMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InstagramService_StartInteractiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartInteractiveChatRequest)
 	if err := dec(in); err != nil {
@@ -419,6 +742,269 @@ func _InstagramService_StartInteractiveChat_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InstagramService_StartConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).StartConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_StartConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).StartConversation(ctx, req.(*StartConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_Broadcast_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BroadcastRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InstagramServiceServer).Broadcast(m, &grpc.GenericServerStream[BroadcastRequest, BroadcastProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InstagramService_BroadcastServer = grpc.ServerStreamingServer[BroadcastProgress]
+
+func _InstagramService_SearchMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).SearchMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_SearchMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).SearchMessages(ctx, req.(*SearchMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_ScheduleMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).ScheduleMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_ScheduleMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).ScheduleMessage(ctx, req.(*ScheduleMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_ListScheduled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).ListScheduled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_ListScheduled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).ListScheduled(ctx, req.(*ListScheduledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_CancelScheduled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).CancelScheduled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_CancelScheduled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).CancelScheduled(ctx, req.(*CancelScheduledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_RenameGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).RenameGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_RenameGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).RenameGroup(ctx, req.(*RenameGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_AddGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).AddGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_AddGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).AddGroupMembers(ctx, req.(*AddGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_RemoveGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).RemoveGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_RemoveGroupMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).RemoveGroupMember(ctx, req.(*RemoveGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_LeaveGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).LeaveGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_LeaveGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).LeaveGroup(ctx, req.(*LeaveGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_MuteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).MuteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_MuteChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).MuteChat(ctx, req.(*MuteChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_ListMessageRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).ListMessageRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_ListMessageRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).ListMessageRequests(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_PreviewMessageRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewMessageRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).PreviewMessageRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_PreviewMessageRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).PreviewMessageRequest(ctx, req.(*PreviewMessageRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstagramService_RespondToMessageRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToMessageRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstagramServiceServer).RespondToMessageRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstagramService_RespondToMessageRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstagramServiceServer).RespondToMessageRequest(ctx, req.(*RespondToMessageRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InstagramService_StreamMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamMessagesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -526,10 +1112,74 @@ var InstagramService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendMessage",
 			Handler:    _InstagramService_SendMessage_Handler,
 		},
+		{
+			MethodName: "SendMedia",
+			Handler:    _InstagramService_SendMedia_Handler,
+		},
+		{
+			MethodName: "ReactToMessage",
+			Handler:    _InstagramService_ReactToMessage_Handler,
+		},
 		{
 			MethodName: "StartInteractiveChat",
 			Handler:    _InstagramService_StartInteractiveChat_Handler,
 		},
+		{
+			MethodName: "StartConversation",
+			Handler:    _InstagramService_StartConversation_Handler,
+		},
+		{
+			MethodName: "SearchMessages",
+			Handler:    _InstagramService_SearchMessages_Handler,
+		},
+		{
+			MethodName: "ScheduleMessage",
+			Handler:    _InstagramService_ScheduleMessage_Handler,
+		},
+		{
+			MethodName: "ListScheduled",
+			Handler:    _InstagramService_ListScheduled_Handler,
+		},
+		{
+			MethodName: "CancelScheduled",
+			Handler:    _InstagramService_CancelScheduled_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _InstagramService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "RenameGroup",
+			Handler:    _InstagramService_RenameGroup_Handler,
+		},
+		{
+			MethodName: "AddGroupMembers",
+			Handler:    _InstagramService_AddGroupMembers_Handler,
+		},
+		{
+			MethodName: "RemoveGroupMember",
+			Handler:    _InstagramService_RemoveGroupMember_Handler,
+		},
+		{
+			MethodName: "LeaveGroup",
+			Handler:    _InstagramService_LeaveGroup_Handler,
+		},
+		{
+			MethodName: "MuteChat",
+			Handler:    _InstagramService_MuteChat_Handler,
+		},
+		{
+			MethodName: "ListMessageRequests",
+			Handler:    _InstagramService_ListMessageRequests_Handler,
+		},
+		{
+			MethodName: "PreviewMessageRequest",
+			Handler:    _InstagramService_PreviewMessageRequest_Handler,
+		},
+		{
+			MethodName: "RespondToMessageRequest",
+			Handler:    _InstagramService_RespondToMessageRequest_Handler,
+		},
 		{
 			MethodName: "GetConfig",
 			Handler:    _InstagramService_GetConfig_Handler,
@@ -544,6 +1194,11 @@ var InstagramService_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Broadcast",
+			Handler:       _InstagramService_Broadcast_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "StreamMessages",
 			Handler:       _InstagramService_StreamMessages_Handler,